@@ -18,7 +18,9 @@ package actions
 import (
 	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"time"
@@ -32,6 +34,10 @@ import (
 )
 
 const (
+	// OptionAddress is the network address to listen on.
+	OptionAddress = "address"
+	// OptionAdopt is adopt option. Used for labeling imported cluster objects as managed by ksonnet.
+	OptionAdopt = "adopt"
 	// OptionApp is app option.
 	OptionApp = "app"
 	// OptionAppRoot is the root directory of the application.
@@ -52,10 +58,12 @@ const (
 	OptionDryRun = "dry-run"
 	// OptionEnvName is envName option.
 	OptionEnvName = "env-name"
-	// OptionEnvName1 is envName1. Used for param diff.
+	// OptionEnvName1 is envName1. Used for param diff and env promote.
 	OptionEnvName1 = "env-name-1"
-	// OptionEnvName2 is envName1. Used for param diff.
+	// OptionEnvName2 is envName1. Used for param diff and env promote.
 	OptionEnvName2 = "env-name-2"
+	// OptionExplain is whether to show where rendered objects came from.
+	OptionExplain = "explain"
 	// OptionExtVarFiles is jsonnet ext var files.
 	OptionExtVarFiles = "ext-vars-files"
 	// OptionExtVars is jsonnet ext vars.
@@ -64,6 +72,8 @@ const (
 	OptionForce = "force"
 	// OptionFormat is format option.
 	OptionFormat = "format"
+	// OptionFromCluster is fromCluster option. Used for importing objects from a cluster.
+	OptionFromCluster = "from-cluster"
 	// OptionFs is fs option.
 	OptionFs = "fs"
 	// OptionGcTag is gcTag option.
@@ -76,8 +86,14 @@ const (
 	OptionHTTPClient = "http-client"
 	// OptionInstalled is for listing installed packages.
 	OptionInstalled = "only-installed"
+	// OptionInteractive is interactive option. It prompts for values which aren't set.
+	OptionInteractive = "interactive"
 	// OptionJPaths is jsonnet paths.
 	OptionJPaths = "jpaths"
+	// OptionJUnit is the path of a JUnit XML report.
+	OptionJUnit = "junit"
+	// OptionKinds is kinds option.
+	OptionKinds = "kinds"
 	// OptionPkgName is (an optionally qualified) name of a package.
 	OptionPkgName = "pkg-name"
 	// OptionName is name option.
@@ -88,8 +104,12 @@ const (
 	OptionNamespace = "namespace"
 	// OptionNewRoot is init new root path option.
 	OptionNewRoot = "root-path"
+	// OptionNewComponentName is newComponentName option. Used for renaming components.
+	OptionNewComponentName = "new-component-name"
 	// OptionNewEnvName is newEnvName option. Used for renaming environments.
 	OptionNewEnvName = "new-env-name"
+	// OptionNoCache is no cache option. It disables the render cache.
+	OptionNoCache = "no-cache"
 	// OptionOutput is output option.
 	OptionOutput = "output"
 	// OptionOverride is override option.
@@ -100,39 +120,63 @@ const (
 	OptionPath = "path"
 	// OptionQuery is query option.
 	OptionQuery = "query"
+	// OptionReference is reference option. It is used to set a parameter as a reference to
+	// another component's parameter.
+	OptionReference = "reference"
+	// OptionRegistry is the name of a registry.
+	OptionRegistry = "registry"
 	// OptionResolveImage is resolve image option. It is used to resolve docker image references
 	// when setting parameters.
 	OptionResolveImage = "resolve-image"
+	// OptionSelector is a label selector option.
+	OptionSelector = "selector"
 	// OptionServer is server option.
 	OptionServer = "server"
 	// OptionServerURI is serverURI option.
 	OptionServerURI = "server-uri"
+	// OptionSingle is single option. Used by import to keep a multi-document file as one component.
+	OptionSingle = "single"
 	// OptionSkipCheckUpgrade tells app not to emit upgrade warnings, probably because the user is already upgrading.
 	OptionSkipCheckUpgrade = "skip-check-upgrade"
 	// OptionSkipDefaultRegistries is skipDefaultRegistries option. Used by init.
 	OptionSkipDefaultRegistries = "skip-default-registries"
 	// OptionSkipGc is skipGc option.
 	OptionSkipGc = "skip-gc"
+	// OptionSnapshotDir is the directory environment snapshots are stored in.
+	OptionSnapshotDir = "snapshot-dir"
 	// OptionSpecFlag is specFlag option. Used for setting k8s spec.
 	OptionSpecFlag = "spec-flag"
 	// OptionSrc1 is src1 option.
 	OptionSrc1 = "src-1"
 	// OptionSrc2 is src2 option.
 	OptionSrc2 = "src-2"
+	// OptionTemplate is the application template used by init.
+	OptionTemplate = "template"
+	// OptionTemplateType is a prototype template type.
+	OptionTemplateType = "template-type"
+	// OptionTimeout is timeout option. Used for limiting how long to wait.
+	OptionTimeout = "timeout"
 	// OptionTlaVarFiles is jsonnet tla var files.
 	OptionTlaVarFiles = "tla-var-files"
 	// OptionTlaVars is jsonnet tla vars.
 	OptionTlaVars = "tla-vars"
+	// OptionTLSCABundle is the path of a PEM file with additional certificate
+	// authorities to trust.
+	OptionTLSCABundle = "tls-ca-bundle"
 	// OptionTLSSkipVerify specifies that tls server certifactes should not be verified.
 	OptionTLSSkipVerify = "tls-skip-verify"
 	// OptionUnset is unset option.
 	OptionUnset = "unset"
 	// OptionURI is uri option. Used for setting registry URI.
 	OptionURI = "URI"
+	// OptionWait is wait option. Used for waiting until deleted objects are gone.
+	OptionWait = "wait"
 	// OptionWithoutModules is without modules option.
 	OptionWithoutModules = "without-modules"
 	// OptionValue is value option.
 	OptionValue = "value"
+	// OptionValuesFiles is values files option. Used for testing prototypes.
+	OptionValuesFiles = "values-files"
 	// OptionVersion is version option.
 	OptionVersion = "version"
 )
@@ -142,6 +186,8 @@ const (
 	OutputWide = "wide"
 	// OutputJSON is JSON output
 	OutputJSON = "json"
+	// OutputYAML is YAML output
+	OutputYAML = "yaml"
 )
 
 var (
@@ -282,6 +328,20 @@ func (o *optionLoader) LoadOptionalInt(name string) int {
 	return a
 }
 
+func (o *optionLoader) LoadOptionalDuration(name string) time.Duration {
+	i := o.loadOptional(name)
+	if i == nil {
+		return 0
+	}
+
+	a, ok := i.(time.Duration)
+	if !ok {
+		return 0
+	}
+
+	return a
+}
+
 func (o *optionLoader) LoadString(name string) string {
 	i := o.load(name)
 	if i == nil {
@@ -363,7 +423,8 @@ func (o *optionLoader) LoadApp() app.App {
 	}
 	var httpClient = o.LoadHTTPClient()
 	if httpClient == nil {
-		o.err = errors.New("initializing http client")
+		// LoadHTTPClient records why the client couldn't be created.
+		o.err = errors.Wrap(o.err, "initializing http client")
 		return nil
 	}
 	var appRoot = o.LoadOptionalString(OptionAppRoot)
@@ -405,6 +466,16 @@ func (o *optionLoader) LoadHTTPClient() *http.Client {
 		InsecureSkipVerify: tlsSkipVerify,
 	}
 
+	if caBundle := o.LoadOptionalString(OptionTLSCABundle); caBundle != "" {
+		pool, err := loadCertPool(caBundle)
+		if err != nil {
+			o.err = err
+			return nil
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
 	timeoutSeconds := 10
 
 	var defaultTransport http.RoundTripper = &http.Transport{
@@ -429,6 +500,26 @@ func (o *optionLoader) LoadHTTPClient() *http.Client {
 	return c
 }
 
+// loadCertPool returns the system certificate pool with the certificates in a
+// PEM file added.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading CA bundle")
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.Errorf("no certificates were found in CA bundle %s", path)
+	}
+
+	return pool, nil
+}
+
 func (o *optionLoader) load(key string) interface{} {
 	if o.err != nil {
 		return nil
@@ -16,11 +16,16 @@
 package actions
 
 import (
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ksonnet/ksonnet/pkg/app/mocks"
 	"github.com/ksonnet/ksonnet/pkg/client"
@@ -29,6 +34,7 @@ import (
 	rmocks "github.com/ksonnet/ksonnet/pkg/registry/mocks"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -171,6 +177,13 @@ func Test_optionLoader_optional_types(t *testing.T) {
 			expected: false,
 			keyName:  OptionApp,
 		},
+		{
+			name:     "Duration",
+			valid:    5 * time.Second,
+			invalid:  "invalid",
+			expected: time.Duration(0),
+			keyName:  OptionApp,
+		},
 		{
 			name:     "Int",
 			valid:    9,
@@ -238,6 +251,43 @@ func Test_optionLoader_optional_types(t *testing.T) {
 	}
 }
 
+func Test_optionLoader_LoadHTTPClient_caBundle(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "ca-bundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	caBundle := filepath.Join(dir, "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caBundle, data, 0644))
+
+	ol := newOptionLoader(map[string]interface{}{})
+	_, err = ol.LoadHTTPClient().Get(ts.URL)
+	require.Error(t, err, "expected the test server's certificate to be untrusted")
+
+	ol = newOptionLoader(map[string]interface{}{OptionTLSCABundle: caBundle})
+	c := ol.LoadHTTPClient()
+	require.NoError(t, ol.err)
+
+	resp, err := c.Get(ts.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ol = newOptionLoader(map[string]interface{}{OptionTLSCABundle: filepath.Join(dir, "missing.pem")})
+	ol.LoadHTTPClient()
+	require.Error(t, ol.err)
+
+	ol = newOptionLoader(map[string]interface{}{
+		OptionFs:          afero.NewMemMapFs(),
+		OptionTLSCABundle: filepath.Join(dir, "missing.pem"),
+	})
+	ol.LoadApp()
+	require.Error(t, ol.err)
+	assert.Contains(t, ol.err.Error(), "initializing http client: reading CA bundle")
+}
+
 func withApp(t *testing.T, fn func(*mocks.App)) {
 	fs := afero.NewMemMapFs()
 
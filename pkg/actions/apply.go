@@ -43,7 +43,9 @@ type Apply struct {
 	create         bool
 	dryRun         bool
 	envName        string
+	force          bool
 	gcTag          string
+	noCache        bool
 	skipGc         bool
 
 	runApplyFn runApplyFn
@@ -59,8 +61,10 @@ func newApply(m map[string]interface{}, opts ...applyOpt) (*Apply, error) {
 		componentNames: ol.LoadStringSlice(OptionComponentNames),
 		create:         ol.LoadBool(OptionCreate),
 		dryRun:         ol.LoadBool(OptionDryRun),
+		force:          ol.LoadOptionalBool(OptionForce),
 		gcTag:          ol.LoadString(OptionGcTag),
 		skipGc:         ol.LoadBool(OptionSkipGc),
+		noCache:        ol.LoadOptionalBool(OptionNoCache),
 
 		runApplyFn: cluster.RunApply,
 	}
@@ -88,7 +92,9 @@ func (a *Apply) run() error {
 		Create:         a.create,
 		DryRun:         a.dryRun,
 		EnvName:        a.envName,
+		Force:          a.force,
 		GcTag:          a.gcTag,
+		NoCache:        a.noCache,
 		SkipGc:         a.skipGc,
 	}
 
@@ -58,8 +58,10 @@ func TestApply(t *testing.T) {
 					OptionCreate:         true,
 					OptionDryRun:         true,
 					OptionEnvName:        tc.envName,
+					OptionForce:          true,
 					OptionGcTag:          "gc-tag",
 					OptionSkipGc:         true,
+					OptionNoCache:        true,
 				}
 
 				expected := cluster.ApplyConfig{
@@ -69,7 +71,9 @@ func TestApply(t *testing.T) {
 					Create:         true,
 					DryRun:         true,
 					EnvName:        "default",
+					Force:          true,
 					GcTag:          "gc-tag",
+					NoCache:        true,
 					SkipGc:         true,
 				}
 
@@ -99,12 +99,10 @@ func (cl *ComponentList) listComponents(components []component.Component) error
 	})
 
 	t := table.New("componentList", cl.out)
-	f, err := table.DetectFormat(cl.output)
-	if err != nil {
+	if err := t.SetOutput(cl.output); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
 
-	t.SetFormat(f)
 	t.SetHeader([]string{"component", "type", "apiversion", "kind", "name"})
 	t.AppendBulk(rows)
 	return t.Render()
@@ -0,0 +1,71 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	log "github.com/sirupsen/logrus"
+)
+
+// RunComponentMv runs `component mv`
+func RunComponentMv(m map[string]interface{}) error {
+	cm, err := NewComponentMv(m)
+	if err != nil {
+		return err
+	}
+
+	return cm.Run()
+}
+
+// ComponentMv renames a component or moves it to another module.
+type ComponentMv struct {
+	app     app.App
+	name    string
+	newName string
+
+	componentMoveFn func(app.App, string, string) error
+}
+
+// NewComponentMv creates an instance of ComponentMv.
+func NewComponentMv(m map[string]interface{}) (*ComponentMv, error) {
+	ol := newOptionLoader(m)
+
+	cm := &ComponentMv{
+		app:     ol.LoadApp(),
+		name:    ol.LoadString(OptionComponentName),
+		newName: ol.LoadString(OptionNewComponentName),
+
+		componentMoveFn: component.Move,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	return cm, nil
+}
+
+// Run runs the ComponentMv action.
+func (cm *ComponentMv) Run() error {
+	if err := cm.componentMoveFn(cm.app, cm.name, cm.newName); err != nil {
+		return err
+	}
+
+	log.Warnf("Names of objects in the cluster will change the next time %q is applied as %q. "+
+		"Check the changes with `ks diff` before running `ks apply`.", cm.name, cm.newName)
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentMv(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		var didMove bool
+
+		moveFn := func(a app.App, oldName, newName string) error {
+			assert.Equal(t, "guestbook", oldName)
+			assert.Equal(t, "web.frontend", newName)
+			didMove = true
+			return nil
+		}
+
+		in := map[string]interface{}{
+			OptionApp:              appMock,
+			OptionComponentName:    "guestbook",
+			OptionNewComponentName: "web.frontend",
+		}
+
+		a, err := NewComponentMv(in)
+		require.NoError(t, err)
+
+		a.componentMoveFn = moveFn
+
+		err = a.Run()
+		require.NoError(t, err)
+
+		assert.True(t, didMove)
+	})
+}
+
+func TestComponentMv_move_fails(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:              appMock,
+			OptionComponentName:    "guestbook",
+			OptionNewComponentName: "frontend",
+		}
+
+		a, err := NewComponentMv(in)
+		require.NoError(t, err)
+
+		a.componentMoveFn = func(app.App, string, string) error {
+			return errors.New("failed")
+		}
+
+		err = a.Run()
+		require.Error(t, err)
+	})
+}
+
+func TestComponentMv_requires_app(t *testing.T) {
+	in := make(map[string]interface{})
+	_, err := NewComponentMv(in)
+	require.Error(t, err)
+}
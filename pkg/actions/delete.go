@@ -16,6 +16,8 @@
 package actions
 
 import (
+	"time"
+
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/client"
 	"github.com/ksonnet/ksonnet/pkg/cluster"
@@ -41,7 +43,10 @@ type Delete struct {
 	clientConfig   *client.Config
 	componentNames []string
 	envName        string
+	force          bool
 	gracePeriod    int64
+	timeout        time.Duration
+	wait           bool
 
 	runDeleteFn runDeleteFn
 }
@@ -54,7 +59,10 @@ func newDelete(m map[string]interface{}, opts ...deleteOpt) (*Delete, error) {
 		app:            ol.LoadApp(),
 		clientConfig:   ol.LoadClientConfig(),
 		componentNames: ol.LoadStringSlice(OptionComponentNames),
+		force:          ol.LoadOptionalBool(OptionForce),
 		gracePeriod:    ol.LoadInt64(OptionGracePeriod),
+		timeout:        ol.LoadOptionalDuration(OptionTimeout),
+		wait:           ol.LoadOptionalBool(OptionWait),
 
 		runDeleteFn: cluster.RunDelete,
 	}
@@ -80,7 +88,10 @@ func (d *Delete) run() error {
 		ClientConfig:   d.clientConfig,
 		ComponentNames: d.componentNames,
 		EnvName:        d.envName,
+		Force:          d.force,
 		GracePeriod:    d.gracePeriod,
+		Timeout:        d.timeout,
+		Wait:           d.wait,
 	}
 
 	return d.runDeleteFn(config)
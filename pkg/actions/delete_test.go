@@ -17,6 +17,7 @@ package actions
 
 import (
 	"testing"
+	"time"
 
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
 	"github.com/ksonnet/ksonnet/pkg/client"
@@ -56,7 +57,10 @@ func TestDelete(t *testing.T) {
 					OptionClientConfig:   &client.Config{},
 					OptionComponentNames: []string{},
 					OptionEnvName:        tc.envName,
+					OptionForce:          true,
 					OptionGracePeriod:    int64(3),
+					OptionTimeout:        time.Minute,
+					OptionWait:           true,
 				}
 
 				expected := cluster.DeleteConfig{
@@ -64,7 +68,10 @@ func TestDelete(t *testing.T) {
 					ClientConfig:   &client.Config{},
 					ComponentNames: []string{},
 					EnvName:        "default",
+					Force:          true,
 					GracePeriod:    3,
+					Timeout:        time.Minute,
+					Wait:           true,
 				}
 
 				runDeleteOpt := func(a *Delete) {
@@ -0,0 +1,94 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/pkg/errors"
+)
+
+// RunEnvClone runs `env clone`
+func RunEnvClone(m map[string]interface{}) error {
+	ec, err := NewEnvClone(m)
+	if err != nil {
+		return err
+	}
+
+	return ec.Run()
+}
+
+type envCloneFn func(a app.App, from, to string, d env.Destination, isOverride bool) error
+
+// EnvClone clones an environment.
+type EnvClone struct {
+	app        app.App
+	envName    string
+	newName    string
+	server     string
+	namespace  string
+	isOverride bool
+
+	envCloneFn envCloneFn
+}
+
+// NewEnvClone creates an instance of EnvClone.
+func NewEnvClone(m map[string]interface{}) (*EnvClone, error) {
+	ol := newOptionLoader(m)
+
+	ec := &EnvClone{
+		app:        ol.LoadApp(),
+		envName:    ol.LoadString(OptionEnvName),
+		newName:    ol.LoadString(OptionNewEnvName),
+		server:     ol.LoadOptionalString(OptionServer),
+		namespace:  ol.LoadOptionalString(OptionNamespace),
+		isOverride: ol.LoadOptionalBool(OptionOverride),
+
+		envCloneFn: env.Clone,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	return ec, nil
+}
+
+// Run clones the environment. The server and namespace of the source
+// environment are used unless they are overridden.
+func (ec *EnvClone) Run() error {
+	src, err := ec.app.Environment(ec.envName)
+	if err != nil {
+		return err
+	}
+
+	server, namespace := ec.server, ec.namespace
+	if src.Destination != nil {
+		if server == "" {
+			server = src.Destination.Server
+		}
+		if namespace == "" {
+			namespace = src.Destination.Namespace
+		}
+	}
+
+	if server == "" {
+		return errors.Errorf("environment %q does not have a server; set one with --server", ec.envName)
+	}
+
+	d := env.NewDestination(server, namespace)
+	return ec.envCloneFn(ec.app, ec.envName, ec.newName, d, ec.isOverride)
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvClone(t *testing.T) {
+	cases := []struct {
+		name      string
+		server    string
+		namespace string
+		srcDest   *app.EnvironmentDestinationSpec
+		expected  env.Destination
+		isErr     bool
+	}{
+		{
+			name:      "new destination",
+			server:    "http://prod.example.com",
+			namespace: "prod",
+			srcDest:   &app.EnvironmentDestinationSpec{Server: "http://example.com", Namespace: "staging"},
+			expected:  env.NewDestination("http://prod.example.com", "prod"),
+		},
+		{
+			name:      "source server",
+			namespace: "prod",
+			srcDest:   &app.EnvironmentDestinationSpec{Server: "http://example.com", Namespace: "staging"},
+			expected:  env.NewDestination("http://example.com", "prod"),
+		},
+		{
+			name:     "source destination",
+			srcDest:  &app.EnvironmentDestinationSpec{Server: "http://example.com", Namespace: "staging"},
+			expected: env.NewDestination("http://example.com", "staging"),
+		},
+		{
+			name:  "no server",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				appMock.On("Environment", "staging").Return(&app.EnvironmentConfig{
+					Name:        "staging",
+					Destination: tc.srcDest,
+				}, nil)
+
+				in := map[string]interface{}{
+					OptionApp:        appMock,
+					OptionEnvName:    "staging",
+					OptionNewEnvName: "prod",
+					OptionServer:     tc.server,
+					OptionNamespace:  tc.namespace,
+					OptionOverride:   false,
+				}
+
+				a, err := NewEnvClone(in)
+				require.NoError(t, err)
+
+				var called bool
+				a.envCloneFn = func(a app.App, from, to string, d env.Destination, isOverride bool) error {
+					called = true
+					assert.Equal(t, "staging", from)
+					assert.Equal(t, "prod", to)
+					assert.Equal(t, tc.expected, d)
+					assert.False(t, isOverride)
+					return nil
+				}
+
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.True(t, called)
+			})
+		})
+	}
+}
+
+func TestEnvClone_requires_app(t *testing.T) {
+	in := make(map[string]interface{})
+	_, err := NewEnvClone(in)
+	require.Error(t, err)
+}
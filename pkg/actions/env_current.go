@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 	"github.com/pkg/errors"
 )
 
@@ -36,9 +37,10 @@ func RunEnvCurrent(m map[string]interface{}) error {
 
 // EnvCurrent sets/unsets the current environment
 type EnvCurrent struct {
-	app     app.App
-	envName string
-	unset   bool
+	app        app.App
+	envName    string
+	outputType string
+	unset      bool
 
 	out io.Writer
 }
@@ -48,9 +50,10 @@ func newEnvCurrent(m map[string]interface{}) (*EnvCurrent, error) {
 	ol := newOptionLoader(m)
 
 	d := &EnvCurrent{
-		app:     ol.LoadApp(),
-		envName: ol.LoadOptionalString(OptionEnvName),
-		unset:   ol.LoadBool(OptionUnset),
+		app:        ol.LoadApp(),
+		envName:    ol.LoadOptionalString(OptionEnvName),
+		outputType: ol.LoadOptionalString(OptionOutput),
+		unset:      ol.LoadBool(OptionUnset),
 
 		out: os.Stdout,
 	}
@@ -72,7 +75,17 @@ func (e *EnvCurrent) run() error {
 	} else if e.envName != "" {
 		return e.app.SetCurrentEnvironment(e.envName)
 	} else {
-		fmt.Fprintln(e.out, e.app.CurrentEnvironment())
-		return nil
+		current := envCurrentDescription{Name: e.app.CurrentEnvironment()}
+
+		return table.RenderObject(e.out, "envCurrent", e.outputType, current, func(w io.Writer) error {
+			_, err := fmt.Fprintln(w, current.Name)
+			return err
+		})
 	}
 }
+
+// envCurrentDescription is the structured output of `env current`. Name is
+// empty if there is no current environment.
+type envCurrentDescription struct {
+	Name string `json:"name"`
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,15 +30,29 @@ func TestEnvCurrent(t *testing.T) {
 		envName     string
 		currentName string
 		unset       bool
+		outputType  string
 		output      string
 		isErr       bool
 	}{
 		{
-			name: "show current environment with no current environment",
+			name:   "show current environment with no current environment",
+			output: "\n",
 		},
 		{
 			name:        "show current environment with current environment set",
 			currentName: "default",
+			output:      "default\n",
+		},
+		{
+			name:        "show current environment as json",
+			currentName: "default",
+			outputType:  "json",
+			output:      "{\n\t\"kind\": \"envCurrent\",\n\t\"data\": {\n\t\t\"name\": \"default\"\n\t}\n}\n",
+		},
+		{
+			name:        "show current environment with go-template",
+			currentName: "default",
+			outputType:  "go-template={{.data.name}}",
 			output:      "default",
 		},
 		{
@@ -65,6 +80,7 @@ func TestEnvCurrent(t *testing.T) {
 				in := map[string]interface{}{
 					OptionApp:     appMock,
 					OptionEnvName: tc.envName,
+					OptionOutput:  tc.outputType,
 					OptionUnset:   tc.unset,
 				}
 
@@ -80,6 +96,8 @@ func TestEnvCurrent(t *testing.T) {
 					return
 				}
 				require.NoError(t, err)
+
+				assert.Equal(t, tc.output, buf.String())
 			})
 		})
 	}
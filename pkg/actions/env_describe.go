@@ -20,6 +20,7 @@ import (
 	"os"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -36,9 +37,17 @@ func RunEnvDescribe(m map[string]interface{}) error {
 
 // EnvDescribe describes an environment by printing its configuration.
 type EnvDescribe struct {
-	app     app.App
-	envName string
-	out     io.Writer
+	app        app.App
+	envName    string
+	outputType string
+	out        io.Writer
+}
+
+// envDescription is the structured output of `env describe`. The
+// environment's fields are named as they are in app.yaml.
+type envDescription struct {
+	Name string `json:"name"`
+	*app.EnvironmentConfig
 }
 
 // NewEnvDescribe creates an instance of EnvDescribe.
@@ -46,8 +55,9 @@ func NewEnvDescribe(m map[string]interface{}) (*EnvDescribe, error) {
 	ol := newOptionLoader(m)
 
 	ed := &EnvDescribe{
-		app:     ol.LoadApp(),
-		envName: ol.LoadString(OptionEnvName),
+		app:        ol.LoadApp(),
+		envName:    ol.LoadString(OptionEnvName),
+		outputType: ol.LoadOptionalString(OptionOutput),
 
 		out: os.Stdout,
 	}
@@ -68,11 +78,18 @@ func (ed *EnvDescribe) Run() error {
 
 	env.Name = ed.envName
 
-	b, err := yaml.Marshal(env)
-	if err != nil {
-		return err
+	desc := envDescription{
+		Name:              ed.envName,
+		EnvironmentConfig: env,
 	}
 
-	_, err = ed.out.Write(b)
-	return err
+	return table.RenderObject(ed.out, "envDescribe", ed.outputType, desc, func(w io.Writer) error {
+		b, err := yaml.Marshal(env)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(b)
+		return err
+	})
 }
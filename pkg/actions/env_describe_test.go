@@ -25,31 +25,71 @@ import (
 )
 
 func TestEnvDescribe(t *testing.T) {
-	withApp(t, func(appMock *amocks.App) {
-		envName := "default"
+	cases := []struct {
+		name       string
+		outputType string
+		outputFile string
+		isErr      bool
+	}{
+		{
+			name:       "default",
+			outputFile: "env/describe/output.txt",
+		},
+		{
+			name:       "json",
+			outputType: "json",
+			outputFile: "env/describe/output.json",
+		},
+		{
+			name:       "yaml",
+			outputType: "yaml",
+			outputFile: "env/describe/output.yaml",
+		},
+		{
+			name:       "invalid output type",
+			outputType: "invalid",
+			isErr:      true,
+		},
+	}
 
-		env := &app.EnvironmentConfig{
-			KubernetesVersion: "v1.7.0",
-		}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				envName := "default"
 
-		appMock.On("Environment", envName).Return(env, nil)
+				env := &app.EnvironmentConfig{
+					KubernetesVersion: "v1.7.0",
+					Destination: &app.EnvironmentDestinationSpec{
+						Server:    "http://example.com",
+						Namespace: "default",
+					},
+				}
 
-		in := map[string]interface{}{
-			OptionApp:     appMock,
-			OptionEnvName: envName,
-		}
+				appMock.On("Environment", envName).Return(env, nil)
 
-		a, err := NewEnvDescribe(in)
-		require.NoError(t, err)
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionEnvName: envName,
+					OptionOutput:  tc.outputType,
+				}
 
-		var buf bytes.Buffer
-		a.out = &buf
+				a, err := NewEnvDescribe(in)
+				require.NoError(t, err)
 
-		err = a.Run()
-		require.NoError(t, err)
+				var buf bytes.Buffer
+				a.out = &buf
 
-		assertOutput(t, "env/describe/output.txt", buf.String())
-	})
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+
+				assertOutput(t, tc.outputFile, buf.String())
+			})
+		})
+	}
 }
 
 func TestEnvDescribe_requires_app(t *testing.T) {
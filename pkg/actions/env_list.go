@@ -75,11 +75,9 @@ func (el *EnvList) Run() error {
 	t := table.New("envList", el.out)
 	t.SetHeader([]string{"name", "override", "kubernetes-version", "namespace", "server"})
 
-	f, err := table.DetectFormat(el.outputType)
-	if err != nil {
+	if err := t.SetOutput(el.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
 	var rows [][]string
 
@@ -0,0 +1,242 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	utilio "github.com/ksonnet/ksonnet/pkg/util/io"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// RunEnvPromote runs `env promote`
+func RunEnvPromote(m map[string]interface{}) error {
+	ep, err := NewEnvPromote(m)
+	if err != nil {
+		return err
+	}
+
+	return ep.Run()
+}
+
+// EnvPromote promotes environment params from one environment to another.
+type EnvPromote struct {
+	app           app.App
+	srcName       string
+	dstName       string
+	componentName string
+	dryRun        bool
+
+	out          io.Writer
+	envEntriesFn func(envName string) ([]params.Entry, error)
+	setParamsFn  func(envName string, updates map[string]map[string]interface{}) error
+}
+
+// NewEnvPromote creates an instance of EnvPromote.
+func NewEnvPromote(m map[string]interface{}) (*EnvPromote, error) {
+	ol := newOptionLoader(m)
+
+	ep := &EnvPromote{
+		app:           ol.LoadApp(),
+		srcName:       ol.LoadString(OptionEnvName1),
+		dstName:       ol.LoadString(OptionEnvName2),
+		componentName: ol.LoadOptionalString(OptionComponentName),
+		dryRun:        ol.LoadOptionalBool(OptionDryRun),
+
+		out: os.Stdout,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	ep.envEntriesFn = ep.envEntries
+	ep.setParamsFn = func(envName string, updates map[string]map[string]interface{}) error {
+		return setEnvParamValues(ep.app, envName, updates)
+	}
+
+	return ep, nil
+}
+
+// Run shows the params which differ between the environments, and sets the
+// source environment's values in the destination environment.
+func (ep *EnvPromote) Run() error {
+	if ep.srcName == ep.dstName {
+		return errors.Errorf("cannot promote environment %q to itself", ep.srcName)
+	}
+
+	srcEntries, err := ep.envEntriesFn(ep.srcName)
+	if err != nil {
+		return errors.Wrapf(err, "listing params for environment %q", ep.srcName)
+	}
+
+	dstEntries, err := ep.envEntriesFn(ep.dstName)
+	if err != nil {
+		return errors.Wrapf(err, "listing params for environment %q", ep.dstName)
+	}
+
+	dstValues := make(map[string]string)
+	for _, e := range dstEntries {
+		dstValues[e.ComponentName+"."+e.ParamName] = e.Value
+	}
+
+	var rows [][]string
+	updates := make(map[string]map[string]interface{})
+
+	for _, e := range srcEntries {
+		if ep.componentName != "" && ep.componentName != e.ComponentName {
+			continue
+		}
+
+		dstValue, ok := dstValues[e.ComponentName+"."+e.ParamName]
+		if ok && dstValue == e.Value {
+			continue
+		}
+
+		if e.Reference != "" {
+			log.Warnf("skipping %s.%s: params which reference %s can't be promoted", e.ComponentName, e.ParamName, e.Reference)
+			continue
+		}
+
+		v, err := decodeParamValue(e.Value)
+		if err != nil {
+			return errors.Wrapf(err, "decoding %s.%s", e.ComponentName, e.ParamName)
+		}
+
+		if updates[e.ComponentName] == nil {
+			updates[e.ComponentName] = make(map[string]interface{})
+		}
+		updates[e.ComponentName][e.ParamName] = v
+
+		rows = append(rows, []string{e.ComponentName, e.ParamName, e.Value, dstValue})
+	}
+
+	if len(rows) == 0 {
+		log.Infof("Environment %q has no params which differ from %q", ep.dstName, ep.srcName)
+		return nil
+	}
+
+	t := table.New("envPromote", ep.out)
+	t.SetHeader([]string{"component", "param", ep.srcName, ep.dstName})
+	t.AppendBulk(rows)
+	if err := t.Render(); err != nil {
+		return err
+	}
+
+	if ep.dryRun {
+		return nil
+	}
+
+	if err := ep.setParamsFn(ep.dstName, updates); err != nil {
+		return err
+	}
+
+	log.Infof("Promoted %d params from %q to %q", len(rows), ep.srcName, ep.dstName)
+	return nil
+}
+
+// envEntries lists the params set in an environment's params file. Component
+// names are qualified with their module.
+func (ep *EnvPromote) envEntries(envName string) ([]params.Entry, error) {
+	p := pipeline.New(ep.app, envName)
+
+	modules, err := p.Modules()
+	if err != nil {
+		return nil, err
+	}
+
+	lister := params.NewLister(ep.app.Root(), app.EnvironmentDestinationSpec{})
+
+	var entries []params.Entry
+	for _, m := range modules {
+		source, err := p.EnvParameters(m.Name(), false)
+		if err != nil {
+			return nil, err
+		}
+
+		moduleEntries, err := lister.List(strings.NewReader(source), "")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range moduleEntries {
+			e.ComponentName = qualifyComponentName(m.Name(), e.ComponentName)
+			entries = append(entries, e)
+		}
+	}
+
+	return entries, nil
+}
+
+// decodeParamValue decodes a param value listed by params.Lister.
+func decodeParamValue(s string) (interface{}, error) {
+	vm := jsonnet.NewVM()
+	out, err := vm.EvaluateSnippet("value", s)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		return nil, err
+	}
+
+	return normalizeValue(v), nil
+}
+
+// setEnvParamValues sets component param values in an environment's params
+// file. Updates are keyed by component name.
+func setEnvParamValues(a app.App, envName string, updates map[string]map[string]interface{}) error {
+	path, err := env.Path(a, envName, "params.libsonnet")
+	if err != nil {
+		return err
+	}
+
+	data, err := afero.ReadFile(a.Fs(), path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	src := string(data)
+
+	var names []string
+	for name := range updates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	eps := params.NewEnvParamSet()
+	for _, name := range names {
+		if src, err = eps.SetValues(name, src, updates[name]); err != nil {
+			return errors.Wrapf(err, "setting params for component %q", name)
+		}
+	}
+
+	ft := utilio.NewFileTransaction(a.Fs())
+	ft.Write(path, []byte(src))
+	return ft.Commit()
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvPromote(t *testing.T) {
+	entries := map[string][]params.Entry{
+		"staging": {
+			{ComponentName: "guestbook", ParamName: "image", Value: "'gb:2'"},
+			{ComponentName: "guestbook", ParamName: "labels", Value: "{ app: 'gb' }"},
+			{ComponentName: "guestbook", ParamName: "replicas", Value: "3"},
+			{ComponentName: "nested.redis", ParamName: "port", Value: "6380"},
+			{ComponentName: "proxy", ParamName: "image", Value: "'gb:2'", Reference: "guestbook.image"},
+		},
+		"prod": {
+			{ComponentName: "guestbook", ParamName: "image", Value: "'gb:1'"},
+			{ComponentName: "guestbook", ParamName: "replicas", Value: "3"},
+			{ComponentName: "guestbook", ParamName: "tier", Value: "'prod'"},
+		},
+	}
+
+	cases := []struct {
+		name          string
+		componentName string
+		dryRun        bool
+		expected      map[string]map[string]interface{}
+		expectedFile  string
+	}{
+		{
+			name: "all components",
+			expected: map[string]map[string]interface{}{
+				"guestbook":    {"image": "gb:2", "labels": map[string]interface{}{"app": "gb"}},
+				"nested.redis": {"port": 6380},
+			},
+			expectedFile: filepath.Join("env", "promote", "output.txt"),
+		},
+		{
+			name:          "component",
+			componentName: "nested.redis",
+			expected: map[string]map[string]interface{}{
+				"nested.redis": {"port": 6380},
+			},
+			expectedFile: filepath.Join("env", "promote", "component.txt"),
+		},
+		{
+			name:         "dry run",
+			dryRun:       true,
+			expectedFile: filepath.Join("env", "promote", "output.txt"),
+		},
+		{
+			name:          "no differences",
+			componentName: "missing",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				in := map[string]interface{}{
+					OptionApp:           appMock,
+					OptionEnvName1:      "staging",
+					OptionEnvName2:      "prod",
+					OptionComponentName: tc.componentName,
+					OptionDryRun:        tc.dryRun,
+				}
+
+				a, err := NewEnvPromote(in)
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				a.out = &buf
+
+				a.envEntriesFn = func(envName string) ([]params.Entry, error) {
+					return entries[envName], nil
+				}
+
+				var got map[string]map[string]interface{}
+				a.setParamsFn = func(envName string, updates map[string]map[string]interface{}) error {
+					assert.Equal(t, "prod", envName)
+					got = updates
+					return nil
+				}
+
+				err = a.Run()
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expected, got)
+
+				if tc.expectedFile == "" {
+					assert.Empty(t, buf.String())
+					return
+				}
+				assertOutput(t, tc.expectedFile, buf.String())
+			})
+		})
+	}
+}
+
+func TestEnvPromote_errors(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:      appMock,
+			OptionEnvName1: "staging",
+			OptionEnvName2: "staging",
+		}
+
+		a, err := NewEnvPromote(in)
+		require.NoError(t, err)
+
+		err = a.Run()
+		require.Error(t, err)
+
+		a.dstName = "prod"
+		a.envEntriesFn = func(envName string) ([]params.Entry, error) {
+			return nil, errors.New("failed")
+		}
+
+		err = a.Run()
+		require.Error(t, err)
+	})
+}
+
+func TestEnvPromote_requires_app(t *testing.T) {
+	in := make(map[string]interface{})
+	_, err := NewEnvPromote(in)
+	require.Error(t, err)
+}
@@ -0,0 +1,243 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/ksonnet/ksonnet/pkg/util/dockerregistry"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RunImagesList runs `images list`.
+func RunImagesList(m map[string]interface{}) error {
+	il, err := NewImagesList(m)
+	if err != nil {
+		return err
+	}
+
+	return il.Run()
+}
+
+// imageUse is a container image used by a component.
+type imageUse struct {
+	// component is the component's name, prefixed with its module.
+	component string
+	image     string
+	// params are the paths of the component params which set the image.
+	params []string
+}
+
+func (u *imageUse) pinned() bool {
+	n, err := dockerregistry.ParseImageName(u.image)
+	return err == nil && n.Digest != ""
+}
+
+// imagesCollector finds the container images used in an environment.
+type imagesCollector struct {
+	objectsFn       func() ([]*unstructured.Unstructured, error)
+	paramsCollector *envParamsCollector
+}
+
+func newImagesCollector(a app.App, envName string) *imagesCollector {
+	p := pipeline.New(a, envName, pipeline.WithProvenance())
+
+	return &imagesCollector{
+		objectsFn: func() ([]*unstructured.Unstructured, error) {
+			return p.Objects(nil)
+		},
+		paramsCollector: newEnvParamsCollector(a, envName),
+	}
+}
+
+// collect returns the images used by each component sorted by component and
+// image, and the environment's params.
+func (c *imagesCollector) collect() ([]*imageUse, *paramsDocument, error) {
+	objects, err := c.objectsFn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := c.paramsCollector.collect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := make(map[string]bool)
+	var uses []*imageUse
+
+	for _, obj := range objects {
+		var componentName string
+
+		p, ok, err := pipeline.ObjectProvenance(obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			componentName = qualifyComponentName(p.Module, p.Component)
+		}
+
+		for _, image := range objectImages(obj.Object) {
+			key := componentName + "\x00" + image
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			uses = append(uses, &imageUse{
+				component: componentName,
+				image:     image,
+				params:    imageParams("", doc.Components[componentName], image),
+			})
+		}
+	}
+
+	sort.Slice(uses, func(i, j int) bool {
+		if uses[i].component != uses[j].component {
+			return uses[i].component < uses[j].component
+		}
+		return uses[i].image < uses[j].image
+	})
+
+	return uses, doc, nil
+}
+
+// ImagesList lists the container images used in an environment.
+type ImagesList struct {
+	app        app.App
+	envName    string
+	outputType string
+
+	out       io.Writer
+	collector *imagesCollector
+}
+
+// NewImagesList creates an instance of ImagesList.
+func NewImagesList(m map[string]interface{}) (*ImagesList, error) {
+	ol := newOptionLoader(m)
+
+	il := &ImagesList{
+		app:        ol.LoadApp(),
+		outputType: ol.LoadOptionalString(OptionOutput),
+
+		out: os.Stdout,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if err := setCurrentEnv(il.app, il, ol); err != nil {
+		return nil, err
+	}
+
+	il.collector = newImagesCollector(il.app, il.envName)
+
+	return il, nil
+}
+
+// Run runs the action.
+func (il *ImagesList) Run() error {
+	uses, _, err := il.collector.collect()
+	if err != nil {
+		return err
+	}
+
+	t := table.New("imagesList", il.out)
+
+	if err := t.SetOutput(il.outputType); err != nil {
+		return errors.Wrap(err, "detecting output format")
+	}
+
+	t.SetHeader([]string{"component", "image", "pinned", "params"})
+
+	for _, use := range uses {
+		pinned := "no"
+		if use.pinned() {
+			pinned = "yes"
+		}
+
+		t.Append([]string{use.component, use.image, pinned, strings.Join(use.params, ", ")})
+	}
+
+	return t.Render()
+}
+
+func (il *ImagesList) setCurrentEnv(name string) {
+	il.envName = name
+}
+
+// objectImages returns the images of the containers and init containers in an
+// object, including containers in pod templates.
+func objectImages(v interface{}) []string {
+	var images []string
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if key == "containers" || key == "initContainers" {
+				containers, _ := value.([]interface{})
+				for _, container := range containers {
+					m, _ := container.(map[string]interface{})
+					if image, ok := m["image"].(string); ok && image != "" {
+						images = append(images, image)
+					}
+				}
+			}
+
+			images = append(images, objectImages(value)...)
+		}
+	case []interface{}:
+		for _, item := range t {
+			images = append(images, objectImages(item)...)
+		}
+	}
+
+	sort.Strings(images)
+	return images
+}
+
+// imageParams returns the sorted paths of the params whose value is image.
+// Nested params are separated by dots.
+func imageParams(prefix string, values map[string]interface{}, image string) []string {
+	var paths []string
+
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch t := value.(type) {
+		case string:
+			if t == image {
+				paths = append(paths, path)
+			}
+		case map[string]interface{}:
+			paths = append(paths, imageParams(path, t, image)...)
+		}
+	}
+
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/util/dockerregistry"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// RunImagesPin runs `images pin`.
+func RunImagesPin(m map[string]interface{}) error {
+	ip, err := NewImagesPin(m)
+	if err != nil {
+		return err
+	}
+
+	return ip.Run()
+}
+
+// ImagesPin replaces the image tags used in an environment with digests. The
+// digests are set in the environment's params.
+type ImagesPin struct {
+	app     app.App
+	envName string
+
+	out       io.Writer
+	collector *imagesCollector
+	resolver  dockerregistry.Resolver
+}
+
+// NewImagesPin creates an instance of ImagesPin.
+func NewImagesPin(m map[string]interface{}) (*ImagesPin, error) {
+	ol := newOptionLoader(m)
+
+	ip := &ImagesPin{
+		app: ol.LoadApp(),
+
+		out: os.Stdout,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	ip.resolver = newImageResolver(ip.app.HTTPClient())
+
+	if err := setCurrentEnv(ip.app, ip, ol); err != nil {
+		return nil, err
+	}
+
+	ip.collector = newImagesCollector(ip.app, ip.envName)
+
+	return ip, nil
+}
+
+// Run runs the action.
+func (ip *ImagesPin) Run() error {
+	uses, doc, err := ip.collector.collect()
+	if err != nil {
+		return err
+	}
+
+	// updates are the top level params to set for each component.
+	updates := make(map[string]map[string]interface{})
+
+	for _, use := range uses {
+		if use.pinned() {
+			continue
+		}
+
+		if len(use.params) == 0 {
+			logrus.Warnf("image %s in component %q is not set by a parameter, so it can't be pinned",
+				use.image, use.component)
+			continue
+		}
+
+		n, err := dockerregistry.ParseImageName(use.image)
+		if err != nil {
+			return errors.Wrapf(err, "parsing image %s", use.image)
+		}
+
+		if err := ip.resolver.Resolve(&n); err != nil {
+			return errors.Wrapf(err, "resolving image %s", use.image)
+		}
+
+		values := doc.Components[use.component]
+		if updates[use.component] == nil {
+			updates[use.component] = make(map[string]interface{})
+		}
+
+		for _, param := range use.params {
+			path := strings.Split(param, ".")
+			setParamValue(values, path, n.String())
+			updates[use.component][path[0]] = values[path[0]]
+
+			fmt.Fprintf(ip.out, "pinned %s.%s to %s\n", use.component, param, n.String())
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return ip.write(updates)
+}
+
+// write sets the updated params in the environment's params file.
+func (ip *ImagesPin) write(updates map[string]map[string]interface{}) error {
+	return setEnvParamValues(ip.app, ip.envName, updates)
+}
+
+func (ip *ImagesPin) setCurrentEnv(name string) {
+	ip.envName = name
+}
+
+// newImageResolver creates an image resolver which uses httpClient's transport
+// and authenticates with registries using the docker config when they require
+// it.
+func newImageResolver(httpClient *http.Client) dockerregistry.Resolver {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return dockerregistry.NewResolver(&http.Client{
+		Transport: dockerregistry.NewAuthTransport(transport),
+		Timeout:   httpClient.Timeout,
+	})
+}
+
+// resolveImage resolves an image to its digest with httpClient.
+func resolveImage(httpClient *http.Client, image string) (string, error) {
+	n, err := dockerregistry.ParseImageName(image)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing image name")
+	}
+
+	if err := newImageResolver(httpClient).Resolve(&n); err != nil {
+		return "", errors.Wrap(err, "resolving image")
+	}
+
+	return n.String(), nil
+}
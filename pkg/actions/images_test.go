@@ -0,0 +1,286 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// stubImagesCollector returns a collector for an environment with a web
+// component whose image is set by params, and a db component whose image is
+// not.
+func stubImagesCollector() *imagesCollector {
+	web := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "web",
+			"annotations": map[string]interface{}{
+				metadata.AnnotationProvenance: `{"module":"/","component":"web"}`,
+			},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"initContainers": []interface{}{
+						map[string]interface{}{"name": "init", "image": "busybox@sha256:1234"},
+					},
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "registry.example.com/team/web:1.0"},
+						map[string]interface{}{"name": "proxy", "image": "registry.example.com/team/proxy:2.0"},
+					},
+				},
+			},
+		},
+	}}
+
+	db := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "db",
+			"annotations": map[string]interface{}{
+				metadata.AnnotationProvenance: `{"module":"/","component":"db"}`,
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "db", "image": "registry.example.com/team/db:9.6"},
+			},
+		},
+	}}
+
+	root := &cmocks.Module{}
+	root.On("Name").Return("/")
+
+	return &imagesCollector{
+		objectsFn: func() ([]*unstructured.Unstructured, error) {
+			return []*unstructured.Unstructured{web, db}, nil
+		},
+		paramsCollector: &envParamsCollector{
+			modulesFn: func() ([]component.Module, error) {
+				return []component.Module{root}, nil
+			},
+			moduleParametersFn: func(component.Module) (string, error) {
+				return `{"components": {
+					"web": {
+						"image": "registry.example.com/team/web:1.0",
+						"sidecar": {"image": "registry.example.com/team/proxy:2.0", "port": 8080},
+						"replicas": 3
+					},
+					"db": {"replicas": 2}
+				}}`, nil
+			},
+			envGlobalsFn: func() (string, error) {
+				return `{}`, nil
+			},
+		},
+	}
+}
+
+// newFakeRegistry starts a registry which has digests for the web and proxy
+// images. The returned client sends every request to the registry.
+func newFakeRegistry(t *testing.T) (*httptest.Server, *http.Client) {
+	digests := map[string]string{
+		"/v2/team/web/manifests/1.0":   "sha256:aaaa",
+		"/v2/team/proxy/manifests/2.0": "sha256:bbbb",
+	}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		digest, ok := digests[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Docker-Content-Digest", digest)
+	}))
+
+	c := &http.Client{
+		Timeout: 1 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, ts.Listener.Addr().String())
+			},
+		},
+	}
+
+	return ts, c
+}
+
+func TestImagesList(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		cases := []struct {
+			name         string
+			outputType   string
+			expectedFile string
+		}{
+			{
+				name:         "table",
+				expectedFile: "images/list.txt",
+			},
+			{
+				name:         "json",
+				outputType:   OutputJSON,
+				expectedFile: "images/list.json",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionEnvName: "default",
+					OptionOutput:  tc.outputType,
+				}
+
+				a, err := NewImagesList(in)
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				a.out = &buf
+				a.collector = stubImagesCollector()
+
+				err = a.Run()
+				require.NoError(t, err)
+
+				assertOutput(t, tc.expectedFile, buf.String())
+			})
+		}
+	})
+}
+
+func TestImagesPin(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		appMock.On("Environment", "default").Return(&app.EnvironmentConfig{Path: "default"}, nil)
+		stageFile(t, appMock.Fs(), "images/params.libsonnet", "/environments/default/params.libsonnet")
+
+		ts, c := newFakeRegistry(t)
+		defer ts.Close()
+
+		appMock.On("HTTPClient").Return(c)
+
+		in := map[string]interface{}{
+			OptionApp:     appMock,
+			OptionEnvName: "default",
+		}
+
+		a, err := NewImagesPin(in)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		a.out = &buf
+		a.collector = stubImagesCollector()
+
+		err = a.Run()
+		require.NoError(t, err)
+
+		assertOutput(t, "images/pin.txt", buf.String())
+
+		got, err := afero.ReadFile(appMock.Fs(), "/environments/default/params.libsonnet")
+		require.NoError(t, err)
+		assertOutput(t, "images/pin-params.libsonnet", string(got))
+
+		// the params file is replaced without leaving staged files behind.
+		fis, err := afero.ReadDir(appMock.Fs(), "/environments/default")
+		require.NoError(t, err)
+		for _, fi := range fis {
+			assert.False(t, strings.HasPrefix(fi.Name(), "kstemp-"), "staged file %s was left behind", fi.Name())
+		}
+	})
+}
+
+func TestImagesPin_unknown_image(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		appMock.On("Environment", "default").Return(&app.EnvironmentConfig{Path: "default"}, nil)
+		stageFile(t, appMock.Fs(), "images/params.libsonnet", "/environments/default/params.libsonnet")
+
+		ts, c := newFakeRegistry(t)
+		defer ts.Close()
+
+		appMock.On("HTTPClient").Return(c)
+
+		in := map[string]interface{}{
+			OptionApp:     appMock,
+			OptionEnvName: "default",
+		}
+
+		a, err := NewImagesPin(in)
+		require.NoError(t, err)
+
+		a.out = &bytes.Buffer{}
+		a.collector = stubImagesCollector()
+		a.collector.paramsCollector.moduleParametersFn = func(component.Module) (string, error) {
+			return `{"components": {"web": {"image": "registry.example.com/team/web:missing"}}}`, nil
+		}
+		a.collector.objectsFn = func() ([]*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						metadata.AnnotationProvenance: `{"module":"/","component":"web"}`,
+					},
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"image": "registry.example.com/team/web:missing"},
+					},
+				},
+			}}
+			return []*unstructured.Unstructured{obj}, nil
+		}
+
+		err = a.Run()
+		require.Error(t, err)
+
+		// nothing is written when an image can't be resolved.
+		got, err := afero.ReadFile(appMock.Fs(), "/environments/default/params.libsonnet")
+		require.NoError(t, err)
+		assertOutput(t, "images/params.libsonnet", string(got))
+	})
+}
+
+func Test_objectImages(t *testing.T) {
+	obj := stubImagesCollector()
+	objects, err := obj.objectsFn()
+	require.NoError(t, err)
+
+	expected := []string{
+		"busybox@sha256:1234",
+		"registry.example.com/team/proxy:2.0",
+		"registry.example.com/team/web:1.0",
+	}
+	assert.Equal(t, expected, objectImages(objects[0].Object))
+}
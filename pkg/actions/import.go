@@ -17,6 +17,7 @@ package actions
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -27,17 +28,26 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/ksonnet/ksonnet/metadata/params"
 	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/cluster"
 	"github.com/ksonnet/ksonnet/pkg/component"
 	"github.com/ksonnet/ksonnet/pkg/prototype"
 	"github.com/ksonnet/ksonnet/pkg/schema"
 	utilstrings "github.com/ksonnet/ksonnet/pkg/util/strings"
 	utilyaml "github.com/ksonnet/ksonnet/pkg/util/yaml"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+type runExportFn func(cluster.ExportConfig, ...cluster.ExportOpts) ([]*unstructured.Unstructured, error)
+type runAdoptFn func(cluster.ExportConfig, []*unstructured.Unstructured, ...cluster.ExportOpts) error
+
 // RunImport runs `import`
 func RunImport(m map[string]interface{}) error {
 	i, err := NewImport(m)
@@ -48,37 +58,78 @@ func RunImport(m map[string]interface{}) error {
 	return i.Run()
 }
 
-// Import imports files or directories into ksonnet.
+// Import imports files, directories or cluster objects into ksonnet.
 type Import struct {
 	app    app.App
 	module string
 	path   string
 
+	fromCluster  bool
+	clientConfig *client.Config
+	envName      string
+	kinds        []string
+	selector     string
+	adopt        bool
+
+	single bool
+	out    io.Writer
+
 	createComponentFn func(a app.App, module, name, text string, p params.Params, templateType prototype.TemplateType) (string, error)
+	runExportFn       runExportFn
+	runAdoptFn        runAdoptFn
 }
 
 // NewImport creates an instance of Import. `module` is the name of the component and
-// entity is the file or directory to import.
+// entity is the file or directory to import. If `from-cluster` is set, objects are
+// imported from the environment's cluster namespace instead.
 func NewImport(m map[string]interface{}) (*Import, error) {
 	ol := newOptionLoader(m)
 
 	i := &Import{
-		app:    ol.LoadApp(),
-		module: ol.LoadString(OptionModule),
-		path:   ol.LoadString(OptionPath),
+		app:         ol.LoadApp(),
+		module:      ol.LoadString(OptionModule),
+		path:        ol.LoadString(OptionPath),
+		fromCluster: ol.LoadOptionalBool(OptionFromCluster),
+		single:      ol.LoadOptionalBool(OptionSingle),
+		out:         os.Stdout,
 
 		createComponentFn: component.Create,
+		runExportFn:       cluster.RunExport,
+		runAdoptFn:        cluster.RunAdopt,
+	}
+
+	if i.fromCluster {
+		i.clientConfig = ol.LoadClientConfig()
+		i.kinds = ol.LoadStringSlice(OptionKinds)
+		i.selector = ol.LoadString(OptionSelector)
+		i.adopt = ol.LoadBool(OptionAdopt)
 	}
 
 	if ol.err != nil {
 		return nil, ol.err
 	}
 
+	if i.fromCluster {
+		if err := setCurrentEnv(i.app, i, ol); err != nil {
+			return nil, err
+		}
+	}
+
 	return i, nil
 }
 
 // Run runs the import process.
 func (i *Import) Run() error {
+	if i.fromCluster {
+		if i.path != "" {
+			return errors.New("path can't be used when importing from a cluster")
+		}
+		if i.single {
+			return errors.New("single can't be used when importing from a cluster")
+		}
+		return i.handleCluster()
+	}
+
 	if i.path == "" {
 		return errors.New("path is required")
 	}
@@ -90,6 +141,58 @@ func (i *Import) Run() error {
 	return i.handleLocal()
 }
 
+func (i *Import) setCurrentEnv(name string) {
+	i.envName = name
+}
+
+func (i *Import) handleCluster() error {
+	config := cluster.ExportConfig{
+		App:          i.app,
+		ClientConfig: i.clientConfig,
+		EnvName:      i.envName,
+		Kinds:        i.kinds,
+		Selector:     i.selector,
+	}
+
+	objects, err := i.runExportFn(config)
+	if err != nil {
+		return errors.Wrap(err, "export objects from cluster")
+	}
+
+	if len(objects) == 0 {
+		log.Warn("no objects were found to import")
+		return nil
+	}
+
+	for _, obj := range objects {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return errors.Wrapf(err, "marshal %s %s", obj.GetKind(), obj.GetName())
+		}
+
+		if err = i.createComponentFromData(clusterComponentName(obj), string(data), prototype.YAML); err != nil {
+			return err
+		}
+	}
+
+	// objects are only adopted once every component has been created, so
+	// ksonnet doesn't manage objects which have no component.
+	if i.adopt {
+		if err = i.runAdoptFn(config, objects); err != nil {
+			return errors.Wrap(err, "adopt objects in cluster")
+		}
+	}
+
+	return nil
+}
+
+// clusterComponentName returns the component name for an object imported from
+// a cluster. Dots separate modules in component names, so they are replaced.
+func clusterComponentName(obj *unstructured.Unstructured) string {
+	name := fmt.Sprintf("%s-%s", obj.GetKind(), obj.GetName())
+	return strings.Replace(strings.ToLower(name), ".", "-", -1)
+}
+
 func (i *Import) handleURL() error {
 	resp, err := http.Get(i.path)
 	if err != nil {
@@ -199,6 +302,9 @@ func (i *Import) importFile(fileName string) error {
 	default:
 		return errors.Errorf("unable to handle components of type %s", templateType)
 	case prototype.YAML:
+		if i.single {
+			return i.createYAMLList(fileName, base, ext)
+		}
 		return i.createYAML(fileName, base, ext)
 	case prototype.JSON, prototype.Jsonnet:
 		return i.createComponent(fileName, base, ext, templateType)
@@ -250,18 +356,274 @@ func (i *Import) createYAML(fileName, base, ext string) error {
 	return nil
 }
 
-func (i *Import) createComponentFromData(name, data string, templateType prototype.TemplateType) error {
-	componentParams := params.Params{}
+// createYAMLList imports the objects in a YAML file as a single component
+// containing a List. The component is named after the file, so importing a
+// newer version of the file updates the component and prints what changed.
+func (i *Import) createYAMLList(fileName, base, ext string) error {
+	objects, err := i.readYAMLObjects(fileName)
+	if err != nil {
+		return err
+	}
+
+	if len(objects) == 0 {
+		log.Warnf("no objects were found in %s", fileName)
+		return nil
+	}
+
+	data, err := marshalList(objects)
+	if err != nil {
+		return err
+	}
+
+	name := listComponentName(base, ext)
+	path := filepath.Join(component.NewModule(i.app, i.moduleName()).Dir(), name+".yaml")
+
+	exists, err := afero.Exists(i.app.Fs(), path)
+	if err != nil {
+		return errors.Wrapf(err, "check if %s exists", path)
+	}
+
+	if !exists {
+		return i.createComponentFromData(name, string(data), prototype.YAML)
+	}
+
+	current, err := i.readYAMLObjects(path)
+	if err != nil {
+		return errors.Wrapf(err, "read component %q", name)
+	}
+
+	changed, err := diffObjects(i.out, current, objects)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		log.Infof("Component %q is up to date", name)
+		return nil
+	}
+
+	log.Infof("Updating component at %s", path)
+	return afero.WriteFile(i.app.Fs(), path, data, app.DefaultFilePermissions)
+}
+
+// listComponentName returns the name of the component a file is imported into
+// by createYAMLList. Dots separate modules in component names, so they are
+// replaced.
+func listComponentName(base, ext string) string {
+	return strings.Replace(strings.TrimSuffix(base, ext), ".", "-", -1)
+}
+
+// readYAMLObjects reads the objects in a multi-document YAML file. Lists are
+// expanded into their items. Every object must have a kind and a name, and
+// the combination must be unique in the file.
+func (i *Import) readYAMLObjects(fileName string) ([]*unstructured.Unstructured, error) {
+	f, err := i.app.Fs().Open(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %q", fileName)
+	}
+	defer f.Close()
+
+	readers, err := utilyaml.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	seen := make(map[string]bool)
+
+	for _, r := range readers {
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
 
-	var moduleName string
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode %s", fileName)
+		}
+
+		var m map[string]interface{}
+		if err = json.Unmarshal(data, &m); err != nil {
+			return nil, errors.Wrapf(err, "decode %s", fileName)
+		}
+
+		if m == nil {
+			continue
+		}
+
+		items, err := listItems(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode %s", fileName)
+		}
+
+		for _, obj := range items {
+			if obj.GetKind() == "" || obj.GetName() == "" {
+				return nil, errors.Errorf("object in %s does not have a kind and a name", fileName)
+			}
+
+			key := objectKey(obj)
+			if seen[key] {
+				return nil, errors.Errorf("%s is defined more than once in %s", key, fileName)
+			}
+			seen[key] = true
+
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects, nil
+}
+
+// listItems returns the items of m if it is a List, or m otherwise.
+func listItems(m map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	if m["kind"] != "List" {
+		return []*unstructured.Unstructured{{Object: m}}, nil
+	}
+
+	items, ok := m["items"].([]interface{})
+	if !ok {
+		return nil, errors.New("list items are not an array")
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("list item is not an object")
+		}
+
+		objects = append(objects, &unstructured.Unstructured{Object: itemMap})
+	}
+
+	return objects, nil
+}
+
+// objectKey identifies an object in a list by kind, namespace and name.
+func objectKey(obj *unstructured.Unstructured) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return fmt.Sprintf("%s/%s/%s", obj.GetKind(), ns, obj.GetName())
+	}
+
+	return fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+}
+
+func marshalList(objects []*unstructured.Unstructured) ([]byte, error) {
+	var items []interface{}
+	for _, obj := range objects {
+		items = append(items, obj.Object)
+	}
+
+	list := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}
+
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal list")
+	}
+
+	return data, nil
+}
+
+// diffObjects writes the objects which were added, changed or removed between
+// the current and imported versions of a list to w, followed by a diff of each
+// changed object. It returns false if nothing changed.
+func diffObjects(w io.Writer, current, imported []*unstructured.Unstructured) (bool, error) {
+	currentObjects := make(map[string]*unstructured.Unstructured)
+	for _, obj := range current {
+		currentObjects[objectKey(obj)] = obj
+	}
+
+	var summary, diffs []string
+	importedKeys := make(map[string]bool)
+
+	for _, obj := range imported {
+		key := objectKey(obj)
+		importedKeys[key] = true
+
+		old, ok := currentObjects[key]
+		if !ok {
+			summary = append(summary, "added   "+key)
+			continue
+		}
+
+		diff, err := objectDiff(key, old, obj)
+		if err != nil {
+			return false, err
+		}
+
+		if diff != "" {
+			summary = append(summary, "changed "+key)
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for _, obj := range current {
+		if key := objectKey(obj); !importedKeys[key] {
+			summary = append(summary, "removed "+key)
+		}
+	}
+
+	if len(summary) == 0 {
+		return false, nil
+	}
+
+	for _, line := range summary {
+		fmt.Fprintln(w, line)
+	}
+
+	for _, diff := range diffs {
+		fmt.Fprintf(w, "\n%s", diff)
+	}
+
+	return true, nil
+}
+
+// objectDiff returns a unified diff of two versions of an object as YAML. It
+// is empty if the versions are the same.
+func objectDiff(key string, a, b *unstructured.Unstructured) (string, error) {
+	aData, err := yaml.Marshal(a.Object)
+	if err != nil {
+		return "", errors.Wrapf(err, "marshal %s", key)
+	}
+
+	bData, err := yaml.Marshal(b.Object)
+	if err != nil {
+		return "", errors.Wrapf(err, "marshal %s", key)
+	}
+
+	if bytes.Equal(aData, bData) {
+		return "", nil
+	}
+
+	// SplitLines adds a newline to the last line, so the trailing newline is
+	// removed to avoid diffing an extra empty line.
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(aData), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(bData), "\n")),
+		FromFile: key + " (current)",
+		ToFile:   key + " (imported)",
+		Context:  3,
+	})
+}
+
+// moduleName returns the module components are imported into. The root
+// module is "".
+func (i *Import) moduleName() string {
 	switch i.module {
 	case "", "/":
-		// do nothing
+		return ""
 	default:
-		moduleName = i.module
+		return i.module
 	}
+}
+
+func (i *Import) createComponentFromData(name, data string, templateType prototype.TemplateType) error {
+	componentParams := params.Params{}
 
-	_, err := i.createComponentFn(i.app, moduleName, name, data, componentParams, templateType)
+	_, err := i.createComponentFn(i.app, i.moduleName(), name, data, componentParams, templateType)
 	if err != nil {
 		return errors.Wrap(err, "create component")
 	}
@@ -16,6 +16,7 @@
 package actions
 
 import (
+	"bytes"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -30,8 +31,12 @@ import (
 	"github.com/ksonnet/ksonnet/metadata/params"
 	"github.com/ksonnet/ksonnet/pkg/app"
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/cluster"
 	"github.com/ksonnet/ksonnet/pkg/prototype"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestImport_http(t *testing.T) {
@@ -230,8 +235,280 @@ func TestImport_invalid_file(t *testing.T) {
 	})
 }
 
+func TestImport_from_cluster(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		clientConfig := &client.Config{}
+
+		in := map[string]interface{}{
+			OptionApp:          appMock,
+			OptionModule:       "/",
+			OptionPath:         "",
+			OptionFromCluster:  true,
+			OptionClientConfig: clientConfig,
+			OptionEnvName:      "default",
+			OptionKinds:        []string{"service"},
+			OptionSelector:     "app=web",
+			OptionAdopt:        true,
+		}
+
+		a, err := NewImport(in)
+		require.NoError(t, err)
+
+		a.runExportFn = func(config cluster.ExportConfig, opts ...cluster.ExportOpts) ([]*unstructured.Unstructured, error) {
+			expected := cluster.ExportConfig{
+				App:          appMock,
+				ClientConfig: clientConfig,
+				EnvName:      "default",
+				Kinds:        []string{"service"},
+				Selector:     "app=web",
+			}
+			assert.Equal(t, expected, config)
+
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Service",
+					"metadata": map[string]interface{}{
+						"name": "my.service",
+					},
+				},
+			}
+
+			return []*unstructured.Unstructured{obj}, nil
+		}
+
+		var created bool
+		a.createComponentFn = func(_ app.App, moduleName, name, text string, p params.Params, templateType prototype.TemplateType) (string, error) {
+			assert.Equal(t, "service-my-service", name)
+			assert.Equal(t, "", moduleName)
+			assert.Equal(t, "apiVersion: v1\nkind: Service\nmetadata:\n  name: my.service\n", text)
+			assert.Equal(t, params.Params{}, p)
+			assert.Equal(t, prototype.YAML, templateType)
+
+			created = true
+			return "/", nil
+		}
+
+		var adopted []*unstructured.Unstructured
+		a.runAdoptFn = func(config cluster.ExportConfig, objects []*unstructured.Unstructured, opts ...cluster.ExportOpts) error {
+			assert.True(t, created, "objects were adopted before their components were created")
+			adopted = objects
+			return nil
+		}
+
+		err = a.Run()
+		require.NoError(t, err)
+
+		require.Len(t, adopted, 1)
+		assert.Equal(t, "my.service", adopted[0].GetName())
+	})
+}
+
+func TestImport_from_cluster_adopt_failed_import(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:          appMock,
+			OptionModule:       "/",
+			OptionPath:         "",
+			OptionFromCluster:  true,
+			OptionClientConfig: &client.Config{},
+			OptionEnvName:      "default",
+			OptionKinds:        []string{"service"},
+			OptionSelector:     "",
+			OptionAdopt:        true,
+		}
+
+		a, err := NewImport(in)
+		require.NoError(t, err)
+
+		a.runExportFn = func(config cluster.ExportConfig, opts ...cluster.ExportOpts) ([]*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Service",
+					"metadata": map[string]interface{}{
+						"name": "web",
+					},
+				},
+			}
+
+			return []*unstructured.Unstructured{obj}, nil
+		}
+
+		a.createComponentFn = func(_ app.App, moduleName, name, text string, p params.Params, templateType prototype.TemplateType) (string, error) {
+			return "", errors.New("component exists")
+		}
+
+		a.runAdoptFn = func(cluster.ExportConfig, []*unstructured.Unstructured, ...cluster.ExportOpts) error {
+			t.Fatal("objects were adopted although the import failed")
+			return nil
+		}
+
+		err = a.Run()
+		require.Error(t, err)
+	})
+}
+
+func TestImport_from_cluster_with_path(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:          appMock,
+			OptionModule:       "/",
+			OptionPath:         "/file.yaml",
+			OptionFromCluster:  true,
+			OptionClientConfig: &client.Config{},
+			OptionEnvName:      "default",
+			OptionKinds:        []string{},
+			OptionSelector:     "",
+			OptionAdopt:        false,
+		}
+
+		a, err := NewImport(in)
+		require.NoError(t, err)
+
+		err = a.Run()
+		require.Error(t, err)
+	})
+}
+
 func TestImport_requires_app(t *testing.T) {
 	in := make(map[string]interface{})
 	_, err := NewImport(in)
 	require.Error(t, err)
 }
+
+func TestImport_single(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		stageFile(t, appMock.Fs(), "import/single/manifest.yaml", "/upstream/manifest.yaml")
+
+		in := map[string]interface{}{
+			OptionApp:    appMock,
+			OptionModule: "/",
+			OptionPath:   "/upstream/manifest.yaml",
+			OptionSingle: true,
+		}
+
+		a, err := NewImport(in)
+		require.NoError(t, err)
+
+		var created bool
+		a.createComponentFn = func(_ app.App, moduleName, name, text string, p params.Params, templateType prototype.TemplateType) (string, error) {
+			created = true
+
+			assert.Equal(t, "manifest", name)
+			assert.Equal(t, "", moduleName)
+			assertOutput(t, "import/single/component.yaml", text)
+			assert.Equal(t, params.Params{}, p)
+			assert.Equal(t, prototype.YAML, templateType)
+
+			return "/", nil
+		}
+
+		err = a.Run()
+		require.NoError(t, err)
+
+		assert.True(t, created)
+	})
+}
+
+func TestImport_single_reimport(t *testing.T) {
+	cases := []struct {
+		name     string
+		manifest string
+		expected string
+		out      string
+	}{
+		{
+			name:     "updated manifest",
+			manifest: "import/single/manifest-v2.yaml",
+			expected: "import/single/component-v2.yaml",
+			out:      "import/single/reimport.txt",
+		},
+		{
+			name:     "unchanged manifest",
+			manifest: "import/single/manifest.yaml",
+			expected: "import/single/component.yaml",
+			out:      "import/single/up-to-date.txt",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				fs := appMock.Fs()
+				stageFile(t, fs, "import/single/component.yaml", "/components/manifest.yaml")
+				stageFile(t, fs, tc.manifest, "/upstream/manifest.yaml")
+
+				in := map[string]interface{}{
+					OptionApp:    appMock,
+					OptionModule: "/",
+					OptionPath:   "/upstream/manifest.yaml",
+					OptionSingle: true,
+				}
+
+				a, err := NewImport(in)
+				require.NoError(t, err)
+
+				var out bytes.Buffer
+				a.out = &out
+
+				a.createComponentFn = func(_ app.App, moduleName, name, text string, p params.Params, templateType prototype.TemplateType) (string, error) {
+					return "", errors.New("component should be updated")
+				}
+
+				err = a.Run()
+				require.NoError(t, err)
+
+				assertOutput(t, tc.out, out.String())
+
+				b, err := afero.ReadFile(fs, "/components/manifest.yaml")
+				require.NoError(t, err)
+				assertOutput(t, tc.expected, string(b))
+			})
+		})
+	}
+}
+
+func TestImport_single_duplicate_objects(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		manifest := "kind: Secret\nmetadata:\n  name: token\n---\nkind: Secret\nmetadata:\n  name: token\n"
+		err := afero.WriteFile(appMock.Fs(), "/manifest.yaml", []byte(manifest), 0644)
+		require.NoError(t, err)
+
+		in := map[string]interface{}{
+			OptionApp:    appMock,
+			OptionModule: "/",
+			OptionPath:   "/manifest.yaml",
+			OptionSingle: true,
+		}
+
+		a, err := NewImport(in)
+		require.NoError(t, err)
+
+		err = a.Run()
+		require.EqualError(t, err, "Secret/token is defined more than once in /manifest.yaml")
+	})
+}
+
+func TestImport_from_cluster_single(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:          appMock,
+			OptionModule:       "/",
+			OptionPath:         "",
+			OptionSingle:       true,
+			OptionFromCluster:  true,
+			OptionClientConfig: &client.Config{},
+			OptionEnvName:      "default",
+			OptionKinds:        []string{},
+			OptionSelector:     "",
+			OptionAdopt:        false,
+		}
+
+		a, err := NewImport(in)
+		require.NoError(t, err)
+
+		err = a.Run()
+		require.Error(t, err)
+	})
+}
@@ -42,7 +42,7 @@ func RunInit(m map[string]interface{}) error {
 
 type appLoadFn func(fs afero.Fs, httpClient *http.Client, root string) (app.App, error)
 
-type appInitFn func(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry) error
+type appInitFn func(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry, tmpl *appinit.Template) error
 
 type initIncubatorFn func(app.App, *http.Client) (registry.Registry, error)
 
@@ -56,10 +56,12 @@ type Init struct {
 	serverURI             string
 	namespace             string
 	skipDefaultRegistries bool
+	template              string
 
 	appInitFn       appInitFn
 	appLoadFn       appLoadFn
 	initIncubatorFn initIncubatorFn
+	gitCloneFn      gitCloneFn
 
 	httpClient *http.Client
 }
@@ -77,10 +79,12 @@ func NewInit(m map[string]interface{}) (*Init, error) {
 		serverURI:             ol.LoadOptionalString(OptionServer),
 		namespace:             ol.LoadString(OptionNamespace),
 		skipDefaultRegistries: ol.LoadBool(OptionSkipDefaultRegistries),
+		template:              ol.LoadOptionalString(OptionTemplate),
 
 		appInitFn:       appinit.Init,
 		appLoadFn:       app.Load,
 		initIncubatorFn: initIncubator,
+		gitCloneFn:      gitClone,
 
 		httpClient: ol.LoadHTTPClient(),
 	}
@@ -110,6 +114,11 @@ func (i *Init) Run() error {
 		registries = append(registries, gh)
 	}
 
+	tmpl, err := i.loadTemplate(registries)
+	if err != nil {
+		return err
+	}
+
 	return i.appInitFn(
 		i.fs,
 		i.httpClient,
@@ -120,6 +129,7 @@ func (i *Init) Run() error {
 		i.serverURI,
 		i.namespace,
 		registries,
+		tmpl,
 	)
 }
 
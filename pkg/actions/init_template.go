@@ -0,0 +1,178 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/appinit"
+	"github.com/ksonnet/ksonnet/pkg/pkg"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	// gitTemplatePrefix marks templates which are cloned from git repositories.
+	gitTemplatePrefix = "git::"
+
+	// registryTemplateDir is the directory in a registry package which
+	// contains an application template.
+	registryTemplateDir = "template"
+)
+
+type gitCloneFn func(repo, ref, dest string) error
+
+// loadTemplate loads the application template for init. A template is either
+// a local directory, a git repository in the form
+// `git::<url>[//<subdir>][?ref=<ref>]`, or a package in one of the registries
+// in the form `<registry>/<package>[@<version>]` which contains a `template`
+// directory.
+func (i *Init) loadTemplate(registries []registry.Registry) (*appinit.Template, error) {
+	if i.template == "" {
+		return nil, nil
+	}
+
+	ok, err := afero.DirExists(i.fs, i.template)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return appinit.LoadTemplate(i.fs, i.template)
+	}
+
+	if strings.HasPrefix(i.template, gitTemplatePrefix) {
+		return i.loadGitTemplate(strings.TrimPrefix(i.template, gitTemplatePrefix))
+	}
+
+	return loadRegistryTemplate(i.template, registries)
+}
+
+func (i *Init) loadGitTemplate(source string) (*appinit.Template, error) {
+	repo, subdir, ref, err := parseGitTemplateSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := afero.TempDir(i.fs, "", "ks-template")
+	if err != nil {
+		return nil, err
+	}
+	defer i.fs.RemoveAll(dir)
+
+	log.Debugf("cloning template %s into %s", repo, dir)
+	if err = i.gitCloneFn(repo, ref, dir); err != nil {
+		return nil, errors.Wrapf(err, "cloning template %s", repo)
+	}
+
+	return appinit.LoadTemplate(i.fs, filepath.Join(dir, filepath.FromSlash(subdir)))
+}
+
+// parseGitTemplateSource splits `<url>[//<subdir>][?ref=<ref>]` into its parts.
+func parseGitTemplateSource(source string) (repo, subdir, ref string, err error) {
+	repo = source
+
+	if i := strings.LastIndex(repo, "?"); i >= 0 {
+		q, err := url.ParseQuery(repo[i+1:])
+		if err != nil {
+			return "", "", "", errors.Wrapf(err, "parsing template source %q", source)
+		}
+		ref = q.Get("ref")
+		repo = repo[:i]
+	}
+
+	start := 0
+	if i := strings.Index(repo, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	if i := strings.Index(repo[start:], "//"); i >= 0 {
+		subdir = repo[start+i+len("//"):]
+		repo = repo[:start+i]
+	}
+
+	if repo == "" {
+		return "", "", "", errors.Errorf("template source %q does not contain a repository", source)
+	}
+
+	return repo, subdir, ref, nil
+}
+
+// gitClone clones a git repository into dest using the git binary.
+func gitClone(repo, ref, dest string) error {
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	// repositories which look like options are not read as options.
+	args = append(args, "--", repo, dest)
+
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return errors.Errorf("git clone: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+func loadRegistryTemplate(source string, registries []registry.Registry) (*appinit.Template, error) {
+	d, err := pkg.Parse(source)
+	if err != nil || d.Registry == "" {
+		return nil, errors.Errorf("template %q is not a directory, git repository or registry package", source)
+	}
+
+	var r registry.Registry
+	for _, cur := range registries {
+		if cur.Name() == d.Registry {
+			r = cur
+			break
+		}
+	}
+	if r == nil {
+		return nil, errors.Errorf("registry %q is not available for templates", d.Registry)
+	}
+
+	prefix := path.Join(d.Name, registryTemplateDir) + "/"
+	files := make(map[string][]byte)
+
+	_, _, err = r.ResolveLibrary(
+		d.Name,
+		d.Name,
+		d.Version,
+		func(relPath string, contents []byte) error {
+			p := filepath.ToSlash(relPath)
+			if strings.HasPrefix(p, prefix) {
+				files[strings.TrimPrefix(p, prefix)] = contents
+			}
+			return nil
+		},
+		func(relPath string) error {
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving template package %s", d)
+	}
+
+	if len(files) == 0 {
+		return nil, errors.Errorf("package %s does not contain a template", d)
+	}
+
+	return appinit.NewTemplate(files), nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/appinit"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	rmocks "github.com/ksonnet/ksonnet/pkg/registry/mocks"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit_template(t *testing.T) {
+	appYAML := []byte("environments:\n  dev: {}\n")
+	params := []byte("{}")
+
+	expected := appinit.NewTemplate(map[string][]byte{
+		"app.yaml":                         appYAML,
+		"components/params.libsonnet.tmpl": params,
+	})
+
+	cases := []struct {
+		name     string
+		template string
+		isErr    bool
+	}{
+		{
+			name:     "directory",
+			template: "/templates/web",
+		},
+		{
+			name:     "git repository",
+			template: "git::https://example.com/templates.git//web?ref=v1",
+		},
+		{
+			name:     "registry package",
+			template: "incubator/web@1.0",
+		},
+		{
+			name:     "registry package without template",
+			template: "incubator/empty",
+			isErr:    true,
+		},
+		{
+			name:     "unknown registry",
+			template: "other/web",
+			isErr:    true,
+		},
+		{
+			name:     "invalid source",
+			template: "web",
+			isErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				fs := appMock.Fs()
+
+				for path, data := range map[string][]byte{
+					"/templates/web/app.yaml":                         appYAML,
+					"/templates/web/components/params.libsonnet.tmpl": params,
+				} {
+					require.NoError(t, afero.WriteFile(fs, path, data, app.DefaultFilePermissions))
+				}
+
+				in := map[string]interface{}{
+					OptionFs:                    fs,
+					OptionName:                  "my-app",
+					OptionNewRoot:               "/my-app",
+					OptionEnvName:               "",
+					OptionSpecFlag:              "specFlag",
+					OptionServer:                "http://example.com",
+					OptionNamespace:             "default",
+					OptionSkipDefaultRegistries: false,
+					OptionTemplate:              tc.template,
+				}
+
+				a, err := NewInit(in)
+				require.NoError(t, err)
+
+				a.appLoadFn = func(fs afero.Fs, httpClient *http.Client, root string) (app.App, error) {
+					return appMock, nil
+				}
+
+				a.initIncubatorFn = func(a app.App, httpClient *http.Client) (registry.Registry, error) {
+					r := &rmocks.Registry{}
+					r.On("Name").Return("incubator")
+					r.On("ResolveLibrary", "web", "web", "1.0", mock.Anything, mock.Anything).
+						Run(func(args mock.Arguments) {
+							onFile := args.Get(3).(registry.ResolveFile)
+							require.NoError(t, onFile("web/parts.yaml", []byte("{}")))
+							require.NoError(t, onFile("web/template/app.yaml", appYAML))
+							require.NoError(t, onFile("web/template/components/params.libsonnet.tmpl", params))
+						}).
+						Return(nil, nil, nil)
+					r.On("ResolveLibrary", "empty", "empty", "", mock.Anything, mock.Anything).
+						Return(nil, nil, nil)
+					return r, nil
+				}
+
+				a.gitCloneFn = func(repo, ref, dest string) error {
+					assert.Equal(t, "https://example.com/templates.git", repo)
+					assert.Equal(t, "v1", ref)
+
+					dir := filepath.Join(dest, "web")
+					require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "app.yaml"), appYAML, app.DefaultFilePermissions))
+					require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "components", "params.libsonnet.tmpl"), params, app.DefaultFilePermissions))
+					require.NoError(t, afero.WriteFile(fs, filepath.Join(dest, ".git", "HEAD"), []byte("ref"), app.DefaultFilePermissions))
+					return nil
+				}
+
+				var got *appinit.Template
+				a.appInitFn = func(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry, tmpl *appinit.Template) error {
+					got = tmpl
+					return nil
+				}
+
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, expected, got)
+			})
+		})
+	}
+}
+
+func Test_parseGitTemplateSource(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		repo   string
+		subdir string
+		ref    string
+		isErr  bool
+	}{
+		{
+			name:   "repository",
+			source: "https://example.com/templates.git",
+			repo:   "https://example.com/templates.git",
+		},
+		{
+			name:   "subdirectory and ref",
+			source: "https://example.com/templates.git//web/app?ref=v1",
+			repo:   "https://example.com/templates.git",
+			subdir: "web/app",
+			ref:    "v1",
+		},
+		{
+			name:   "scp style",
+			source: "git@example.com:org/templates.git//web",
+			repo:   "git@example.com:org/templates.git",
+			subdir: "web",
+		},
+		{
+			name:   "missing repository",
+			source: "//web",
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, subdir, ref, err := parseGitTemplateSource(tc.source)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.repo, repo)
+			assert.Equal(t, tc.subdir, subdir)
+			assert.Equal(t, tc.ref, ref)
+		})
+	}
+}
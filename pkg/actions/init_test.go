@@ -21,6 +21,7 @@ import (
 
 	"github.com/ksonnet/ksonnet/pkg/app"
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/appinit"
 	"github.com/ksonnet/ksonnet/pkg/registry"
 	rmocks "github.com/ksonnet/ksonnet/pkg/registry/mocks"
 	"github.com/spf13/afero"
@@ -72,7 +73,7 @@ func TestInit(t *testing.T) {
 				a, err := NewInit(in)
 				require.NoError(t, err)
 
-				a.appInitFn = func(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry) error {
+				a.appInitFn = func(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry, tmpl *appinit.Template) error {
 					assert.Equal(t, aFs, fs)
 					assert.Equal(t, aName, name)
 					assert.Equal(t, aRootPath, rootPath)
@@ -80,6 +81,7 @@ func TestInit(t *testing.T) {
 					assert.Equal(t, aK8sSpecFlag, k8sSpecFlag)
 					assert.Equal(t, aServerURI, serverURI)
 					assert.Equal(t, aNamespace, namespace)
+					assert.Nil(t, tmpl)
 
 					if !tc.skipRegistries {
 						assert.Len(t, registries, 1)
@@ -75,11 +75,9 @@ func (nl *ModuleList) Run() error {
 	t := table.New("moduleList", nl.out)
 	t.SetHeader([]string{"module"})
 
-	f, err := table.DetectFormat(nl.outputType)
-	if err != nil {
+	if err := t.SetOutput(nl.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
 	names := make([]string, len(modules))
 	for i := range modules {
@@ -167,11 +167,9 @@ func (pd *ParamDiff) moduleParams(envName string) ([]component.ModuleParameter,
 func (pd *ParamDiff) print(rows [][]string) error {
 	t := table.New("paramDiff", pd.out)
 
-	f, err := table.DetectFormat(pd.outputType)
-	if err != nil {
+	if err := t.SetOutput(pd.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
 	t.SetHeader([]string{"component", "param", "env1", "env2"})
 	t.AppendBulk(rows)
@@ -0,0 +1,261 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// RunParamExplain runs `param explain`.
+func RunParamExplain(m map[string]interface{}) error {
+	pe, err := NewParamExplain(m)
+	if err != nil {
+		return err
+	}
+
+	return pe.Run()
+}
+
+// paramLayer is a file which can define a parameter. Layers are listed from
+// lowest to highest precedence.
+type paramLayer struct {
+	name string
+	path string
+	// fieldPath is the path of the parameter in the file.
+	fieldPath []string
+	// anywhere is true if the parameter can be defined in any object in the
+	// file rather than only in the root object.
+	anywhere bool
+}
+
+// ParamExplain explains where a component parameter's value comes from.
+type ParamExplain struct {
+	app           app.App
+	componentName string
+	rawPath       string
+	envName       string
+	outputType    string
+
+	out                io.Writer
+	getModuleFn        getModuleFn
+	moduleParametersFn func(envName string, module component.Module) (string, error)
+	lister             paramsLister
+}
+
+// NewParamExplain creates an instance of ParamExplain.
+func NewParamExplain(m map[string]interface{}) (*ParamExplain, error) {
+	ol := newOptionLoader(m)
+
+	pe := &ParamExplain{
+		app:           ol.LoadApp(),
+		componentName: ol.LoadString(OptionComponentName),
+		rawPath:       ol.LoadString(OptionPath),
+		envName:       ol.LoadString(OptionEnvName),
+		outputType:    ol.LoadOptionalString(OptionOutput),
+
+		out:         os.Stdout,
+		getModuleFn: component.GetModule,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if pe.envName == "" {
+		return nil, errors.New("environment is required")
+	}
+
+	pe.moduleParametersFn = func(envName string, module component.Module) (string, error) {
+		return pipeline.New(pe.app, envName).ModuleParameters(module)
+	}
+	pe.lister = params.NewLister(pe.app.Root(), app.EnvironmentDestinationSpec{})
+
+	return pe, nil
+}
+
+// Run runs the action.
+func (pe *ParamExplain) Run() error {
+	moduleName, componentName := component.FromName(pe.componentName)
+	path := strings.Split(pe.rawPath, ".")
+
+	module, err := pe.getModuleFn(pe.app, moduleName)
+	if err != nil {
+		return errors.Wrap(err, "could not find module")
+	}
+
+	envParamsPath, err := env.Path(pe.app, pe.envName, "params.libsonnet")
+	if err != nil {
+		return err
+	}
+
+	envGlobalsPath, err := env.Path(pe.app, pe.envName, "globals.libsonnet")
+	if err != nil {
+		return err
+	}
+
+	layers := []paramLayer{
+		{
+			name:      "component",
+			path:      module.ParamsPath(),
+			fieldPath: append([]string{"components", componentName}, path...),
+		},
+		{
+			name:      "module globals",
+			path:      module.ParamsPath(),
+			fieldPath: append([]string{"global"}, path...),
+		},
+		{
+			name:      "environment",
+			path:      envParamsPath,
+			fieldPath: append([]string{"components", componentName}, path...),
+			anywhere:  true,
+		},
+		{
+			name:      "environment globals",
+			path:      envGlobalsPath,
+			fieldPath: path,
+		},
+	}
+
+	var rows [][]string
+	for _, layer := range layers {
+		layerRows, err := pe.explainLayer(layer)
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, layerRows...)
+	}
+
+	value, err := pe.evaluatedValue(module, componentName, path)
+	if err != nil {
+		return err
+	}
+
+	rows = append(rows, []string{"evaluated", "", value})
+
+	t := table.New("paramExplain", pe.out)
+
+	if err := t.SetOutput(pe.outputType); err != nil {
+		return errors.Wrap(err, "detecting output format")
+	}
+
+	t.SetHeader([]string{"layer", "source", "value"})
+	t.AppendBulk(rows)
+
+	return t.Render()
+}
+
+func (pe *ParamExplain) explainLayer(layer paramLayer) ([][]string, error) {
+	data, err := afero.ReadFile(pe.app.Fs(), layer.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading %s", layer.path)
+	}
+
+	sources, err := params.FindFieldSources(layer.path, string(data), layer.fieldPath, layer.anywhere)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding %s in %s", pe.rawPath, layer.path)
+	}
+
+	rel, err := filepath.Rel(pe.app.Root(), layer.path)
+	if err != nil {
+		rel = layer.path
+	}
+
+	var rows [][]string
+	for _, source := range sources {
+		location := fmt.Sprintf("%s:%d", filepath.ToSlash(rel), source.Line)
+		rows = append(rows, []string{layer.name, location, source.Source})
+	}
+
+	return rows, nil
+}
+
+// evaluatedValue returns the parameter's value after the environment has been
+// applied.
+func (pe *ParamExplain) evaluatedValue(module component.Module, componentName string, path []string) (string, error) {
+	data, err := pe.moduleParametersFn(pe.envName, module)
+	if err != nil {
+		return "", errors.Wrap(err, "evaluating parameters")
+	}
+
+	if len(path) > 1 {
+		return nestedParamValue(data, componentName, path)
+	}
+
+	entries, err := pe.lister.List(strings.NewReader(data), componentName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		if entry.ParamName == path[0] {
+			return entry.Value, nil
+		}
+	}
+
+	return "", errors.Errorf("component %q has no parameter %q in environment %q",
+		pe.componentName, pe.rawPath, pe.envName)
+}
+
+// nestedParamValue returns a nested parameter from evaluated parameters.
+func nestedParamValue(data, componentName string, path []string) (string, error) {
+	var m struct {
+		Components map[string]interface{} `json:"components"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return "", errors.Wrap(err, "decoding parameters")
+	}
+
+	var cur interface{} = m.Components[componentName]
+	for _, key := range path {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			cur = nil
+			break
+		}
+		cur, ok = obj[key]
+		if !ok {
+			cur = nil
+			break
+		}
+	}
+
+	if cur == nil {
+		return "", errors.Errorf("component %q has no parameter %q",
+			componentName, strings.Join(path, "."))
+	}
+
+	return params.FormatValue(cur)
+}
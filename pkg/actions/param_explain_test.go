@@ -0,0 +1,144 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamExplain(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		appMock.On("Environment", "default").Return(&app.EnvironmentConfig{Path: "default"}, nil)
+
+		stageFile(t, appMock.Fs(), "param/explain/params.libsonnet", "/components/params.libsonnet")
+		stageFile(t, appMock.Fs(), "param/explain/env-params.libsonnet", "/environments/default/params.libsonnet")
+		stageFile(t, appMock.Fs(), "param/explain/globals.libsonnet", "/environments/default/globals.libsonnet")
+
+		module := &cmocks.Module{}
+		module.On("ParamsPath").Return("/components/params.libsonnet")
+
+		cases := []struct {
+			name       string
+			path       string
+			outputType string
+			outputFile string
+			isErr      bool
+		}{
+			{
+				name:       "overridden by environment",
+				path:       "replicas",
+				outputFile: filepath.Join("param", "explain", "replicas.txt"),
+			},
+			{
+				name:       "global",
+				path:       "tier",
+				outputFile: filepath.Join("param", "explain", "tier.txt"),
+			},
+			{
+				name:       "nested",
+				path:       "labels.app",
+				outputType: "json",
+				outputFile: filepath.Join("param", "explain", "nested.json"),
+			},
+			{
+				name:  "missing",
+				path:  "missing",
+				isErr: true,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				in := map[string]interface{}{
+					OptionApp:           appMock,
+					OptionComponentName: "web",
+					OptionPath:          tc.path,
+					OptionEnvName:       "default",
+					OptionOutput:        tc.outputType,
+				}
+
+				pe, err := NewParamExplain(in)
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				pe.out = &buf
+
+				pe.getModuleFn = func(_ app.App, moduleName string) (component.Module, error) {
+					assert.Equal(t, "", moduleName)
+					return module, nil
+				}
+
+				pe.moduleParametersFn = func(envName string, _ component.Module) (string, error) {
+					assert.Equal(t, "default", envName)
+					return `{"components": {"web": {"replicas": 3, "tier": "front", "labels": {"app": "web"}}}}`, nil
+				}
+
+				err = pe.Run()
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				assertOutput(t, tc.outputFile, buf.String())
+			})
+		}
+	})
+}
+
+func TestParamExplain_module_error(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:           appMock,
+			OptionComponentName: "web",
+			OptionPath:          "replicas",
+			OptionEnvName:       "default",
+		}
+
+		pe, err := NewParamExplain(in)
+		require.NoError(t, err)
+
+		pe.getModuleFn = func(app.App, string) (component.Module, error) {
+			return nil, errors.New("failed")
+		}
+
+		require.Error(t, pe.Run())
+	})
+}
+
+func TestParamExplain_requires_env(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:           appMock,
+			OptionComponentName: "web",
+			OptionPath:          "replicas",
+			OptionEnvName:       "",
+		}
+
+		_, err := NewParamExplain(in)
+		require.Error(t, err)
+	})
+}
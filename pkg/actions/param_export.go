@@ -0,0 +1,216 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// paramsDocument is the file format used by `param export` and `param import`.
+type paramsDocument struct {
+	// Components are component params keyed by the component's name. Components
+	// in modules other than the root module are prefixed with the module name.
+	Components map[string]map[string]interface{} `json:"components,omitempty"`
+	// Globals are global params.
+	Globals map[string]interface{} `json:"globals,omitempty"`
+}
+
+// RunParamExport runs `param export`.
+func RunParamExport(m map[string]interface{}) error {
+	pe, err := NewParamExport(m)
+	if err != nil {
+		return err
+	}
+
+	return pe.Run()
+}
+
+// envParamsCollector collects the effective params of an environment.
+type envParamsCollector struct {
+	modulesFn          func() ([]component.Module, error)
+	moduleParametersFn func(module component.Module) (string, error)
+	envGlobalsFn       func() (string, error)
+}
+
+func newEnvParamsCollector(a app.App, envName string) *envParamsCollector {
+	p := pipeline.New(a, envName)
+
+	return &envParamsCollector{
+		modulesFn:          p.Modules,
+		moduleParametersFn: p.ModuleParameters,
+		envGlobalsFn: func() (string, error) {
+			return env.EvaluateGlobals(a, envName)
+		},
+	}
+}
+
+// collect returns the effective component params for every module in the
+// environment, and the environment's globals.
+func (c *envParamsCollector) collect() (*paramsDocument, error) {
+	modules, err := c.modulesFn()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &paramsDocument{
+		Components: make(map[string]map[string]interface{}),
+		Globals:    make(map[string]interface{}),
+	}
+
+	for _, module := range modules {
+		data, err := c.moduleParametersFn(module)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluating parameters for module %q", module.Name())
+		}
+
+		var m struct {
+			Components map[string]map[string]interface{} `json:"components"`
+		}
+		if err := json.Unmarshal([]byte(data), &m); err != nil {
+			return nil, errors.Wrapf(err, "decoding parameters for module %q", module.Name())
+		}
+
+		for name, values := range m.Components {
+			doc.Components[qualifyComponentName(module.Name(), name)] = normalizeParams(values)
+		}
+	}
+
+	data, err := c.envGlobalsFn()
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluating environment globals")
+	}
+
+	if err := json.Unmarshal([]byte(data), &doc.Globals); err != nil {
+		return nil, errors.Wrap(err, "decoding environment globals")
+	}
+	doc.Globals = normalizeParams(doc.Globals)
+
+	return doc, nil
+}
+
+// ParamExport exports the params of an environment.
+type ParamExport struct {
+	app        app.App
+	envName    string
+	outputType string
+
+	out       io.Writer
+	collector *envParamsCollector
+}
+
+// NewParamExport creates an instance of ParamExport.
+func NewParamExport(m map[string]interface{}) (*ParamExport, error) {
+	ol := newOptionLoader(m)
+
+	pe := &ParamExport{
+		app:        ol.LoadApp(),
+		envName:    ol.LoadString(OptionEnvName),
+		outputType: ol.LoadOptionalString(OptionOutput),
+
+		out: os.Stdout,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if pe.envName == "" {
+		return nil, errors.New("environment is required")
+	}
+
+	switch pe.outputType {
+	case "":
+		pe.outputType = OutputYAML
+	case OutputYAML, OutputJSON:
+	default:
+		return nil, errors.Errorf("unknown output format %q", pe.outputType)
+	}
+
+	pe.collector = newEnvParamsCollector(pe.app, pe.envName)
+
+	return pe, nil
+}
+
+// Run runs the action.
+func (pe *ParamExport) Run() error {
+	doc, err := pe.collector.collect()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if pe.outputType == OutputJSON {
+		data, err = json.MarshalIndent(doc, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return errors.Wrap(err, "encoding params")
+	}
+
+	_, err = pe.out.Write(data)
+	return err
+}
+
+// qualifyComponentName prefixes a component name with its module. Components
+// in the root module are not prefixed.
+func qualifyComponentName(moduleName, name string) string {
+	moduleName = strings.Trim(moduleName, "/")
+	if moduleName == "" || strings.HasPrefix(name, moduleName+".") {
+		return name
+	}
+
+	return moduleName + "." + name
+}
+
+// normalizeParams converts whole numbers in decoded params to ints, so they
+// can be compared with params read from Jsonnet.
+func normalizeParams(m map[string]interface{}) map[string]interface{} {
+	for k, v := range m {
+		m[k] = normalizeValue(v)
+	}
+
+	return m
+}
+
+func normalizeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < math.MaxInt32 {
+			return int(t)
+		}
+	case map[string]interface{}:
+		return normalizeParams(t)
+	case []interface{}:
+		for i := range t {
+			t[i] = normalizeValue(t[i])
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubEnvParamsCollector returns a collector for an app with a root module
+// and a nested module.
+func stubEnvParamsCollector() *envParamsCollector {
+	root := &cmocks.Module{}
+	root.On("Name").Return("/")
+
+	nested := &cmocks.Module{}
+	nested.On("Name").Return("nested")
+
+	moduleParams := map[string]string{
+		"/":      `{"components": {"web": {"replicas": 3, "image": "nginx:1.15", "labels": {"app": "web"}}}}`,
+		"nested": `{"components": {"nested.db": {"port": 5432}}}`,
+	}
+
+	return &envParamsCollector{
+		modulesFn: func() ([]component.Module, error) {
+			return []component.Module{root, nested}, nil
+		},
+		moduleParametersFn: func(module component.Module) (string, error) {
+			return moduleParams[module.Name()], nil
+		},
+		envGlobalsFn: func() (string, error) {
+			return `{"tier": "front"}`, nil
+		},
+	}
+}
+
+func TestParamExport(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		cases := []struct {
+			name       string
+			outputType string
+			outputFile string
+			isErr      bool
+		}{
+			{
+				name:       "yaml",
+				outputFile: filepath.Join("param", "export", "default.yaml"),
+			},
+			{
+				name:       "json",
+				outputType: "json",
+				outputFile: filepath.Join("param", "export", "default.json"),
+			},
+			{
+				name:       "unknown format",
+				outputType: "table",
+				isErr:      true,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionEnvName: "default",
+					OptionOutput:  tc.outputType,
+				}
+
+				pe, err := NewParamExport(in)
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				pe.out = &buf
+				pe.collector = stubEnvParamsCollector()
+
+				require.NoError(t, pe.Run())
+				assertOutput(t, tc.outputFile, buf.String())
+			})
+		}
+	})
+}
+
+func TestParamExport_requires_env(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:     appMock,
+			OptionEnvName: "",
+		}
+
+		_, err := NewParamExport(in)
+		require.Error(t, err)
+	})
+}
+
+func Test_qualifyComponentName(t *testing.T) {
+	cases := []struct {
+		moduleName string
+		name       string
+		expected   string
+	}{
+		{moduleName: "/", name: "web", expected: "web"},
+		{moduleName: "", name: "web", expected: "web"},
+		{moduleName: "nested", name: "web", expected: "nested.web"},
+		{moduleName: "nested", name: "nested.web", expected: "nested.web"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.moduleName+"/"+tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, qualifyComponentName(tc.moduleName, tc.name))
+		})
+	}
+}
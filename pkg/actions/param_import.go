@@ -0,0 +1,398 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	mp "github.com/ksonnet/ksonnet/metadata/params"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	utilio "github.com/ksonnet/ksonnet/pkg/util/io"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// RunParamImport runs `param import`.
+func RunParamImport(m map[string]interface{}) error {
+	pi, err := NewParamImport(m)
+	if err != nil {
+		return err
+	}
+
+	return pi.Run()
+}
+
+// ParamImport sets params from a file. Every file is updated, or none are.
+type ParamImport struct {
+	app     app.App
+	path    string
+	envName string
+
+	getModuleFn   getModuleFn
+	resolvePathFn func(a app.App, path string) (component.Module, component.Component, error)
+	collector     *envParamsCollector
+	validator     *paramsValidator
+
+	// updates are the updated contents of the files which will be written.
+	updates map[string]string
+}
+
+// NewParamImport creates an instance of ParamImport.
+func NewParamImport(m map[string]interface{}) (*ParamImport, error) {
+	ol := newOptionLoader(m)
+
+	pi := &ParamImport{
+		app:     ol.LoadApp(),
+		path:    ol.LoadString(OptionPath),
+		envName: ol.LoadOptionalString(OptionEnvName),
+
+		getModuleFn:   component.GetModule,
+		resolvePathFn: component.ResolvePath,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if pi.envName != "" {
+		pi.collector = newEnvParamsCollector(pi.app, pi.envName)
+	}
+
+	return pi, nil
+}
+
+// Run runs the action.
+func (pi *ParamImport) Run() error {
+	data, err := afero.ReadFile(pi.app.Fs(), pi.path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", pi.path)
+	}
+
+	var doc paramsDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return errors.Wrapf(err, "decoding %s", pi.path)
+	}
+
+	for name := range doc.Components {
+		doc.Components[name] = normalizeParams(doc.Components[name])
+	}
+	doc.Globals = normalizeParams(doc.Globals)
+
+	pi.updates = make(map[string]string)
+	pi.validator = newParamsValidator(pi.app.Fs())
+
+	if pi.envName != "" {
+		err = pi.importEnv(&doc)
+	} else {
+		err = pi.importApp(&doc)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := pi.validator.err(); err != nil {
+		return err
+	}
+
+	return pi.write()
+}
+
+// importApp sets params in module params files.
+func (pi *ParamImport) importApp(doc *paramsDocument) error {
+	for _, name := range sortedComponentNames(doc) {
+		module, c, err := pi.resolveComponent(name)
+		if err != nil {
+			return err
+		}
+
+		localName := c.Name(false)
+		path := module.ParamsPath()
+
+		src, err := pi.read(path)
+		if err != nil {
+			return err
+		}
+
+		current, err := moduleComponentParams(localName, src)
+		if err != nil {
+			return errors.Wrapf(err, "reading params for component %q", name)
+		}
+
+		changed := changedParams(current, doc.Components[name])
+		if len(changed) == 0 {
+			continue
+		}
+
+		for _, key := range changed {
+			src, err = params.SetInObject([]string{key}, src, localName, doc.Components[name][key], "components")
+			if err != nil {
+				return errors.Wrapf(err, "setting %s for component %q", key, name)
+			}
+		}
+
+		updated, err := resolvedComponentParams(localName, src)
+		if err != nil {
+			return errors.Wrapf(err, "reading params for component %q", name)
+		}
+
+		if err := pi.validator.validate(module, localName, updated); err != nil {
+			return err
+		}
+
+		pi.updates[path] = src
+	}
+
+	if len(doc.Globals) == 0 {
+		return nil
+	}
+
+	module, err := pi.getModuleFn(pi.app, "/")
+	if err != nil {
+		return errors.Wrap(err, "retrieve module")
+	}
+
+	path := module.ParamsPath()
+	src, err := pi.read(path)
+	if err != nil {
+		return err
+	}
+
+	current, err := params.ToMap("", src, "global")
+	if err != nil {
+		return errors.Wrap(err, "reading global params")
+	}
+
+	changed := changedParams(current, doc.Globals)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	for _, key := range changed {
+		src, err = params.SetInObject([]string{key}, src, "", doc.Globals[key], "global")
+		if err != nil {
+			return errors.Wrapf(err, "setting global %s", key)
+		}
+	}
+
+	pi.updates[path] = src
+	return nil
+}
+
+// importEnv sets params in environment params files. Params which already
+// have the imported value in the environment are not set.
+func (pi *ParamImport) importEnv(doc *paramsDocument) error {
+	current, err := pi.collector.collect()
+	if err != nil {
+		return err
+	}
+
+	paramsPath, err := env.Path(pi.app, pi.envName, "params.libsonnet")
+	if err != nil {
+		return err
+	}
+
+	eps := params.NewEnvParamSet()
+
+	for _, name := range sortedComponentNames(doc) {
+		module, c, err := pi.resolveComponent(name)
+		if err != nil {
+			return err
+		}
+
+		updated := make(map[string]interface{})
+		for key, value := range current.Components[name] {
+			updated[key] = value
+		}
+
+		changed := make(map[string]interface{})
+		for _, key := range changedParams(current.Components[name], doc.Components[name]) {
+			changed[key] = doc.Components[name][key]
+			updated[key] = doc.Components[name][key]
+		}
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		if err := pi.validator.validate(module, c.Name(false), updated); err != nil {
+			return err
+		}
+
+		src, err := pi.read(paramsPath)
+		if err != nil {
+			return err
+		}
+
+		if src, err = eps.SetValues(name, src, changed); err != nil {
+			return errors.Wrapf(err, "setting params for component %q", name)
+		}
+
+		pi.updates[paramsPath] = src
+	}
+
+	changed := make(mp.Params)
+	for _, key := range changedParams(current.Globals, doc.Globals) {
+		changed[key] = doc.Globals[key]
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	globalsPath, err := env.Path(pi.app, pi.envName, "globals.libsonnet")
+	if err != nil {
+		return err
+	}
+
+	src, err := pi.read(globalsPath)
+	if os.IsNotExist(errors.Cause(err)) {
+		src, err = "{\n}", nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if src, err = params.NewEnvGlobalsSet().Set(src, changed); err != nil {
+		return errors.Wrap(err, "setting environment globals")
+	}
+
+	pi.updates[globalsPath] = src
+	return nil
+}
+
+func (pi *ParamImport) resolveComponent(name string) (component.Module, component.Component, error) {
+	module, c, err := pi.resolvePathFn(pi.app, name)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not find component")
+	}
+
+	if c == nil {
+		return nil, nil, errors.Errorf("unable to find component %s", name)
+	}
+
+	return module, c, nil
+}
+
+// read reads a file, including updates which haven't been written yet.
+func (pi *ParamImport) read(path string) (string, error) {
+	if src, ok := pi.updates[path]; ok {
+		return src, nil
+	}
+
+	data, err := afero.ReadFile(pi.app.Fs(), path)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s", path)
+	}
+
+	return string(data), nil
+}
+
+// write writes the updated files. Files are only replaced once every update has
+// been staged successfully, and files which were already replaced are restored
+// if a later file can't be.
+func (pi *ParamImport) write() error {
+	ft := utilio.NewFileTransaction(pi.app.Fs())
+	for path, src := range pi.updates {
+		ft.Write(path, []byte(src))
+	}
+
+	if err := ft.Commit(); err != nil {
+		return err
+	}
+
+	for path := range pi.updates {
+		logrus.Debugf("updated %s", path)
+	}
+
+	return nil
+}
+
+// moduleComponentParams returns the params of a component in a module's
+// params source. References are returned as they are stored.
+func moduleComponentParams(componentName, src string) (map[string]interface{}, error) {
+	all, err := params.ToMap("", src, "components")
+	if err != nil {
+		return nil, err
+	}
+
+	return componentValues(all, componentName)
+}
+
+// resolvedComponentParams returns the params of a component in a module's
+// params source with references resolved, which is what the params schema
+// describes.
+func resolvedComponentParams(componentName, src string) (map[string]interface{}, error) {
+	all, err := params.ToMap("", src, "components")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := params.ResolveRefs(all); err != nil {
+		return nil, err
+	}
+
+	return componentValues(all, componentName)
+}
+
+// componentValues returns a component's params from a components object.
+// Components without params yet have an empty object, like params.SetInObject
+// creates.
+func componentValues(components map[string]interface{}, componentName string) (map[string]interface{}, error) {
+	v, ok := components[componentName]
+	if !ok {
+		return make(map[string]interface{}), nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("component %q params is not an object", componentName)
+	}
+
+	return m, nil
+}
+
+func sortedComponentNames(doc *paramsDocument) []string {
+	var names []string
+	for name := range doc.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// changedParams returns the sorted keys in updated whose values differ from
+// the values in current.
+func changedParams(current, updated map[string]interface{}) []string {
+	var keys []string
+	for key, value := range updated {
+		if cur, ok := current[key]; ok && reflect.DeepEqual(cur, value) {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
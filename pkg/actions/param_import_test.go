@@ -0,0 +1,213 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamImport(t *testing.T) {
+	cases := []struct {
+		name         string
+		envName      string
+		paramsFile   string
+		importFile   string
+		expectedFile string
+		paramsPath   string
+		schema       bool
+		isErr        bool
+	}{
+		{
+			name:         "app",
+			importFile:   "param/import/app.yaml",
+			paramsPath:   "/components/params.libsonnet",
+			expectedFile: "param/import/app-params.libsonnet",
+		},
+		{
+			name:         "environment",
+			envName:      "default",
+			importFile:   "param/import/env.json",
+			paramsPath:   "/environments/default/params.libsonnet",
+			expectedFile: "param/import/env-params.libsonnet",
+		},
+		{
+			name:         "unchanged",
+			envName:      "default",
+			importFile:   "param/import/unchanged.yaml",
+			paramsPath:   "/environments/default/params.libsonnet",
+			expectedFile: "param/import/env-params-in.libsonnet",
+		},
+		{
+			name:         "app component without params",
+			importFile:   "param/import/new-component.yaml",
+			paramsPath:   "/components/params.libsonnet",
+			expectedFile: "param/import/new-component-params.libsonnet",
+		},
+		{
+			name:         "unknown component",
+			importFile:   "param/import/unknown.yaml",
+			paramsPath:   "/components/params.libsonnet",
+			expectedFile: "param/import/app-params-in.libsonnet",
+			isErr:        true,
+		},
+		{
+			name:         "app with schema",
+			importFile:   "param/import/app.yaml",
+			paramsPath:   "/components/params.libsonnet",
+			expectedFile: "param/import/app-params.libsonnet",
+			schema:       true,
+		},
+		{
+			name:         "app with schema and references",
+			paramsFile:   "param/import/refs-params-in.libsonnet",
+			importFile:   "param/import/refs.yaml",
+			paramsPath:   "/components/params.libsonnet",
+			expectedFile: "param/import/refs-params.libsonnet",
+			schema:       true,
+		},
+		{
+			name:         "app with invalid params",
+			importFile:   "param/import/app-invalid.yaml",
+			paramsPath:   "/components/params.libsonnet",
+			expectedFile: "param/import/app-params-in.libsonnet",
+			schema:       true,
+			isErr:        true,
+		},
+		{
+			name:         "environment with invalid params",
+			envName:      "default",
+			importFile:   "param/import/env-invalid.json",
+			paramsPath:   "/environments/default/params.libsonnet",
+			expectedFile: "param/import/env-params-in.libsonnet",
+			schema:       true,
+			isErr:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				fs := appMock.Fs()
+				appMock.On("Environment", "default").Return(&app.EnvironmentConfig{Path: "default"}, nil)
+
+				paramsFile := tc.paramsFile
+				if paramsFile == "" {
+					paramsFile = "param/import/app-params-in.libsonnet"
+				}
+
+				stageFile(t, fs, paramsFile, "/components/params.libsonnet")
+				stageFile(t, fs, "param/import/env-params-in.libsonnet", "/environments/default/params.libsonnet")
+				stageFile(t, fs, "param/explain/globals.libsonnet", "/environments/default/globals.libsonnet")
+				stageFile(t, fs, tc.importFile, "/import")
+
+				componentsFiles := 1
+				if tc.schema {
+					stageFile(t, fs, "param/import/params.schema.libsonnet", "/components/params.schema.libsonnet")
+					componentsFiles++
+				}
+
+				module := &cmocks.Module{}
+				module.On("Name").Return("/")
+				module.On("ParamsPath").Return("/components/params.libsonnet")
+
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionPath:    "/import",
+					OptionEnvName: tc.envName,
+				}
+
+				pi, err := NewParamImport(in)
+				require.NoError(t, err)
+
+				pi.getModuleFn = func(_ app.App, moduleName string) (component.Module, error) {
+					assert.Equal(t, "/", moduleName)
+					return module, nil
+				}
+
+				pi.resolvePathFn = func(_ app.App, path string) (component.Module, component.Component, error) {
+					if path != "web" && path != "db" && path != "cache" {
+						return nil, nil, errors.Errorf("component %q not found", path)
+					}
+
+					c := &cmocks.Component{}
+					c.On("Name", false).Return(path)
+					return module, c, nil
+				}
+
+				pi.collector = &envParamsCollector{
+					modulesFn: func() ([]component.Module, error) {
+						root := &cmocks.Module{}
+						root.On("Name").Return("/")
+						return []component.Module{root}, nil
+					},
+					moduleParametersFn: func(component.Module) (string, error) {
+						return `{"components": {"web": {"replicas": 3, "tier": "front"}, "db": {"port": 5432, "tier": "front"}}}`, nil
+					},
+					envGlobalsFn: func() (string, error) {
+						return `{"tier": "front"}`, nil
+					},
+				}
+
+				err = pi.Run()
+				if tc.isErr && tc.schema {
+					require.IsType(t, &params.ValidationError{}, err)
+					assert.Equal(t, []params.Violation{
+						{Path: "db.port", Message: "should be greater than or equal to 1024"},
+						{Path: "web.replicas", Message: "should be greater than or equal to 1"},
+					}, err.(*params.ValidationError).Violations)
+				} else if tc.isErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				got, err := afero.ReadFile(fs, tc.paramsPath)
+				require.NoError(t, err)
+				assertOutput(t, tc.expectedFile, string(got))
+
+				// temporary files are not left behind.
+				fis, err := afero.ReadDir(fs, "/components")
+				require.NoError(t, err)
+				assert.Len(t, fis, componentsFiles)
+			})
+		})
+	}
+}
+
+func Test_changedParams(t *testing.T) {
+	current := map[string]interface{}{
+		"replicas": 1,
+		"labels":   map[string]interface{}{"app": "web"},
+	}
+
+	updated := map[string]interface{}{
+		"replicas": 2,
+		"labels":   map[string]interface{}{"app": "web"},
+		"image":    "nginx",
+	}
+
+	assert.Equal(t, []string{"image", "replicas"}, changedParams(current, updated))
+}
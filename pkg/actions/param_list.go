@@ -122,15 +122,31 @@ func (pl *ParamList) Run() error {
 func (pl *ParamList) print(entries []params.Entry) error {
 	t := table.New("paramList", pl.out)
 
-	f, err := table.DetectFormat(pl.outputType)
-	if err != nil {
+	if err := t.SetOutput(pl.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
-	t.SetHeader([]string{"component", "param", "value"})
+	// only show references if there are entries which use them.
+	hasReferences := false
+	for _, entry := range entries {
+		if entry.Reference != "" {
+			hasReferences = true
+			break
+		}
+	}
+
+	if hasReferences {
+		t.SetHeader([]string{"component", "param", "value", "reference"})
+	} else {
+		t.SetHeader([]string{"component", "param", "value"})
+	}
+
 	for _, entry := range entries {
-		t.Append([]string{entry.ComponentName, entry.ParamName, entry.Value})
+		row := []string{entry.ComponentName, entry.ParamName, entry.Value}
+		if hasReferences {
+			row = append(row, entry.Reference)
+		}
+		t.Append(row)
 	}
 
 	return t.Render()
@@ -0,0 +1,117 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// paramsValidator validates component params against their module's parameter
+// schema. Violations are collected so every violation can be reported at once.
+type paramsValidator struct {
+	fs         afero.Fs
+	schemas    map[string]*params.Schema
+	violations []params.Violation
+}
+
+func newParamsValidator(fs afero.Fs) *paramsValidator {
+	return &paramsValidator{
+		fs:      fs,
+		schemas: make(map[string]*params.Schema),
+	}
+}
+
+// validate validates a component's params. componentName is the component's
+// name in its module.
+func (v *paramsValidator) validate(module component.Module, componentName string, values map[string]interface{}) error {
+	path := module.ParamsPath()
+
+	schema, ok := v.schemas[path]
+	if !ok {
+		var err error
+		schema, err = params.LoadSchema(v.fs, path)
+		if err != nil {
+			return errors.Wrapf(err, "loading parameter schema for module %q", module.Name())
+		}
+		v.schemas[path] = schema
+	}
+
+	err := schema.ValidateComponent(componentName, values)
+	if err == nil {
+		return nil
+	}
+
+	ve, ok := err.(*params.ValidationError)
+	if !ok {
+		return err
+	}
+
+	for _, violation := range ve.Violations {
+		violation.Path = qualifyComponentName(module.Name(), violation.Path)
+		v.violations = append(v.violations, violation)
+	}
+
+	return nil
+}
+
+// err returns a *params.ValidationError if any params were invalid.
+func (v *paramsValidator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return &params.ValidationError{Violations: v.violations}
+}
+
+// envComponentParams returns a component's params from evaluated environment
+// params.
+func envComponentParams(data, componentName string) (map[string]interface{}, error) {
+	var m struct {
+		Components map[string]map[string]interface{} `json:"components"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return nil, errors.Wrap(err, "decoding parameters")
+	}
+
+	values := m.Components[componentName]
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	return normalizeParams(values), nil
+}
+
+// setParamValue sets the value at path in a component's params, creating
+// objects as needed.
+func setParamValue(values map[string]interface{}, path []string, value interface{}) {
+	cur := values
+	for _, key := range path[:len(path)-1] {
+		next, ok := cur[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			cur[key] = next
+		}
+		cur = next
+	}
+
+	cur[path[len(path)-1]] = value
+}
@@ -16,15 +16,18 @@
 package actions
 
 import (
+	"encoding/json"
 	"strings"
 
 	mp "github.com/ksonnet/ksonnet/metadata/params"
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/component"
 	"github.com/ksonnet/ksonnet/pkg/env"
-	"github.com/ksonnet/ksonnet/pkg/util/dockerregistry"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
 	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 )
 
 // RunParamSet runs `param set`
@@ -47,12 +50,14 @@ type ParamSet struct {
 	envName      string
 	asString     bool
 	resolveImage bool
+	reference    string
 
 	getModuleFn    getModuleFn
 	resolvePathFn  func(a app.App, path string) (component.Module, component.Component, error)
 	setEnvFn       func(ksApp app.App, envName, name, pName, value string) error
 	setGlobalEnvFn func(ksApp app.App, envName, pName, value string) error
 	resolveImageFn func(image string) (string, error)
+	validateFn     func(path []string, value interface{}) error
 }
 
 // NewParamSet creates an instance of ParamSet.
@@ -68,27 +73,47 @@ func NewParamSet(m map[string]interface{}) (*ParamSet, error) {
 		envName:      ol.LoadOptionalString(OptionEnvName),
 		asString:     ol.LoadOptionalBool(OptionAsString),
 		resolveImage: ol.LoadOptionalBool(OptionResolveImage),
+		reference:    ol.LoadOptionalString(OptionReference),
 
 		getModuleFn:    component.GetModule,
 		resolvePathFn:  component.ResolvePath,
 		setEnvFn:       setEnv,
 		setGlobalEnvFn: setGlobalEnv,
-		resolveImageFn: dockerregistry.ResolveImage,
 	}
 
 	if ol.err != nil {
 		return nil, ol.err
 	}
 
+	ps.resolveImageFn = func(image string) (string, error) {
+		return resolveImage(ps.app.HTTPClient(), image)
+	}
+
+	ps.validateFn = ps.validate
+
 	if ps.envName != "" && ps.global {
 		return nil, errors.New("unable to set global param for environments")
 	}
 
+	if ps.reference != "" {
+		if ps.global || ps.name == "" {
+			return nil, errors.New("references can only be set for component params")
+		}
+
+		if ps.asString || ps.resolveImage {
+			return nil, errors.New("references can't be combined with as-string or resolve-image")
+		}
+	}
+
 	return ps, nil
 }
 
 // Run runs the action.
 func (ps *ParamSet) Run() error {
+	if ps.reference != "" {
+		return ps.setReference()
+	}
+
 	var value interface{}
 	var err error
 
@@ -113,6 +138,15 @@ func (ps *ParamSet) Run() error {
 		}
 
 		if ps.name != "" {
+			decoded, err := jsonnet.DecodeValue(value)
+			if err != nil {
+				return errors.Wrap(err, "value is invalid")
+			}
+
+			if err := ps.validateFn(strings.Split(ps.rawPath, "."), decoded); err != nil {
+				return err
+			}
+
 			return ps.setEnvFn(ps.app, ps.envName, ps.name, ps.rawPath, value)
 		}
 		return ps.setGlobalEnvFn(ps.app, ps.envName, ps.rawPath, value)
@@ -137,9 +171,136 @@ func (ps *ParamSet) Run() error {
 		return ps.setGlobal(path, value)
 	}
 
+	if err := ps.validateFn(path, value); err != nil {
+		return err
+	}
+
 	return ps.setLocal(path, value)
 }
 
+// validate validates a component param against its module's parameter schema
+// before it is set.
+func (ps *ParamSet) validate(path []string, value interface{}) error {
+	module, c, err := ps.resolvePathFn(ps.app, ps.name)
+	if err != nil {
+		return errors.Wrap(err, "could not find component")
+	}
+
+	if c == nil {
+		return errors.Errorf("unable to find component %s", ps.name)
+	}
+
+	componentName := c.Name(false)
+
+	var components map[string]interface{}
+	if ps.envName != "" {
+		data, err := pipeline.New(ps.app, ps.envName).ModuleParameters(module)
+		if err != nil {
+			return errors.Wrap(err, "evaluating parameters")
+		}
+
+		var m struct {
+			Components map[string]interface{} `json:"components"`
+		}
+		if err := json.Unmarshal([]byte(data), &m); err != nil {
+			return errors.Wrap(err, "decoding parameters")
+		}
+		components = m.Components
+	} else {
+		data, err := afero.ReadFile(ps.app.Fs(), module.ParamsPath())
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", module.ParamsPath())
+		}
+
+		components, err = params.ToMap("", string(data), "components")
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", module.ParamsPath())
+		}
+	}
+
+	if components == nil {
+		components = make(map[string]interface{})
+	}
+
+	values, err := componentValues(components, componentName)
+	if err != nil {
+		return err
+	}
+
+	setParamValue(values, path, normalizeValue(value))
+	components[componentName] = values
+
+	// the schema describes the values components see, so references are
+	// resolved before validating.
+	if err := params.ResolveRefs(components); err != nil {
+		return err
+	}
+
+	values, _ = components[componentName].(map[string]interface{})
+	values = normalizeParams(values)
+
+	v := newParamsValidator(ps.app.Fs())
+	if err := v.validate(module, componentName, values); err != nil {
+		return err
+	}
+
+	return v.err()
+}
+
+// setReference sets a param which references another component's param.
+func (ps *ParamSet) setReference() error {
+	ref, err := params.NewRef(ps.reference)
+	if err != nil {
+		return err
+	}
+
+	if err = ps.checkRefTarget(); err != nil {
+		return err
+	}
+
+	path := strings.Split(ps.rawPath, ".")
+	if err = ps.validateFn(path, ref); err != nil {
+		return err
+	}
+
+	if ps.envName != "" {
+		data, err := json.Marshal(ref)
+		if err != nil {
+			return errors.Wrap(err, "encoding reference")
+		}
+
+		return ps.setEnvFn(ps.app, ps.envName, ps.name, ps.rawPath, string(data))
+	}
+
+	return ps.setLocal(path, ref)
+}
+
+// checkRefTarget checks the component a reference points at exists, so a typo
+// is caught when the reference is set rather than when it is rendered.
+// References point at components in the same module.
+func (ps *ParamSet) checkRefTarget() error {
+	targetName, _, err := params.ParseRef(ps.reference)
+	if err != nil {
+		return err
+	}
+
+	module, _, err := ps.resolvePathFn(ps.app, ps.name)
+	if err != nil {
+		return errors.Wrap(err, "could not find component")
+	}
+
+	if name := module.Name(); name != "/" && name != "" {
+		targetName = strings.Join([]string{name, targetName}, ".")
+	}
+
+	_, c, err := ps.resolvePathFn(ps.app, targetName)
+	if err != nil || c == nil {
+		return errors.Errorf("reference %q points at unknown component %q", ps.reference, targetName)
+	}
+
+	return nil
+}
+
 func (ps *ParamSet) setGlobal(path []string, value interface{}) error {
 	module, err := ps.getModuleFn(ps.app, ps.name)
 	if err != nil {
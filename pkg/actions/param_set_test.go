@@ -22,7 +22,11 @@ import (
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
 	"github.com/ksonnet/ksonnet/pkg/component"
 	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -44,6 +48,7 @@ func TestParamSet(t *testing.T) {
 
 		a, err := NewParamSet(in)
 		require.NoError(t, err)
+		a.validateFn = skipParamValidation
 
 		a.resolvePathFn = func(app.App, string) (component.Module, component.Component, error) {
 			return nil, c, nil
@@ -98,6 +103,7 @@ func TestParamSet_asString(t *testing.T) {
 
 		a, err := NewParamSet(in)
 		require.NoError(t, err)
+		a.validateFn = skipParamValidation
 
 		a.resolvePathFn = func(app.App, string) (component.Module, component.Component, error) {
 			return nil, c, nil
@@ -127,6 +133,7 @@ func TestParamSet_resolveImage(t *testing.T) {
 
 		a, err := NewParamSet(in)
 		require.NoError(t, err)
+		a.validateFn = skipParamValidation
 
 		a.resolvePathFn = func(app.App, string) (component.Module, component.Component, error) {
 			return nil, c, nil
@@ -141,6 +148,39 @@ func TestParamSet_resolveImage(t *testing.T) {
 	})
 }
 
+func TestParamSet_resolveImage_registry(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		ts, httpClient := newFakeRegistry(t)
+		defer ts.Close()
+
+		appMock.On("HTTPClient").Return(httpClient)
+
+		c := &cmocks.Component{}
+		c.On("SetParam", []string{"image"}, "registry.example.com/team/web@sha256:aaaa").Return(nil)
+
+		in := map[string]interface{}{
+			OptionApp:          appMock,
+			OptionName:         "web",
+			OptionPath:         "image",
+			OptionValue:        "registry.example.com/team/web:1.0",
+			OptionResolveImage: true,
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+		a.validateFn = skipParamValidation
+
+		a.resolvePathFn = func(app.App, string) (component.Module, component.Component, error) {
+			return nil, c, nil
+		}
+
+		err = a.Run()
+		require.NoError(t, err)
+
+		c.AssertExpectations(t)
+	})
+}
+
 func TestParamSet_global(t *testing.T) {
 	withApp(t, func(appMock *amocks.App) {
 		module := "/"
@@ -186,6 +226,7 @@ func TestParamSet_env(t *testing.T) {
 
 		a, err := NewParamSet(in)
 		require.NoError(t, err)
+		a.validateFn = skipParamValidation
 
 		envSetter := func(ksApp app.App, envName, name, pName, value string) error {
 			assert.Equal(t, "default", envName)
@@ -218,6 +259,7 @@ func TestParamSet_env_resolveImage(t *testing.T) {
 
 		a, err := NewParamSet(in)
 		require.NoError(t, err)
+		a.validateFn = skipParamValidation
 
 		envSetter := func(ksApp app.App, envName, name, pName, value string) error {
 			assert.Equal(t, "default", envName)
@@ -269,3 +311,277 @@ func TestParamSet_requires_app(t *testing.T) {
 	_, err := NewParamSet(in)
 	require.Error(t, err)
 }
+
+func TestParamSet_reference(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		m := &cmocks.Module{}
+		m.On("Name").Return("/")
+
+		c := &cmocks.Component{}
+		c.On("SetParam", []string{"db_host"}, map[string]interface{}{"__ksonnet/ref": "db.service_name"}).Return(nil)
+
+		components := map[string]component.Component{
+			"web": c,
+			"db":  &cmocks.Component{},
+		}
+
+		in := map[string]interface{}{
+			OptionApp:       appMock,
+			OptionName:      "web",
+			OptionPath:      "db_host",
+			OptionValue:     "",
+			OptionReference: "db.service_name",
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+
+		a.resolvePathFn = func(_ app.App, path string) (component.Module, component.Component, error) {
+			return m, components[path], nil
+		}
+		a.validateFn = skipParamValidation
+
+		err = a.Run()
+		require.NoError(t, err)
+	})
+}
+
+func TestParamSet_reference_env(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:       appMock,
+			OptionName:      "web",
+			OptionPath:      "db_host",
+			OptionValue:     "",
+			OptionEnvName:   "default",
+			OptionReference: "db.service_name",
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+
+		m := &cmocks.Module{}
+		m.On("Name").Return("nested")
+
+		a.resolvePathFn = func(_ app.App, path string) (component.Module, component.Component, error) {
+			assert.Contains(t, []string{"web", "nested.db"}, path)
+			return m, &cmocks.Component{}, nil
+		}
+		a.validateFn = skipParamValidation
+
+		var got string
+		a.setEnvFn = func(ksApp app.App, envName, name, pName, value string) error {
+			assert.Equal(t, "default", envName)
+			assert.Equal(t, "web", name)
+			assert.Equal(t, "db_host", pName)
+			got = value
+			return nil
+		}
+
+		err = a.Run()
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"__ksonnet/ref":"db.service_name"}`, got)
+	})
+}
+
+func TestParamSet_reference_unknown_component(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:       appMock,
+			OptionName:      "web",
+			OptionPath:      "db_host",
+			OptionValue:     "",
+			OptionReference: "dbb.service_name",
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+
+		m := &cmocks.Module{}
+		m.On("Name").Return("/")
+
+		c := &cmocks.Component{}
+
+		a.resolvePathFn = func(_ app.App, path string) (component.Module, component.Component, error) {
+			if path == "web" {
+				return m, c, nil
+			}
+			return nil, nil, errors.Errorf("%q is not a component or a module", path)
+		}
+
+		err = a.Run()
+		require.EqualError(t, err, `reference "dbb.service_name" points at unknown component "dbb"`)
+
+		c.AssertNotCalled(t, "SetParam", mock.Anything, mock.Anything)
+	})
+}
+
+func TestParamSet_reference_invalid(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:       appMock,
+			OptionName:      "web",
+			OptionPath:      "db_host",
+			OptionValue:     "",
+			OptionReference: "db",
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+
+		err = a.Run()
+		require.Error(t, err)
+
+		in[OptionGlobal] = true
+		in[OptionReference] = "db.service_name"
+		_, err = NewParamSet(in)
+		require.Error(t, err)
+	})
+}
+
+func TestParamSet_schema(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		stageFile(t, appMock.Fs(), "param/schema/params.libsonnet", "/components/params.libsonnet")
+		stageFile(t, appMock.Fs(), "param/schema/params.schema.libsonnet", "/components/params.schema.libsonnet")
+
+		m := &cmocks.Module{}
+		m.On("Name").Return("/")
+		m.On("ParamsPath").Return("/components/params.libsonnet")
+
+		c := &cmocks.Component{}
+		c.On("Name", false).Return("web")
+		c.On("SetParam", []string{"replicas"}, 3).Return(nil)
+		c.On("SetParam", []string{"db_port"}, map[string]interface{}{"__ksonnet/ref": "db.port"}).Return(nil)
+
+		cases := []struct {
+			name      string
+			path      string
+			value     string
+			reference string
+			isErr     bool
+		}{
+			{
+				name:  "valid",
+				path:  "replicas",
+				value: "3",
+			},
+			{
+				name:      "valid reference",
+				path:      "db_port",
+				reference: "db.port",
+			},
+			{
+				name:      "reference to param of wrong type",
+				path:      "db_port",
+				reference: "db.host",
+				isErr:     true,
+			},
+			{
+				name:  "below minimum",
+				path:  "replicas",
+				value: "0",
+				isErr: true,
+			},
+			{
+				name:  "not in enum",
+				path:  "tier",
+				value: "gold",
+				isErr: true,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				in := map[string]interface{}{
+					OptionApp:       appMock,
+					OptionName:      "web",
+					OptionPath:      tc.path,
+					OptionValue:     tc.value,
+					OptionReference: tc.reference,
+				}
+
+				a, err := NewParamSet(in)
+				require.NoError(t, err)
+
+				a.resolvePathFn = func(app.App, string) (component.Module, component.Component, error) {
+					return m, c, nil
+				}
+
+				err = a.Run()
+				if tc.isErr {
+					require.IsType(t, &params.ValidationError{}, err)
+					return
+				}
+
+				require.NoError(t, err)
+			})
+		}
+
+		c.AssertNumberOfCalls(t, "SetParam", 2)
+	})
+}
+
+func TestParamSet_schema_invalid_params(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		err := afero.WriteFile(appMock.Fs(), "/components/params.libsonnet", []byte("{ components: "), 0644)
+		require.NoError(t, err)
+
+		m := &cmocks.Module{}
+		m.On("ParamsPath").Return("/components/params.libsonnet")
+
+		c := &cmocks.Component{}
+		c.On("Name", false).Return("web")
+
+		in := map[string]interface{}{
+			OptionApp:   appMock,
+			OptionName:  "web",
+			OptionPath:  "replicas",
+			OptionValue: "3",
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+
+		a.resolvePathFn = func(app.App, string) (component.Module, component.Component, error) {
+			return m, c, nil
+		}
+
+		err = a.Run()
+		require.Error(t, err)
+
+		c.AssertNotCalled(t, "SetParam", mock.Anything, mock.Anything)
+	})
+}
+
+func TestParamSet_env_schema(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:     appMock,
+			OptionName:    "web",
+			OptionPath:    "replicas",
+			OptionValue:   "0",
+			OptionEnvName: "default",
+		}
+
+		a, err := NewParamSet(in)
+		require.NoError(t, err)
+
+		a.validateFn = func(path []string, value interface{}) error {
+			assert.Equal(t, []string{"replicas"}, path)
+			assert.Equal(t, 0, value)
+			return &params.ValidationError{}
+		}
+		a.setEnvFn = func(ksApp app.App, envName, name, pName, value string) error {
+			t.Fatal("invalid param was set")
+			return nil
+		}
+
+		err = a.Run()
+		require.Error(t, err)
+	})
+}
+
+func skipParamValidation([]string, interface{}) error {
+	return nil
+}
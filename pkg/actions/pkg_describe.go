@@ -23,6 +23,7 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/pkg"
 	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 )
 
 // RunPkgDescribe runs `pkg install`
@@ -40,6 +41,7 @@ type PkgDescribe struct {
 	app     app.App
 	pkgName string
 
+	outputType     string
 	templateSrc    string
 	out            io.Writer
 	packageManager registry.PackageManager
@@ -56,6 +58,7 @@ func NewPkgDescribe(m map[string]interface{}) (*PkgDescribe, error) {
 		app:     app,
 		pkgName: ol.LoadString(OptionPackageName),
 
+		outputType:     ol.LoadOptionalString(OptionOutput),
 		templateSrc:    pkgDescribeTemplate,
 		out:            os.Stdout,
 		packageManager: registry.NewPackageManager(app, httpClientOpt),
@@ -85,12 +88,18 @@ func (pd *PkgDescribe) Run() error {
 		"Description": p.Description(),
 	}
 
+	desc := pkgDescription{
+		Name:        pd.pkgName,
+		Description: p.Description(),
+	}
+
 	isInstalled, err := p.IsInstalled()
 	if err != nil {
 		return err
 	}
 
 	data["IsInstalled"] = isInstalled
+	desc.Installed = isInstalled
 
 	if isInstalled {
 		prototypes, err := p.Prototypes()
@@ -99,18 +108,37 @@ func (pd *PkgDescribe) Run() error {
 		}
 
 		data["Prototypes"] = prototypes
+		for _, proto := range prototypes {
+			desc.Prototypes = append(desc.Prototypes, prototypeSummary{
+				Name:        proto.Name,
+				Description: proto.Template.ShortDescription,
+			})
+		}
 	}
 
-	t, err := template.New("pkg-describe").Parse(pd.templateSrc)
-	if err != nil {
-		return err
-	}
+	return table.RenderObject(pd.out, "pkgDescribe", pd.outputType, desc, func(w io.Writer) error {
+		t, err := template.New("pkg-describe").Parse(pd.templateSrc)
+		if err != nil {
+			return err
+		}
 
-	if err = t.Execute(pd.out, data); err != nil {
-		return err
-	}
+		return t.Execute(w, data)
+	})
+}
+
+// pkgDescription is the structured output of `pkg describe`. Prototypes are
+// only listed for installed packages.
+type pkgDescription struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Installed   bool               `json:"installed"`
+	Prototypes  []prototypeSummary `json:"prototypes,omitempty"`
+}
 
-	return nil
+// prototypeSummary is the name and short description of a prototype.
+type prototypeSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
 
 const pkgDescribeTemplate = `LIBRARY NAME:
@@ -39,6 +39,7 @@ func TestPkgDescribe(t *testing.T) {
 
 	cases := []struct {
 		name        string
+		outputType  string
 		output      string
 		pkgManager  func() registry.PackageManager
 		isErr       bool
@@ -83,6 +84,31 @@ func TestPkgDescribe(t *testing.T) {
 				return pkgManager
 			},
 		},
+		{
+			name:       "with prototypes in json",
+			outputType: "json",
+			output:     "pkg/describe/with-prototypes.json",
+			pkgManager: func() registry.PackageManager {
+				prototypes := prototype.Prototypes{
+					{
+						Name: "proto1",
+						Template: prototype.SnippetSchema{
+							ShortDescription: "short description",
+						},
+					},
+				}
+
+				p := &pkgmocks.Package{}
+				p.On("Description").Return("description")
+				p.On("IsInstalled").Return(true, nil)
+				p.On("Prototypes").Return(prototypes, nil)
+
+				pkgManager := &regmocks.PackageManager{}
+				pkgManager.On("Find", d).Return(p, nil)
+
+				return pkgManager
+			},
+		},
 		{
 			name:  "package manager find error",
 			isErr: true,
@@ -157,6 +183,7 @@ func TestPkgDescribe(t *testing.T) {
 				in := map[string]interface{}{
 					OptionApp:           a,
 					OptionPackageName:   "apache",
+					OptionOutput:        tc.outputType,
 					OptionTLSSkipVerify: false,
 				}
 
@@ -133,11 +133,9 @@ func (pl *PkgList) Run() error {
 
 	t := table.New("pkgList", pl.out)
 
-	f, err := table.DetectFormat(pl.outputType)
-	if err != nil {
+	if err := t.SetOutput(pl.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
 	t.SetHeader([]string{"registry", "name", "version", "installed", "environments"})
 	t.AppendBulk(rows)
@@ -25,6 +25,7 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/pkg"
 	"github.com/ksonnet/ksonnet/pkg/prototype"
 	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 	"github.com/pkg/errors"
 )
 
@@ -42,6 +43,7 @@ func RunPrototypeDescribe(m map[string]interface{}) error {
 type PrototypeDescribe struct {
 	app            app.App
 	out            io.Writer
+	outputType     string
 	query          string
 	packageManager registry.PackageManager
 }
@@ -54,8 +56,9 @@ func NewPrototypeDescribe(m map[string]interface{}) (*PrototypeDescribe, error)
 	httpClientOpt := registry.HTTPClientOpt(ol.LoadHTTPClient())
 
 	pd := &PrototypeDescribe{
-		app:   app,
-		query: ol.LoadString(OptionQuery),
+		app:        app,
+		query:      ol.LoadString(OptionQuery),
+		outputType: ol.LoadOptionalString(OptionOutput),
 
 		out:            os.Stdout,
 		packageManager: registry.NewPackageManager(app, httpClientOpt),
@@ -90,22 +93,42 @@ func (pd *PrototypeDescribe) Run() error {
 		return err
 	}
 
-	fmt.Fprintln(pd.out, `PROTOTYPE NAME:`)
-	fmt.Fprintln(pd.out, p.Name)
-	fmt.Fprintln(pd.out)
-	fmt.Fprintln(pd.out, `DESCRIPTION:`)
-	fmt.Fprintln(pd.out, p.Template.Description)
-	fmt.Fprintln(pd.out)
-	fmt.Fprintln(pd.out, `REQUIRED PARAMETERS:`)
-	fmt.Fprintln(pd.out, p.RequiredParams().PrettyString("  "))
-	fmt.Fprintln(pd.out)
-	fmt.Fprintln(pd.out, `OPTIONAL PARAMETERS:`)
-	fmt.Fprintln(pd.out, p.OptionalParams().PrettyString("  "))
-	fmt.Fprintln(pd.out)
-	fmt.Fprintln(pd.out, `TEMPLATE TYPES AVAILABLE:`)
-	fmt.Fprintf(pd.out, "  %s\n", p.Template.AvailableTemplates())
-
-	return nil
+	desc := prototypeDescription{
+		Name:           p.Name,
+		Description:    p.Template.Description,
+		RequiredParams: append(prototype.ParamSchemas{}, p.RequiredParams()...),
+		OptionalParams: append(prototype.ParamSchemas{}, p.OptionalParams()...),
+		TemplateTypes:  append([]prototype.TemplateType{}, p.Template.AvailableTemplates()...),
+	}
+
+	return table.RenderObject(pd.out, "prototypeDescribe", pd.outputType, desc, func(w io.Writer) error {
+		fmt.Fprintln(w, `PROTOTYPE NAME:`)
+		fmt.Fprintln(w, p.Name)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `DESCRIPTION:`)
+		fmt.Fprintln(w, p.Template.Description)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `REQUIRED PARAMETERS:`)
+		fmt.Fprintln(w, p.RequiredParams().PrettyString("  "))
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `OPTIONAL PARAMETERS:`)
+		fmt.Fprintln(w, p.OptionalParams().PrettyString("  "))
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `TEMPLATE TYPES AVAILABLE:`)
+		fmt.Fprintf(w, "  %s\n", p.Template.AvailableTemplates())
+
+		return nil
+	})
+}
+
+// prototypeDescription is the structured output of `prototype describe`.
+// Required params have a null default.
+type prototypeDescription struct {
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description"`
+	RequiredParams prototype.ParamSchemas   `json:"requiredParams"`
+	OptionalParams prototype.ParamSchemas   `json:"optionalParams"`
+	TemplateTypes  []prototype.TemplateType `json:"templateTypes"`
 }
 
 type prototypeFn func(app.App, pkg.Descriptor) (prototype.Prototypes, error)
@@ -26,31 +26,52 @@ import (
 )
 
 func TestPrototypeDescribe(t *testing.T) {
-	withApp(t, func(appMock *amocks.App) {
-		prototypes := prototype.Prototypes{}
+	cases := []struct {
+		name       string
+		outputType string
+		outputFile string
+	}{
+		{
+			name:       "default",
+			outputFile: "prototype/describe/output.txt",
+		},
+		{
+			name:       "json",
+			outputType: "json",
+			outputFile: "prototype/describe/output.json",
+		},
+	}
 
-		manager := &registrymocks.PackageManager{}
-		manager.On("Prototypes").Return(prototypes, nil)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				prototypes := prototype.Prototypes{}
 
-		in := map[string]interface{}{
-			OptionApp:           appMock,
-			OptionQuery:         "namespace",
-			OptionTLSSkipVerify: false,
-		}
+				manager := &registrymocks.PackageManager{}
+				manager.On("Prototypes").Return(prototypes, nil)
 
-		a, err := NewPrototypeDescribe(in)
-		require.NoError(t, err)
+				in := map[string]interface{}{
+					OptionApp:           appMock,
+					OptionQuery:         "namespace",
+					OptionOutput:        tc.outputType,
+					OptionTLSSkipVerify: false,
+				}
 
-		a.packageManager = manager
+				a, err := NewPrototypeDescribe(in)
+				require.NoError(t, err)
 
-		var buf bytes.Buffer
-		a.out = &buf
+				a.packageManager = manager
 
-		err = a.Run()
-		require.NoError(t, err)
+				var buf bytes.Buffer
+				a.out = &buf
 
-		assertOutput(t, "prototype/describe/output.txt", buf.String())
-	})
+				err = a.Run()
+				require.NoError(t, err)
+
+				assertOutput(t, tc.outputFile, buf.String())
+			})
+		})
+	}
 }
 
 func TestPrototypeDescribe_requires_app(t *testing.T) {
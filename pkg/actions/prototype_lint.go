@@ -0,0 +1,230 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/pkg"
+	"github.com/ksonnet/ksonnet/pkg/prototype"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// RunPrototypeLint runs `prototype lint`
+func RunPrototypeLint(m map[string]interface{}) error {
+	pl, err := NewPrototypeLint(m)
+	if err != nil {
+		return err
+	}
+
+	return pl.Run()
+}
+
+// PrototypeLint checks prototype sources for problems. Jsonnet templates are
+// evaluated with the libraries of an environment, if one is set.
+type PrototypeLint struct {
+	app        app.App
+	envName    string
+	target     string
+	outputType string
+
+	out               io.Writer
+	packageManager    registry.PackageManager
+	lintFn            func(src string, opts ...prototype.LintOpt) []prototype.LintIssue
+	evaluateJsonnetFn func(a app.App, envName, snippet, paramsStr string) (string, error)
+}
+
+// NewPrototypeLint creates an instance of PrototypeLint. The target is a
+// prototype file, a directory of prototypes, or a package.
+func NewPrototypeLint(m map[string]interface{}) (*PrototypeLint, error) {
+	ol := newOptionLoader(m)
+
+	app := ol.LoadApp()
+	httpClientOpt := registry.HTTPClientOpt(ol.LoadHTTPClient())
+
+	pl := &PrototypeLint{
+		app:        app,
+		envName:    ol.LoadOptionalString(OptionEnvName),
+		target:     ol.LoadString(OptionPath),
+		outputType: ol.LoadOptionalString(OptionOutput),
+
+		out:               os.Stdout,
+		packageManager:    registry.NewPackageManager(app, httpClientOpt),
+		lintFn:            prototype.Lint,
+		evaluateJsonnetFn: evaluatePrototypeJsonnet,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if pl.envName == "" {
+		pl.envName = pl.app.CurrentEnvironment()
+	}
+
+	return pl, nil
+}
+
+// Run lints the prototypes.
+func (pl *PrototypeLint) Run() error {
+	paths, err := pl.prototypePaths()
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return errors.Errorf("no prototypes found in %q", pl.target)
+	}
+
+	var opts []prototype.LintOpt
+	if pl.envName != "" {
+		opts = append(opts, prototype.WithJsonnetEvaluator(pl.evaluateJsonnet))
+	}
+
+	var rows [][]string
+	var errorCount int
+	for _, path := range paths {
+		data, err := afero.ReadFile(pl.app.Fs(), path)
+		if err != nil {
+			return err
+		}
+
+		name := pl.displayPath(path)
+		for _, issue := range pl.lintFn(string(data), opts...) {
+			if issue.Severity == prototype.LintError {
+				errorCount++
+			}
+			rows = append(rows, []string{name, string(issue.Severity), issue.Message})
+		}
+	}
+
+	if len(rows) > 0 {
+		t := table.New("prototypeLint", pl.out)
+		t.SetHeader([]string{"file", "severity", "message"})
+
+		if err := t.SetOutput(pl.outputType); err != nil {
+			return errors.Wrap(err, "detecting output format")
+		}
+
+		t.AppendBulk(rows)
+		if err = t.Render(); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(pl.out, "%d prototypes passed lint\n", len(paths))
+	}
+
+	if errorCount > 0 {
+		return errors.Errorf("prototype lint found %d errors", errorCount)
+	}
+
+	return nil
+}
+
+// evaluateJsonnet evaluates a prototype's Jsonnet template in the environment,
+// like `prototype test`.
+func (pl *PrototypeLint) evaluateJsonnet(p *prototype.Prototype, values map[string]string) (string, error) {
+	text, err := expandPrototype(p, prototype.Jsonnet, values, prototypeTestComponent)
+	if err != nil {
+		return "", err
+	}
+
+	return pl.evaluateJsonnetFn(pl.app, pl.envName, text, prototypeTestParams(values))
+}
+
+// prototypePaths returns the prototype files for the target. A directory
+// containing a `prototypes` directory is treated as a package.
+func (pl *PrototypeLint) prototypePaths() ([]string, error) {
+	fs := pl.app.Fs()
+
+	fi, err := fs.Stat(pl.target)
+	if err == nil && !fi.IsDir() {
+		return []string{pl.target}, nil
+	}
+
+	dir := pl.target
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		if dir, err = pl.packageDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	protoDir := filepath.Join(dir, "prototypes")
+	if ok, _ := afero.DirExists(fs, protoDir); ok {
+		dir = protoDir
+	}
+
+	var paths []string
+	err = afero.Walk(fs, dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !fi.IsDir() && filepath.Ext(path) == ".jsonnet" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// packageDir returns the vendored directory of the package named by the target.
+func (pl *PrototypeLint) packageDir() (string, error) {
+	d, err := pkg.Parse(pl.target)
+	if err != nil {
+		return "", errors.Errorf("%q is not a file, directory or package", pl.target)
+	}
+
+	p, err := pl.packageManager.Find(d)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding package %q", pl.target)
+	}
+
+	installed, err := p.IsInstalled()
+	if err != nil {
+		return "", err
+	}
+	if !installed || p.Path() == "" {
+		return "", errors.Errorf("package %q is not installed", pl.target)
+	}
+
+	return p.Path(), nil
+}
+
+// displayPath returns a path relative to the application root if possible.
+func (pl *PrototypeLint) displayPath(path string) string {
+	if rel, err := filepath.Rel(pl.app.Root(), path); err == nil && !filepath.IsAbs(pl.target) {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/pkg"
+	pkgmocks "github.com/ksonnet/ksonnet/pkg/pkg/mocks"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	regmocks "github.com/ksonnet/ksonnet/pkg/registry/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrototypeLint(t *testing.T) {
+	d := pkg.Descriptor{Registry: "incubator", Name: "web"}
+
+	cases := []struct {
+		name       string
+		target     string
+		envName    string
+		outputType string
+		outputFile string
+		pkgManager func() registry.PackageManager
+		isErr      bool
+	}{
+		{
+			name:       "valid file",
+			target:     "/lib/valid.jsonnet",
+			outputFile: "prototype/lint/valid.txt",
+		},
+		{
+			name:       "evaluate in environment",
+			target:     "/lib/valid.jsonnet",
+			envName:    "default",
+			outputFile: "prototype/lint/evaluate.txt",
+			isErr:      true,
+		},
+		{
+			name:       "directory",
+			target:     "/lib",
+			outputType: "table",
+			outputFile: "prototype/lint/output.txt",
+			isErr:      true,
+		},
+		{
+			name:       "directory json",
+			target:     "/lib",
+			outputType: "json",
+			outputFile: "prototype/lint/output.json",
+			isErr:      true,
+		},
+		{
+			name:       "package",
+			target:     "incubator/web",
+			outputFile: "prototype/lint/package.txt",
+			pkgManager: func() registry.PackageManager {
+				p := &pkgmocks.Package{}
+				p.On("IsInstalled").Return(true, nil)
+				p.On("Path").Return("/vendor/incubator/web")
+
+				pkgManager := &regmocks.PackageManager{}
+				pkgManager.On("Find", d).Return(p, nil)
+
+				return pkgManager
+			},
+		},
+		{
+			name:   "package not installed",
+			target: "incubator/web",
+			pkgManager: func() registry.PackageManager {
+				p := &pkgmocks.Package{}
+				p.On("IsInstalled").Return(false, nil)
+				p.On("Path").Return("")
+
+				pkgManager := &regmocks.PackageManager{}
+				pkgManager.On("Find", d).Return(p, nil)
+
+				return pkgManager
+			},
+			isErr: true,
+		},
+		{
+			name:   "package not found",
+			target: "incubator/web",
+			pkgManager: func() registry.PackageManager {
+				pkgManager := &regmocks.PackageManager{}
+				pkgManager.On("Find", d).Return(nil, errors.New("not found"))
+
+				return pkgManager
+			},
+			isErr: true,
+		},
+		{
+			name:       "invalid output type",
+			target:     "/lib",
+			outputType: "invalid",
+			isErr:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				appMock.On("CurrentEnvironment").Return("")
+
+				stageFile(t, appMock.Fs(), "prototype/lint/valid.jsonnet", "/lib/valid.jsonnet")
+				stageFile(t, appMock.Fs(), "prototype/lint/invalid.jsonnet", "/lib/invalid.jsonnet")
+				stageFile(t, appMock.Fs(), "prototype/lint/valid.jsonnet", "/vendor/incubator/web/prototypes/valid.jsonnet")
+
+				in := map[string]interface{}{
+					OptionApp:           appMock,
+					OptionPath:          tc.target,
+					OptionOutput:        tc.outputType,
+					OptionEnvName:       tc.envName,
+					OptionTLSSkipVerify: false,
+				}
+
+				a, err := NewPrototypeLint(in)
+				require.NoError(t, err)
+
+				a.evaluateJsonnetFn = func(_ app.App, envName, snippet, paramsStr string) (string, error) {
+					assert.Equal(t, tc.envName, envName)
+					assert.Contains(t, snippet, `local params = std.extVar("__ksonnet/params").components["prototype-test"];`)
+					assert.Equal(t, `{components: {"prototype-test": {"name": "name", "port": 80}}}`, paramsStr)
+					return "", errors.New("RUNTIME ERROR: Couldn't open import \"k.libsonnet\"")
+				}
+
+				if tc.pkgManager != nil {
+					a.packageManager = tc.pkgManager()
+				}
+
+				var buf bytes.Buffer
+				a.out = &buf
+
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				if tc.outputFile != "" {
+					assertOutput(t, tc.outputFile, buf.String())
+				}
+			})
+		})
+	}
+}
+
+func TestPrototypeLint_requires_app(t *testing.T) {
+	in := make(map[string]interface{})
+	_, err := NewPrototypeLint(in)
+	assert.Error(t, err)
+}
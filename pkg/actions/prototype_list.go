@@ -93,11 +93,9 @@ func (pl *PrototypeList) Run() error {
 	t := table.New("prototypeList", pl.out)
 	t.SetHeader([]string{"name", "description"})
 
-	f, err := table.DetectFormat(pl.outputType)
-	if err != nil {
+	if err := t.SetOutput(pl.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
 	sort.Slice(rows, func(i, j int) bool {
 		return rows[i][0] < rows[j][0]
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"sort"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/prototype"
@@ -38,15 +37,17 @@ func RunPrototypeSearch(m map[string]interface{}) error {
 	return ps.Run()
 }
 
-// PrototypeSearch searches for prototypes by name.
+// PrototypeSearch searches for prototypes by name, description, parameters and
+// package keywords.
 type PrototypeSearch struct {
 	app        app.App
 	query      string
 	outputType string
+	filter     prototype.SearchFilter
 
 	out            io.Writer
 	packageManager registry.PackageManager
-	protoSearchFn  func(string, prototype.Prototypes) (prototype.Prototypes, error)
+	protoSearchFn  func(string, prototype.SearchFilter, prototype.Prototypes) ([]prototype.SearchResult, error)
 }
 
 // NewPrototypeSearch creates an instance of PrototypeSearch
@@ -60,6 +61,10 @@ func NewPrototypeSearch(m map[string]interface{}) (*PrototypeSearch, error) {
 		app:        app,
 		query:      ol.LoadString(OptionQuery),
 		outputType: ol.LoadOptionalString(OptionOutput),
+		filter: prototype.SearchFilter{
+			Registry: ol.LoadOptionalString(OptionRegistry),
+			Package:  ol.LoadOptionalString(OptionPackageName),
+		},
 
 		out:            os.Stdout,
 		packageManager: registry.NewPackageManager(app, httpClientOpt),
@@ -70,6 +75,14 @@ func NewPrototypeSearch(m map[string]interface{}) (*PrototypeSearch, error) {
 		return nil, ol.err
 	}
 
+	if t := ol.LoadOptionalString(OptionTemplateType); t != "" {
+		templateType, err := prototype.ParseTemplateType(t)
+		if err != nil {
+			return nil, err
+		}
+		ps.filter.TemplateType = templateType
+	}
+
 	return ps, nil
 }
 
@@ -80,42 +93,42 @@ func (ps *PrototypeSearch) Run() error {
 		return err
 	}
 
-	results, err := ps.protoSearchFn(ps.query, prototypes)
+	results, err := ps.protoSearchFn(ps.query, ps.filter, prototypes)
 	if err != nil {
 		return err
 	}
 
-	if len(results) == 1 {
+	if len(results) == 0 {
 		return fmt.Errorf("failed to find any search results for query %q", ps.query)
 	}
 
+	// Results are listed in rank order.
 	var rows [][]string
-	for _, p := range results {
-		rows = append(rows, []string{p.Name, p.Template.ShortDescription})
+	for _, r := range results {
+		var pkgName string
+		if r.Prototype.Package != "" {
+			pkgName = fmt.Sprintf("%s/%s", r.Prototype.Registry, r.Prototype.Package)
+		}
+
+		rows = append(rows, []string{r.Prototype.Name, pkgName, r.Prototype.Template.ShortDescription})
 	}
 
 	t := table.New("prototypeSearch", ps.out)
-	t.SetHeader([]string{"name", "description"})
+	t.SetHeader([]string{"name", "package", "description"})
 
-	f, err := table.DetectFormat(ps.outputType)
-	if err != nil {
+	if err := t.SetOutput(ps.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
-
-	sort.Slice(rows, func(i, j int) bool {
-		return rows[i][0] < rows[j][0]
-	})
 
 	t.AppendBulk(rows)
 
 	return t.Render()
 }
 
-func protoSearch(query string, prototypes prototype.Prototypes) (prototype.Prototypes, error) {
+func protoSearch(query string, filter prototype.SearchFilter, prototypes prototype.Prototypes) ([]prototype.SearchResult, error) {
 	index, err := prototype.NewIndex(prototypes, prototype.DefaultBuilder)
 	if err != nil {
 		return nil, err
 	}
-	return index.SearchNames(query, prototype.Substring)
+	return index.Search(query, filter)
 }
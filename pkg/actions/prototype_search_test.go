@@ -22,6 +22,7 @@ import (
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
 	"github.com/ksonnet/ksonnet/pkg/prototype"
 	registrymocks "github.com/ksonnet/ksonnet/pkg/registry/mocks"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,6 +31,7 @@ func TestPrototypeSearch(t *testing.T) {
 		name       string
 		outputType string
 		outputFile string
+		results    []prototype.SearchResult
 		isErr      bool
 	}{
 		{
@@ -47,6 +49,12 @@ func TestPrototypeSearch(t *testing.T) {
 			outputType: "invalid",
 			isErr:      true,
 		},
+		{
+			name:       "no results",
+			outputType: "table",
+			results:    []prototype.SearchResult{},
+			isErr:      true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -61,6 +69,9 @@ func TestPrototypeSearch(t *testing.T) {
 					OptionApp:           appMock,
 					OptionQuery:         "search",
 					OptionOutput:        tc.outputType,
+					OptionRegistry:      "incubator",
+					OptionPackageName:   "redis",
+					OptionTemplateType:  "jsonnet",
 					OptionTLSSkipVerify: false,
 				}
 
@@ -72,12 +83,23 @@ func TestPrototypeSearch(t *testing.T) {
 				var buf bytes.Buffer
 				a.out = &buf
 
-				a.protoSearchFn = func(string, prototype.Prototypes) (prototype.Prototypes, error) {
+				a.protoSearchFn = func(query string, filter prototype.SearchFilter, _ prototype.Prototypes) ([]prototype.SearchResult, error) {
+					expected := prototype.SearchFilter{
+						Registry:     "incubator",
+						Package:      "redis",
+						TemplateType: prototype.Jsonnet,
+					}
+					assert.Equal(t, expected, filter)
+
+					if tc.results != nil {
+						return tc.results, nil
+					}
+
 					snippet := prototype.SnippetSchema{ShortDescription: "description"}
 
-					return prototype.Prototypes{
-						{Name: "result1", Template: snippet},
-						{Name: "result2", Template: snippet},
+					return []prototype.SearchResult{
+						{Prototype: &prototype.Prototype{Name: "result2", Package: "redis", Registry: "incubator", Template: snippet}, Score: 2},
+						{Prototype: &prototype.Prototype{Name: "result1", Template: snippet}, Score: 1},
 					}, nil
 				}
 
@@ -95,6 +117,19 @@ func TestPrototypeSearch(t *testing.T) {
 
 }
 
+func TestPrototypeSearch_invalid_template_type(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:          appMock,
+			OptionQuery:        "search",
+			OptionTemplateType: "invalid",
+		}
+
+		_, err := NewPrototypeSearch(in)
+		require.Error(t, err)
+	})
+}
+
 func TestProtoptypeSearch_requires_app(t *testing.T) {
 	in := make(map[string]interface{})
 	_, err := NewPrototypeSearch(in)
@@ -0,0 +1,316 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/openapi"
+	"github.com/ksonnet/ksonnet/pkg/prototype"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// prototypeTestComponent is the component name used when rendering a
+// prototype under test.
+const prototypeTestComponent = "prototype-test"
+
+// RunPrototypeTest runs `prototype test`
+func RunPrototypeTest(m map[string]interface{}) error {
+	pt, err := NewPrototypeTest(m)
+	if err != nil {
+		return err
+	}
+
+	return pt.Run()
+}
+
+// PrototypeTest renders a prototype with values files and validates the
+// rendered objects against the environment's schema.
+type PrototypeTest struct {
+	app         app.App
+	envName     string
+	path        string
+	valuesFiles []string
+	outputType  string
+
+	out               io.Writer
+	evaluateJsonnetFn func(a app.App, envName, snippet, paramsStr string) (string, error)
+	validateFn        func(a app.App, obj *unstructured.Unstructured, envName string) []error
+}
+
+// NewPrototypeTest creates an instance of PrototypeTest.
+func NewPrototypeTest(m map[string]interface{}) (*PrototypeTest, error) {
+	ol := newOptionLoader(m)
+
+	pt := &PrototypeTest{
+		app:         ol.LoadApp(),
+		path:        ol.LoadString(OptionPath),
+		valuesFiles: ol.LoadStringSlice(OptionValuesFiles),
+		outputType:  ol.LoadOptionalString(OptionOutput),
+
+		out:               os.Stdout,
+		evaluateJsonnetFn: evaluatePrototypeJsonnet,
+		validateFn:        openapi.NewValidator().Validate,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if err := setCurrentEnv(pt.app, pt, ol); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+func (pt *PrototypeTest) setCurrentEnv(name string) {
+	pt.envName = name
+}
+
+// Run renders each of the prototype's templates with each values file. If
+// there are no values files, the templates are rendered with the defaults of
+// the prototype's params.
+func (pt *PrototypeTest) Run() error {
+	data, err := afero.ReadFile(pt.app.Fs(), pt.path)
+	if err != nil {
+		return err
+	}
+
+	p, err := prototype.JsonnetParse(string(data))
+	if err != nil {
+		return errors.Wrapf(err, "parsing prototype %q", pt.path)
+	}
+
+	templates := p.Template.AvailableTemplates()
+	if len(templates) == 0 {
+		return errors.Errorf("prototype %q does not have a template", p.Name)
+	}
+
+	valuesFiles := pt.valuesFiles
+	if len(valuesFiles) == 0 {
+		valuesFiles = []string{""}
+	}
+
+	var rows [][]string
+	var failures int
+	for _, valuesFile := range valuesFiles {
+		name := valuesFile
+		if name == "" {
+			name = "defaults"
+		}
+
+		params, err := pt.params(p, valuesFile)
+		if err != nil {
+			failures++
+			rows = append(rows, []string{name, "", err.Error()})
+			continue
+		}
+
+		for _, t := range templates {
+			result := "ok"
+			if errs := pt.test(p, t, params); len(errs) > 0 {
+				failures++
+				var msgs []string
+				for _, err := range errs {
+					msgs = append(msgs, err.Error())
+				}
+				result = strings.Join(msgs, "; ")
+			}
+
+			rows = append(rows, []string{name, string(t), result})
+		}
+	}
+
+	t := table.New("prototypeTest", pt.out)
+	t.SetHeader([]string{"values", "template", "result"})
+
+	if err := t.SetOutput(pt.outputType); err != nil {
+		return errors.Wrap(err, "detecting output format")
+	}
+
+	t.AppendBulk(rows)
+	if err = t.Render(); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return errors.Errorf("prototype %q failed %d tests", p.Name, failures)
+	}
+
+	return nil
+}
+
+// params returns the quoted param values for a prototype. Values from the
+// values file override the defaults of the prototype's params.
+func (pt *PrototypeTest) params(p *prototype.Prototype, valuesFile string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, param := range p.OptionalParams() {
+		quoted, err := param.Quote(*param.Default)
+		if err != nil {
+			return nil, err
+		}
+		values[param.Name] = quoted
+	}
+
+	if valuesFile != "" {
+		f, err := pt.app.Fs().Open(valuesFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening values file")
+		}
+		defer f.Close()
+
+		vf, err := prototype.ReadValues(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading values file")
+		}
+
+		keys, err := vf.Keys()
+		if err != nil {
+			return nil, errors.Wrap(err, "finding keys in values file")
+		}
+
+		for _, k := range keys {
+			v, err := vf.Get(k)
+			if err != nil {
+				return nil, errors.Wrapf(err, "retrieving %q from values file", k)
+			}
+			values[k] = v
+		}
+	}
+
+	var missing []string
+	for _, param := range p.RequiredParams() {
+		if _, ok := values[param.Name]; !ok {
+			missing = append(missing, param.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.Errorf("missing required params: %s", strings.Join(missing, ", "))
+	}
+
+	return values, nil
+}
+
+// test renders a template and validates the objects it creates.
+func (pt *PrototypeTest) test(p *prototype.Prototype, t prototype.TemplateType, params map[string]string) []error {
+	text, err := expandPrototype(p, t, params, prototypeTestComponent)
+	if err != nil {
+		return []error{errors.Wrap(err, "expanding template")}
+	}
+
+	var rendered []byte
+	if t == prototype.Jsonnet {
+		out, err := pt.evaluateJsonnetFn(pt.app, pt.envName, text, prototypeTestParams(params))
+		if err != nil {
+			return []error{errors.Wrap(err, "rendering template")}
+		}
+		rendered = []byte(out)
+	} else {
+		if rendered, err = yaml.YAMLToJSON([]byte(text)); err != nil {
+			return []error{errors.Wrap(err, "rendering template")}
+		}
+	}
+
+	objects, err := decodePrototypeObjects(rendered)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, obj := range objects {
+		for _, err := range pt.validateFn(pt.app, obj, pt.envName) {
+			errs = append(errs, errors.Wrapf(err, "%s %q", obj.GetKind(), obj.GetName()))
+		}
+	}
+
+	return errs
+}
+
+// prototypeTestParams creates the params object a Jsonnet template is
+// evaluated with.
+func prototypeTestParams(params map[string]string) string {
+	var names []string
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []string
+	for _, name := range names {
+		fields = append(fields, fmt.Sprintf("%q: %s", name, params[name]))
+	}
+
+	return fmt.Sprintf("{components: {%q: {%s}}}", prototypeTestComponent, strings.Join(fields, ", "))
+}
+
+// evaluatePrototypeJsonnet evaluates an expanded Jsonnet template in an
+// environment.
+func evaluatePrototypeJsonnet(a app.App, envName, snippet, paramsStr string) (string, error) {
+	vm, cleanup, err := env.NewVM(a, envName, paramsStr)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	return vm.EvaluateSnippet(prototypeTestComponent, snippet)
+}
+
+// decodePrototypeObjects decodes the objects rendered by a template. A
+// template can render an object, an array of objects, or a List.
+func decodePrototypeObjects(data []byte) ([]*unstructured.Unstructured, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, errors.Wrap(err, "decoding rendered template")
+	}
+
+	var items []interface{}
+	switch t := v.(type) {
+	case []interface{}:
+		items = t
+	case map[string]interface{}:
+		if list, ok := t["items"].([]interface{}); ok && t["kind"] == "List" {
+			items = list
+		} else {
+			items = []interface{}{t}
+		}
+	default:
+		return nil, errors.New("template did not render an object")
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("template rendered an item which is not an object")
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: m})
+	}
+
+	return objects, nil
+}
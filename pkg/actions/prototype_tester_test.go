@@ -0,0 +1,176 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/ksonnet"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPrototypeTest(t *testing.T) {
+	cases := []struct {
+		name        string
+		valuesFiles []string
+		validateFn  func(app.App, *unstructured.Unstructured, string) []error
+		outputFile  string
+		isErr       bool
+	}{
+		{
+			name:        "valid",
+			valuesFiles: []string{"/values.jsonnet"},
+			outputFile:  "prototype/test/valid.txt",
+		},
+		{
+			name:       "missing required params",
+			outputFile: "prototype/test/defaults.txt",
+			isErr:      true,
+		},
+		{
+			name:        "missing required param in values file",
+			valuesFiles: []string{"/values.jsonnet", "/missing.jsonnet"},
+			outputFile:  "prototype/test/missing.txt",
+			isErr:       true,
+		},
+		{
+			name:        "invalid object",
+			valuesFiles: []string{"/values.jsonnet"},
+			validateFn: func(a app.App, obj *unstructured.Unstructured, envName string) []error {
+				return []error{errors.New("spec.type in body should be one of [ClusterIP NodePort]")}
+			},
+			outputFile: "prototype/test/invalid.txt",
+			isErr:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				stageFile(t, appMock.Fs(), "prototype/test/service.jsonnet", "/service.jsonnet")
+				stageFile(t, appMock.Fs(), "prototype/test/values.jsonnet", "/values.jsonnet")
+				stageFile(t, appMock.Fs(), "prototype/test/missing.jsonnet", "/missing.jsonnet")
+
+				in := map[string]interface{}{
+					OptionApp:         appMock,
+					OptionEnvName:     "default",
+					OptionPath:        "/service.jsonnet",
+					OptionValuesFiles: tc.valuesFiles,
+					OptionOutput:      "",
+				}
+
+				a, err := NewPrototypeTest(in)
+				require.NoError(t, err)
+
+				a.evaluateJsonnetFn = func(_ app.App, envName, snippet, paramsStr string) (string, error) {
+					assert.Equal(t, "default", envName)
+
+					vm := jsonnet.NewVM()
+					vm.ExtCode(ksonnet.EnvExtCodeKey, "{}")
+					vm.ExtCode(ksonnet.ParamsExtCodeKey, paramsStr)
+					return vm.EvaluateSnippet("snippet", snippet)
+				}
+
+				a.validateFn = func(a app.App, obj *unstructured.Unstructured, envName string) []error {
+					assert.Equal(t, "Service", obj.GetKind())
+					assert.Equal(t, "web", obj.GetName())
+					return nil
+				}
+				if tc.validateFn != nil {
+					a.validateFn = tc.validateFn
+				}
+
+				var buf bytes.Buffer
+				a.out = &buf
+
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				assertOutput(t, tc.outputFile, buf.String())
+			})
+		})
+	}
+}
+
+func TestPrototypeTest_requires_app(t *testing.T) {
+	in := make(map[string]interface{})
+	_, err := NewPrototypeTest(in)
+	assert.Error(t, err)
+}
+
+func Test_decodePrototypeObjects(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected []string
+		isErr    bool
+	}{
+		{
+			name:     "object",
+			in:       `{"kind": "Service"}`,
+			expected: []string{"Service"},
+		},
+		{
+			name:     "array",
+			in:       `[{"kind": "Service"}, {"kind": "Deployment"}]`,
+			expected: []string{"Service", "Deployment"},
+		},
+		{
+			name:     "list",
+			in:       `{"kind": "List", "items": [{"kind": "Service"}]}`,
+			expected: []string{"Service"},
+		},
+		{
+			name:  "not an object",
+			in:    `"service"`,
+			isErr: true,
+		},
+		{
+			name:  "array item is not an object",
+			in:    `[1]`,
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects, err := decodePrototypeObjects([]byte(tc.in))
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var kinds []string
+			for _, obj := range objects {
+				kinds = append(kinds, obj.GetKind())
+			}
+
+			assert.Equal(t, tc.expected, kinds)
+		})
+	}
+}
@@ -16,6 +16,7 @@
 package actions
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/component"
 	"github.com/ksonnet/ksonnet/pkg/prototype"
 	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/ksonnet/ksonnet/pkg/util/prompt"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
@@ -45,7 +47,10 @@ func RunPrototypeUse(m map[string]interface{}) error {
 type PrototypeUse struct {
 	app                 app.App
 	args                []string
+	interactive         bool
+	in                  io.Reader
 	out                 io.Writer
+	isTerminalFn        func() bool
 	packageManager      registry.PackageManager
 	createComponentFn   func(app.App, string, string, string, param.Params, prototype.TemplateType) (string, error)
 	bindFlagsFn         func(p *prototype.Prototype) (*pflag.FlagSet, error)
@@ -60,10 +65,13 @@ func NewPrototypeUse(m map[string]interface{}) (*PrototypeUse, error) {
 	httpClientOpt := registry.HTTPClientOpt(ol.LoadHTTPClient())
 
 	pl := &PrototypeUse{
-		app:  app,
-		args: ol.LoadStringSlice(OptionArguments),
+		app:         app,
+		args:        ol.LoadStringSlice(OptionArguments),
+		interactive: ol.LoadOptionalBool(OptionInteractive),
 
+		in:                  os.Stdin,
 		out:                 os.Stdout,
+		isTerminalFn:        prompt.StdinIsTerminal,
 		packageManager:      registry.NewPackageManager(app, httpClientOpt),
 		createComponentFn:   component.Create,
 		bindFlagsFn:         prototype.BindFlags,
@@ -148,12 +156,25 @@ func (pl *PrototypeUse) Run() error {
 		moduleName = mn
 	}
 
-	name, err := flags.GetString("name")
-	if err != nil {
-		return err
+	var prompter *prototype.Prompter
+	if pl.interactive {
+		if pl.isTerminalFn() {
+			prompter = prototype.NewPrompter(pl.in, pl.out)
+			if err = promptParams(prompter, p, flags, prototypeName); err != nil {
+				return err
+			}
+		} else {
+			logrus.Warn("stdin is not a terminal; not prompting for parameters")
+		}
+	}
+
+	// The name param's flag may not be a string flag, so read its value directly.
+	nameFlag := flags.Lookup("name")
+	if nameFlag == nil {
+		return errors.Errorf("prototype %q does not have a name param", p.Name)
 	}
 
-	if name == "" {
+	if nameFlag.Value.String() == "" {
 		if err = flags.Set("name", prototypeName); err != nil {
 			return err
 		}
@@ -169,6 +190,20 @@ func (pl *PrototypeUse) Run() error {
 		return err
 	}
 
+	if prompter != nil {
+		fmt.Fprintf(pl.out, "\n%s\n\n", strings.TrimSpace(text))
+
+		ok, err := prompter.Confirm(fmt.Sprintf("Create component %q?", componentName))
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			fmt.Fprintln(pl.out, "Component was not created")
+			return nil
+		}
+	}
+
 	ps := param.Params{}
 	for k, v := range rawParams {
 		ps[k] = v
@@ -181,3 +216,34 @@ func (pl *PrototypeUse) Run() error {
 
 	return nil
 }
+
+// promptParams prompts for the params which weren't set on the command line.
+// Required params are prompted for first. The name param defaults to the
+// component name.
+func promptParams(prompter *prototype.Prompter, p *prototype.Prototype, flags *pflag.FlagSet, componentName string) error {
+	params := append(p.RequiredParams(), p.OptionalParams()...)
+
+	for _, param := range params {
+		if flags.Changed(param.Name) {
+			continue
+		}
+
+		var def string
+		if param.Default != nil {
+			def = *param.Default
+		} else if param.Name == "name" {
+			def = componentName
+		}
+
+		value, err := prompter.Param(param, def)
+		if err != nil {
+			return err
+		}
+
+		if err = flags.Set(param.Name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,6 +16,8 @@
 package actions
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	param "github.com/ksonnet/ksonnet/metadata/params"
@@ -178,6 +180,100 @@ func TestPrototypeUse_with_module_in_name(t *testing.T) {
 	})
 }
 
+func TestPrototypeUse_interactive(t *testing.T) {
+	cases := []struct {
+		name       string
+		in         string
+		isTerminal bool
+		args       []string
+		outputFile string
+		created    bool
+	}{
+		{
+			name:       "prompts for params",
+			in:         "\nnginx\ntwo\n3\n\ny\n",
+			isTerminal: true,
+			args:       []string{"single-port-deployment", "deployment"},
+			outputFile: "prototype/use/interactive.txt",
+			created:    true,
+		},
+		{
+			name:       "skips params set with flags",
+			in:         "3\n\ny\n",
+			isTerminal: true,
+			args:       []string{"single-port-deployment", "deployment", "--name", "deployment", "--image", "nginx"},
+			outputFile: "prototype/use/interactive-flags.txt",
+			created:    true,
+		},
+		{
+			name:       "component is not confirmed",
+			in:         "\nnginx\n3\n\nn\n",
+			isTerminal: true,
+			args:       []string{"single-port-deployment", "deployment"},
+			outputFile: "prototype/use/interactive-declined.txt",
+		},
+		{
+			name:    "stdin is not a terminal",
+			args:    []string{"single-port-deployment", "deployment", "--image", "nginx", "--replicas", "3"},
+			created: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				manager := &registrymocks.PackageManager{}
+				manager.On("Prototypes").Return(prototype.Prototypes{}, nil)
+
+				in := map[string]interface{}{
+					OptionApp:           appMock,
+					OptionArguments:     tc.args,
+					OptionInteractive:   true,
+					OptionTLSSkipVerify: false,
+				}
+
+				a, err := NewPrototypeUse(in)
+				require.NoError(t, err)
+
+				a.packageManager = manager
+				a.in = strings.NewReader(tc.in)
+				a.isTerminalFn = func() bool {
+					return tc.isTerminal
+				}
+
+				var buf bytes.Buffer
+				a.out = &buf
+
+				var created bool
+				a.createComponentFn = func(_ app.App, moduleName, name, text string, params param.Params, template prototype.TemplateType) (string, error) {
+					created = true
+
+					expectedParams := param.Params{
+						"name":          `"deployment"`,
+						"image":         `"nginx"`,
+						"replicas":      "3",
+						"containerPort": "80",
+					}
+
+					assert.Equal(t, expectedParams, params)
+					return "", nil
+				}
+
+				err = a.Run()
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.created, created)
+
+				if tc.outputFile == "" {
+					assert.Empty(t, buf.String())
+					return
+				}
+				assertOutput(t, tc.outputFile, buf.String())
+			})
+		})
+	}
+}
+
 func TestPrototypeUse_requires_app(t *testing.T) {
 	in := make(map[string]interface{})
 	_, err := NewPrototypeUse(in)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 	"github.com/pkg/errors"
 )
 
@@ -41,6 +42,7 @@ func RunRegistryDescribe(m map[string]interface{}) error {
 type RegistryDescribe struct {
 	app                 app.App
 	name                string
+	outputType          string
 	out                 io.Writer
 	fetchRegistrySpecFn func(a app.App, name string) (*registry.Spec, *app.RegistryConfig, error)
 }
@@ -51,8 +53,9 @@ func NewRegistryDescribe(m map[string]interface{}) (*RegistryDescribe, error) {
 
 	httpClient := ol.LoadHTTPClient()
 	rd := &RegistryDescribe{
-		app:  ol.LoadApp(),
-		name: ol.LoadString(OptionName),
+		app:        ol.LoadApp(),
+		name:       ol.LoadString(OptionName),
+		outputType: ol.LoadOptionalString(OptionOutput),
 
 		out: os.Stdout,
 		fetchRegistrySpecFn: func(a app.App, name string) (*registry.Spec, *app.RegistryConfig, error) {
@@ -74,27 +77,46 @@ func (rd *RegistryDescribe) Run() error {
 		return err
 	}
 
-	fmt.Fprintln(rd.out, `REGISTRY NAME:`)
-	fmt.Fprintln(rd.out, regRef.Name)
-	fmt.Fprintln(rd.out)
-	fmt.Fprintln(rd.out, `URI:`)
-	fmt.Fprintln(rd.out, regRef.URI)
-	fmt.Fprintln(rd.out)
-	fmt.Fprintln(rd.out, `PROTOCOL:`)
-	fmt.Fprintln(rd.out, regRef.Protocol)
-	fmt.Fprintln(rd.out)
-	fmt.Fprintln(rd.out, `PACKAGES:`)
-
 	libs := make([]string, 0, len(spec.Libraries))
 	for _, lib := range spec.Libraries {
 		libs = append(libs, lib.Path)
 	}
 	sort.Strings(libs)
-	for _, libPath := range libs {
-		fmt.Fprintf(rd.out, "  %s\n", libPath)
+
+	desc := registryDescription{
+		Name:     regRef.Name,
+		URI:      regRef.URI,
+		Protocol: regRef.Protocol,
+		Packages: libs,
 	}
 
-	return nil
+	return table.RenderObject(rd.out, "registryDescribe", rd.outputType, desc, func(w io.Writer) error {
+		fmt.Fprintln(w, `REGISTRY NAME:`)
+		fmt.Fprintln(w, regRef.Name)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `URI:`)
+		fmt.Fprintln(w, regRef.URI)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `PROTOCOL:`)
+		fmt.Fprintln(w, regRef.Protocol)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `PACKAGES:`)
+
+		for _, libPath := range libs {
+			fmt.Fprintf(w, "  %s\n", libPath)
+		}
+
+		return nil
+	})
+}
+
+// registryDescription is the structured output of `registry describe`.
+// Packages are sorted by path.
+type registryDescription struct {
+	Name     string   `json:"name"`
+	URI      string   `json:"uri"`
+	Protocol string   `json:"protocol"`
+	Packages []string `json:"packages"`
 }
 
 func fetchRegistrySpec(a app.App, name string, httpClient *http.Client) (*registry.Spec, *app.RegistryConfig, error) {
@@ -26,10 +26,40 @@ import (
 )
 
 func TestRegistryDescribe(t *testing.T) {
+	cases := []struct {
+		name       string
+		outputType string
+		outputFile string
+	}{
+		{
+			name:       "default",
+			outputFile: "registry/describe/output.txt",
+		},
+		{
+			name:       "yaml",
+			outputType: "yaml",
+			outputFile: "registry/describe/output.yaml",
+		},
+		{
+			name:       "jsonpath",
+			outputType: `jsonpath={range .data.packages[*]}{@}{"\n"}{end}`,
+			outputFile: "registry/describe/packages.txt",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			testRegistryDescribe(t, tc.outputType, tc.outputFile)
+		})
+	}
+}
+
+func testRegistryDescribe(t *testing.T, outputType, outputFile string) {
 	withApp(t, func(appMock *amocks.App) {
 		in := map[string]interface{}{
 			OptionApp:           appMock,
 			OptionName:          "incubator",
+			OptionOutput:        outputType,
 			OptionTLSSkipVerify: false,
 		}
 
@@ -70,7 +100,7 @@ func TestRegistryDescribe(t *testing.T) {
 		err = a.Run()
 		require.NoError(t, err)
 
-		assertOutput(t, "registry/describe/output.txt", buf.String())
+		assertOutput(t, outputFile, buf.String())
 	})
 }
 
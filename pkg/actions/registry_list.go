@@ -84,11 +84,9 @@ func (rl *RegistryList) Run() error {
 	t := table.New("registryList", rl.out)
 	t.SetHeader([]string{"name", "override", "protocol", "uri"})
 
-	f, err := table.DetectFormat(rl.outputType)
-	if err != nil {
+	if err := t.SetOutput(rl.outputType); err != nil {
 		return errors.Wrap(err, "detecting output format")
 	}
-	t.SetFormat(f)
 
 	var rows [][]string
 
@@ -0,0 +1,87 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"net/http"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultServeAddress is the default address for `serve`.
+	DefaultServeAddress = "127.0.0.1:8080"
+)
+
+// RunServe runs `serve`.
+func RunServe(m map[string]interface{}) error {
+	s, err := NewServe(m)
+	if err != nil {
+		return err
+	}
+
+	return s.Run()
+}
+
+type listenAndServeFn func(address string, handler http.Handler) error
+
+// Serve serves an application over HTTP.
+type Serve struct {
+	app     app.App
+	address string
+
+	listenAndServeFn listenAndServeFn
+}
+
+// NewServe creates an instance of Serve.
+func NewServe(m map[string]interface{}) (*Serve, error) {
+	ol := newOptionLoader(m)
+
+	s := &Serve{
+		app:     ol.LoadApp(),
+		address: ol.LoadOptionalString(OptionAddress),
+
+		listenAndServeFn: http.ListenAndServe,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if s.address == "" {
+		s.address = DefaultServeAddress
+	}
+
+	return s, nil
+}
+
+// Run serves the application until the server fails. The Jsonnet settings
+// from the command line are used for every request.
+func (s *Serve) Run() error {
+	settings := env.CurrentEvaluateSettings()
+	handler := server.New(s.app, server.WithEvaluateSettings(settings))
+	defer func() {
+		if err := handler.Close(); err != nil {
+			log.WithError(err).Warn("removing revendored packages")
+		}
+	}()
+
+	log.Infof("serving %s on http://%s", s.app.Root(), s.address)
+	return s.listenAndServeFn(s.address, handler)
+}
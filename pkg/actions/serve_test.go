@@ -0,0 +1,70 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"net/http"
+	"testing"
+
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe(t *testing.T) {
+	cases := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{
+			name:     "default address",
+			expected: DefaultServeAddress,
+		},
+		{
+			name:     "address",
+			address:  ":9000",
+			expected: ":9000",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				appMock.On("HTTPClient").Return(nil)
+
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionAddress: tc.address,
+				}
+
+				a, err := NewServe(in)
+				require.NoError(t, err)
+
+				var address string
+				a.listenAndServeFn = func(addr string, handler http.Handler) error {
+					address = addr
+					assert.IsType(t, &server.Server{}, handler)
+					return nil
+				}
+
+				require.NoError(t, a.Run())
+				assert.Equal(t, tc.expected, address)
+			})
+		})
+	}
+}
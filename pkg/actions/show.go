@@ -45,6 +45,8 @@ type Show struct {
 	componentNames []string
 	envName        string
 	format         string
+	noCache        bool
+	explain        bool
 
 	out       io.Writer
 	runShowFn runShowFn
@@ -58,6 +60,8 @@ func newShow(m map[string]interface{}, opts ...showOpt) (*Show, error) {
 		app:            ol.LoadApp(),
 		componentNames: ol.LoadStringSlice(OptionComponentNames),
 		format:         ol.LoadString(OptionFormat),
+		noCache:        ol.LoadOptionalBool(OptionNoCache),
+		explain:        ol.LoadOptionalBool(OptionExplain),
 
 		out:       os.Stdout,
 		runShowFn: cluster.RunShow,
@@ -84,6 +88,8 @@ func (s *Show) run() error {
 		ComponentNames: s.componentNames,
 		EnvName:        s.envName,
 		Format:         s.format,
+		NoCache:        s.noCache,
+		Explain:        s.explain,
 		Out:            s.out,
 	}
 
@@ -56,6 +56,8 @@ func TestShow(t *testing.T) {
 					OptionComponentNames: []string{},
 					OptionEnvName:        tc.envName,
 					OptionFormat:         "yaml",
+					OptionNoCache:        true,
+					OptionExplain:        true,
 				}
 
 				expected := cluster.ShowConfig{
@@ -63,6 +65,8 @@ func TestShow(t *testing.T) {
 					ComponentNames: []string{},
 					EnvName:        "default",
 					Format:         "yaml",
+					NoCache:        true,
+					Explain:        true,
 					Out:            os.Stdout,
 				}
 
@@ -0,0 +1,94 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"io"
+	"os"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/snapshot"
+	"github.com/pkg/errors"
+)
+
+// RunSnapshotCheck runs `snapshot check`.
+func RunSnapshotCheck(m map[string]interface{}) error {
+	sc, err := NewSnapshotCheck(m)
+	if err != nil {
+		return err
+	}
+
+	return sc.Run()
+}
+
+type snapshotCheckFn func(s *snapshot.Snapshot, w io.Writer) (bool, error)
+
+// SnapshotCheck compares an environment to its snapshot.
+type SnapshotCheck struct {
+	app     app.App
+	envName string
+	dir     string
+
+	out             io.Writer
+	snapshotCheckFn snapshotCheckFn
+}
+
+// NewSnapshotCheck creates an instance of SnapshotCheck.
+func NewSnapshotCheck(m map[string]interface{}) (*SnapshotCheck, error) {
+	ol := newOptionLoader(m)
+
+	sc := &SnapshotCheck{
+		app: ol.LoadApp(),
+		dir: ol.LoadOptionalString(OptionSnapshotDir),
+
+		out: os.Stdout,
+		snapshotCheckFn: func(s *snapshot.Snapshot, w io.Writer) (bool, error) {
+			return s.Check(w)
+		},
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if err := setCurrentEnv(sc.app, sc, ol); err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// Run compares the rendered environment to its snapshot. It returns an error if
+// they differ.
+func (sc *SnapshotCheck) Run() error {
+	s := snapshot.New(sc.app, sc.envName, sc.dir)
+
+	ok, err := sc.snapshotCheckFn(s, sc.out)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.Errorf("environment %q does not match its snapshot; run `ks snapshot update %s` to update it",
+			sc.envName, sc.envName)
+	}
+
+	return nil
+}
+
+func (sc *SnapshotCheck) setCurrentEnv(name string) {
+	sc.envName = name
+}
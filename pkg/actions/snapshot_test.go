@@ -0,0 +1,112 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotUpdate(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:         appMock,
+			OptionEnvName:     "default",
+			OptionSnapshotDir: "golden",
+		}
+
+		a, err := NewSnapshotUpdate(in)
+		require.NoError(t, err)
+
+		var updated bool
+		a.snapshotUpdateFn = func(s *snapshot.Snapshot) error {
+			assert.Equal(t, "/golden/default", s.Dir())
+			updated = true
+			return nil
+		}
+
+		var buf bytes.Buffer
+		a.out = &buf
+
+		require.NoError(t, a.Run())
+		assert.True(t, updated)
+		assert.Equal(t, "updated snapshot for environment \"default\" in /golden/default\n", buf.String())
+	})
+}
+
+func TestSnapshotCheck(t *testing.T) {
+	cases := []struct {
+		name    string
+		matches bool
+		isErr   bool
+	}{
+		{
+			name:    "matches",
+			matches: true,
+		},
+		{
+			name:  "differs",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionEnvName: "default",
+				}
+
+				a, err := NewSnapshotCheck(in)
+				require.NoError(t, err)
+
+				a.snapshotCheckFn = func(s *snapshot.Snapshot, w io.Writer) (bool, error) {
+					assert.Equal(t, "/snapshots/default", s.Dir())
+					return tc.matches, nil
+				}
+
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+			})
+		})
+	}
+}
+
+func TestSnapshotCheck_requires_env(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		appMock.On("CurrentEnvironment").Return("")
+
+		in := map[string]interface{}{
+			OptionApp:     appMock,
+			OptionEnvName: "",
+		}
+
+		_, err := NewSnapshotCheck(in)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/snapshot"
+)
+
+// RunSnapshotUpdate runs `snapshot update`.
+func RunSnapshotUpdate(m map[string]interface{}) error {
+	su, err := NewSnapshotUpdate(m)
+	if err != nil {
+		return err
+	}
+
+	return su.Run()
+}
+
+type snapshotUpdateFn func(s *snapshot.Snapshot) error
+
+// SnapshotUpdate writes the snapshot of an environment.
+type SnapshotUpdate struct {
+	app     app.App
+	envName string
+	dir     string
+
+	out              io.Writer
+	snapshotUpdateFn snapshotUpdateFn
+}
+
+// NewSnapshotUpdate creates an instance of SnapshotUpdate.
+func NewSnapshotUpdate(m map[string]interface{}) (*SnapshotUpdate, error) {
+	ol := newOptionLoader(m)
+
+	su := &SnapshotUpdate{
+		app: ol.LoadApp(),
+		dir: ol.LoadOptionalString(OptionSnapshotDir),
+
+		out: os.Stdout,
+		snapshotUpdateFn: func(s *snapshot.Snapshot) error {
+			return s.Update()
+		},
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if err := setCurrentEnv(su.app, su, ol); err != nil {
+		return nil, err
+	}
+
+	return su, nil
+}
+
+// Run writes the snapshot.
+func (su *SnapshotUpdate) Run() error {
+	s := snapshot.New(su.app, su.envName, su.dir)
+
+	if err := su.snapshotUpdateFn(s); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(su.out, "updated snapshot for environment %q in %s\n", su.envName, s.Dir())
+	return nil
+}
+
+func (su *SnapshotUpdate) setCurrentEnv(name string) {
+	su.envName = name
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/jsonnettest"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// RunTest runs `test`.
+func RunTest(m map[string]interface{}) error {
+	t, err := NewTest(m)
+	if err != nil {
+		return err
+	}
+
+	return t.Run()
+}
+
+type testEvaluatorFn func(a app.App, envName, moduleName string) (jsonnettest.Evaluator, func() error, error)
+
+// Test runs Jsonnet unit tests.
+type Test struct {
+	app       app.App
+	envName   string
+	junitPath string
+
+	out         io.Writer
+	evaluatorFn testEvaluatorFn
+}
+
+// NewTest creates an instance of Test.
+func NewTest(m map[string]interface{}) (*Test, error) {
+	ol := newOptionLoader(m)
+
+	t := &Test{
+		app:       ol.LoadApp(),
+		junitPath: ol.LoadOptionalString(OptionJUnit),
+
+		out:         os.Stdout,
+		evaluatorFn: testEvaluator,
+	}
+
+	if ol.err != nil {
+		return nil, ol.err
+	}
+
+	if err := setCurrentEnv(t.app, t, ol); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Run runs the tests in lib/ and components/.
+func (t *Test) Run() error {
+	root := t.app.Root()
+
+	paths, err := jsonnettest.Discover(t.app.Fs(),
+		filepath.Join(root, "lib"),
+		filepath.Join(root, "components"))
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		fmt.Fprintf(t.out, "no test files found\n")
+		return nil
+	}
+
+	// tests are evaluated with the parameters of the module they are in. Tests
+	// outside of components/ use the root module.
+	evaluators := make(map[string]jsonnettest.Evaluator)
+	var cleanups []func() error
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+
+	var results []jsonnettest.FileResult
+	for _, path := range paths {
+		moduleName := t.moduleName(path)
+
+		vm, ok := evaluators[moduleName]
+		if !ok {
+			var cleanup func() error
+			vm, cleanup, err = t.evaluatorFn(t.app, t.envName, moduleName)
+			if err != nil {
+				return errors.Wrapf(err, "creating Jsonnet VM for module %q", moduleName)
+			}
+
+			evaluators[moduleName] = vm
+			cleanups = append(cleanups, cleanup)
+		}
+
+		name := jsonnettest.RelativeName(root, path)
+		results = append(results, jsonnettest.RunFile(vm, name, path))
+	}
+
+	if err := jsonnettest.Fprint(t.out, results); err != nil {
+		return err
+	}
+
+	if t.junitPath != "" {
+		if err := t.writeJUnit(results); err != nil {
+			return err
+		}
+	}
+
+	if !jsonnettest.Passed(results) {
+		return errors.New("tests failed")
+	}
+
+	return nil
+}
+
+// moduleName returns the name of the module a test file belongs to. It is
+// the closest directory in components/ which is a module.
+func (t *Test) moduleName(path string) string {
+	componentsDir := filepath.Join(t.app.Root(), "components")
+
+	dir := filepath.Dir(path)
+	if !strings.HasPrefix(dir+string(filepath.Separator), componentsDir+string(filepath.Separator)) {
+		return "/"
+	}
+
+	moduleName := component.ModuleFromPath(t.app, dir)
+	for moduleName != "" {
+		if _, err := component.GetModule(t.app, moduleName); err == nil {
+			return moduleName
+		}
+
+		i := strings.LastIndex(moduleName, ".")
+		if i < 0 {
+			break
+		}
+		moduleName = moduleName[:i]
+	}
+
+	return "/"
+}
+
+func (t *Test) writeJUnit(results []jsonnettest.FileResult) error {
+	var buf bytes.Buffer
+	if err := jsonnettest.WriteJUnit(&buf, results); err != nil {
+		return errors.Wrap(err, "creating JUnit report")
+	}
+
+	if err := afero.WriteFile(t.app.Fs(), t.junitPath, buf.Bytes(), app.DefaultFilePermissions); err != nil {
+		return errors.Wrapf(err, "writing JUnit report to %q", t.junitPath)
+	}
+
+	return nil
+}
+
+func (t *Test) setCurrentEnv(name string) {
+	t.envName = name
+}
+
+// testEvaluator creates a VM with the same settings as the VM which evaluates
+// the module's components.
+func testEvaluator(a app.App, envName, moduleName string) (jsonnettest.Evaluator, func() error, error) {
+	module, err := component.GetModule(a, moduleName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := pipeline.New(a, envName)
+
+	paramsStr, err := p.ModuleParameters(module)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "evaluating parameters for module %q", moduleName)
+	}
+
+	return env.NewVM(a, envName, paramsStr)
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/jsonnettest"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTest(t *testing.T) {
+	cases := []struct {
+		name       string
+		files      map[string]string
+		isErr      bool
+		modules    []string
+		hasResults bool
+	}{
+		{
+			name: "passing tests",
+			files: map[string]string{
+				"/lib/helpers.libsonnet":                  `{ double(x):: x * 2 }`,
+				"/lib/helpers_test.jsonnet":               `local h = import "helpers.libsonnet"; { double: h.double(2) == 4 }`,
+				"/components/params.libsonnet":            `{}`,
+				"/components/nested/params.libsonnet":     `{}`,
+				"/components/nested/service_test.jsonnet": `{ port: { actual: 80, expected: 80 } }`,
+				"/components/nested/tests/x_test.jsonnet": `{ x: true }`,
+			},
+			modules:    []string{"/", "nested"},
+			hasResults: true,
+		},
+		{
+			name: "failing tests",
+			files: map[string]string{
+				"/lib/helpers_test.jsonnet": `{ fails: false }`,
+			},
+			isErr:      true,
+			modules:    []string{"/"},
+			hasResults: true,
+		},
+		{
+			name:  "no tests",
+			files: map[string]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withApp(t, func(appMock *amocks.App) {
+				fs := appMock.Fs()
+				for path, content := range tc.files {
+					require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+				}
+
+				in := map[string]interface{}{
+					OptionApp:     appMock,
+					OptionEnvName: "default",
+					OptionJUnit:   "/report.xml",
+				}
+
+				a, err := NewTest(in)
+				require.NoError(t, err)
+
+				var modules []string
+				a.evaluatorFn = func(_ app.App, envName, moduleName string) (jsonnettest.Evaluator, func() error, error) {
+					assert.Equal(t, "default", envName)
+					modules = append(modules, moduleName)
+					return jsonnet.NewVM(jsonnet.AferoImporterOpt(fs)), func() error { return nil }, nil
+				}
+
+				var buf bytes.Buffer
+				a.out = &buf
+
+				err = a.Run()
+				if tc.isErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				assert.Equal(t, tc.modules, modules)
+
+				exists, err := afero.Exists(fs, "/report.xml")
+				require.NoError(t, err)
+				assert.Equal(t, tc.hasResults, exists)
+
+				if !tc.hasResults {
+					assert.Equal(t, "no test files found\n", buf.String())
+				}
+			})
+		})
+	}
+}
+
+func TestTest_requires_env(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		appMock.On("CurrentEnvironment").Return("")
+
+		in := map[string]interface{}{
+			OptionApp:     appMock,
+			OptionEnvName: "",
+		}
+
+		_, err := NewTest(in)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+local params = std.extVar('__ksonnet/params');
+local globals = import 'globals.libsonnet';
+local envParams = params + {
+  components+: {
+    db+: {
+      replicas: 2,
+    },
+    web+: {
+      image: 'registry.example.com/team/web@sha256:aaaa',
+      sidecar: {
+        image: 'registry.example.com/team/proxy@sha256:bbbb',
+        port: 8080,
+      },
+    },
+  },
+};
+
+{
+  components: {
+    [x]: envParams.components[x] + globals
+    for x in std.objectFields(envParams.components)
+  },
+}
\ No newline at end of file
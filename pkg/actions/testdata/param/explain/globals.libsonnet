@@ -0,0 +1,3 @@
+{
+  tier: "front",
+}
@@ -0,0 +1,11 @@
+{
+  global: {
+    tier: "back",
+  },
+  components: {
+    web: {
+      replicas: 1,
+      labels: { app: "web" },
+    },
+  },
+}
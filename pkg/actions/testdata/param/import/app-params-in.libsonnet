@@ -0,0 +1,15 @@
+{
+  global: {
+    // shared by every component
+    tier: "back",
+  },
+  components: {
+    db: {
+      port: 5432,
+    },
+    web: {
+      replicas: 1,
+      labels: { app: "web" },
+    },
+  },
+}
@@ -0,0 +1,17 @@
+{
+  global: {
+    tier: 'front',
+  },
+  components: {
+    db: {
+      port: 5432,
+    },
+    web: {
+      image: 'nginx:1.15',
+      labels: {
+        app: 'web',
+      },
+      replicas: 2,
+    },
+  },
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+local params = std.extVar('__ksonnet/params');
+local globals = import 'globals.libsonnet';
+local envParams = params + {
+  components+: {
+    web+: {
+      replicas: 5,
+    },
+    db+: {
+      port: '5433',
+    },
+  },
+};
+
+{
+  components: {
+    [x]: envParams.components[x] + globals
+    for x in std.objectFields(envParams.components)
+  },
+}
\ No newline at end of file
@@ -0,0 +1,18 @@
+{
+  global: {
+    // shared by every component
+    tier: 'back',
+  },
+  components: {
+    db: {
+      port: 5432,
+    },
+    web: {
+      replicas: 1,
+      labels: { app: 'web' },
+    },
+    cache: {
+      size: 10,
+    },
+  },
+}
\ No newline at end of file
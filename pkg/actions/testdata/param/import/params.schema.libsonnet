@@ -0,0 +1,16 @@
+{
+  components: {
+    web: {
+      type: "object",
+      properties: {
+        replicas: { type: "integer", minimum: 1 },
+        db_port: { type: "integer" },
+      },
+    },
+    db: {
+      properties: {
+        port: { minimum: 1024 },
+      },
+    },
+  },
+}
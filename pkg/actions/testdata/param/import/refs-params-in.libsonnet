@@ -0,0 +1,12 @@
+{
+  global: {},
+  components: {
+    db: {
+      port: 5432,
+    },
+    web: {
+      replicas: 1,
+      db_port: { "__ksonnet/ref": "db.port" },
+    },
+  },
+}
@@ -0,0 +1,14 @@
+{
+  global: {},
+  components: {
+    db: {
+      port: 5432,
+    },
+    web: {
+      db_port: {
+        "__ksonnet/ref": 'db.port',
+      },
+      replicas: 2,
+    },
+  },
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+{
+  global: {},
+  components: {
+    db: {
+      host: "db.local",
+      port: 5432,
+    },
+    web: {
+      image: "nginx",
+      replicas: 1,
+      tier: "free",
+      db_port: { "__ksonnet/ref": "db.port" },
+    },
+  },
+}
@@ -0,0 +1,13 @@
+{
+  components: {
+    web: {
+      type: "object",
+      required: ["image"],
+      properties: {
+        replicas: { type: "integer", minimum: 1 },
+        tier: { enum: ["free", "paid"] },
+        db_port: { type: "integer" },
+      },
+    },
+  },
+}
@@ -111,6 +111,15 @@ func (v *Validate) Run() error {
 		desc := fmt.Sprintf("%s %s", utils.ResourceNameFor(disc, obj), utils.FqName(obj))
 		log.Info("Validating ", desc)
 
+		// point errors to the files the object was rendered from.
+		provenance, ok, err := pipeline.ObjectProvenance(obj)
+		if err != nil {
+			return err
+		}
+		if ok {
+			desc = fmt.Sprintf("%s (%s)", desc, provenance)
+		}
+
 		errs := v.validateObjectFn(v.app, obj, v.envName)
 		for _, err := range errs {
 			log.Errorf("Error in %s: %v", desc, err)
@@ -131,7 +140,7 @@ func loadDiscovery(a app.App, clientConfig *client.Config, envName string) (disc
 }
 
 func findObjects(a app.App, envName string, componentNames []string) ([]*unstructured.Unstructured, error) {
-	p := pipeline.New(a, envName)
+	p := pipeline.New(a, envName, pipeline.WithProvenance())
 	return p.Objects(componentNames)
 }
 
@@ -16,6 +16,8 @@
 package actions
 
 import (
+	"bytes"
+	"os"
 	"testing"
 
 	swagger "github.com/emicklei/go-restful-swagger12"
@@ -23,7 +25,9 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/app"
 	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
 	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/metadata"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -108,6 +112,48 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidate_reports_provenance(t *testing.T) {
+	withApp(t, func(appMock *amocks.App) {
+		in := map[string]interface{}{
+			OptionApp:            appMock,
+			OptionEnvName:        "default",
+			OptionModule:         "",
+			OptionComponentNames: []string{},
+			OptionClientConfig:   &client.Config{},
+		}
+
+		a, err := NewValidate(in)
+		require.NoError(t, err)
+
+		a.discoveryFn = func(a app.App, clientConfig *client.Config, envName string) (discovery.DiscoveryInterface, error) {
+			return &stubDiscovery{}, nil
+		}
+
+		a.findObjectsFn = func(a app.App, envName string, componentNames []string) ([]*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{}
+			obj.SetName("web")
+			obj.SetAnnotations(map[string]string{
+				metadata.AnnotationProvenance: `{"module":"/","component":"web","source":"components/web.jsonnet"}`,
+			})
+
+			return []*unstructured.Unstructured{obj}, nil
+		}
+
+		a.validateObjectFn = func(a app.App, obj *unstructured.Unstructured, envName string) []error {
+			return []error{errors.New("invalid port")}
+		}
+
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		defer log.SetOutput(os.Stderr)
+
+		err = a.Run()
+		require.Error(t, err)
+
+		assert.Contains(t, buf.String(), "(components/web.jsonnet): invalid port")
+	})
+}
+
 func TestValidate_requires_app(t *testing.T) {
 	in := make(map[string]interface{})
 	_, err := NewValidate(in)
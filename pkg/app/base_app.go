@@ -460,6 +460,10 @@ func (ba *baseApp) mergedEnvironment(name string) *EnvironmentConfig {
 			copy(t, override.Targets)
 			combined.Targets = t
 		}
+		// Overrides can protect an environment, but can't remove its protection.
+		if override.Protected {
+			combined.Protected = true
+		}
 		return combined
 	case hasOverride:
 		e := deepCopyEnvironmentConfig(*override)
@@ -132,6 +132,9 @@ type EnvironmentConfig030 struct {
 	Targets []string `json:"targets,omitempty"`
 	// Libraries specifies versioned libraries specifically used by this environment.
 	Libraries LibraryConfigs030 `json:"libraries,omitempty"`
+	// Protected environments require confirmation before objects are deleted
+	// from them.
+	Protected bool `json:"protected,omitempty"`
 }
 
 // MakePath return the absolute path to the environment directory.
@@ -18,19 +18,24 @@ package appinit
 import (
 	"net/http"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/component"
 	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/pkg"
 	"github.com/ksonnet/ksonnet/pkg/registry"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
 
-// Init initializes a ksonnet application.
-func Init(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry) error {
+// Init initializes a ksonnet application. If tmpl is not nil, the application
+// is created from the template.
+func Init(fs afero.Fs, httpClient *http.Client, name, rootPath, envName, k8sSpecFlag, serverURI, namespace string, registries []registry.Registry, tmpl *Template) error {
 	i := newInitApp(fs, httpClient, name, rootPath, envName, k8sSpecFlag, serverURI, namespace, registries)
+	i.template = tmpl
 	return i.Run()
 }
 
@@ -44,6 +49,9 @@ type initApp struct {
 	serverURI   string
 	namespace   string
 	registries  []registry.Registry
+	template    *Template
+
+	rendered *renderedTemplate
 }
 
 // New creates an instance of Init.
@@ -69,6 +77,31 @@ func (i *initApp) Run() error {
 		"k8s-spec-flag": i.k8sSpecFlag,
 	}).Debug("initializing ksonnet app")
 
+	// Render the template before anything is written, so template errors
+	// don't leave a partially created application behind.
+	if i.template != nil {
+		vars := TemplateVars{
+			Name:      i.name,
+			Namespace: i.namespace,
+			Server:    i.serverURI,
+		}
+
+		rendered, err := i.template.render(vars)
+		if err != nil {
+			return err
+		}
+		i.rendered = rendered
+
+		// Registries defined by the template replace registries with the same name.
+		var registries []registry.Registry
+		for _, r := range i.registries {
+			if _, ok := rendered.spec.Registries[r.Name()]; !ok {
+				registries = append(registries, r)
+			}
+		}
+		i.registries = registries
+	}
+
 	// Initialize directory structure.
 	if err := i.createAppDirTree(); err != nil {
 		return err
@@ -86,7 +119,11 @@ func (i *initApp) Run() error {
 	}
 
 	// Initialize environment, and cache specification data.
-	if i.serverURI != "" {
+	if i.rendered != nil && len(i.rendered.spec.Environments) > 0 {
+		if err = i.createTemplateEnvironments(a); err != nil {
+			return errorOnCreateFailure(i.name, err)
+		}
+	} else if i.serverURI != "" {
 		d := env.NewDestination(i.serverURI, i.namespace)
 		err = env.Create(
 			a,
@@ -109,9 +146,189 @@ func (i *initApp) Run() error {
 		}
 	}
 
+	if i.rendered == nil {
+		return nil
+	}
+
+	if err = i.writeTemplateFiles(); err != nil {
+		return errorOnCreateFailure(i.name, err)
+	}
+
+	if err = i.setupTemplateRegistries(a); err != nil {
+		return errorOnCreateFailure(i.name, err)
+	}
+
+	if err = i.installTemplateLibraries(a); err != nil {
+		return errorOnCreateFailure(i.name, err)
+	}
+
+	return nil
+}
+
+// createTemplateEnvironments creates the environments defined by the template.
+// Environments without a destination use the server and namespace the
+// application was initialized with.
+func (i *initApp) createTemplateEnvironments(a app.App) error {
+	envs := i.rendered.spec.Environments
+
+	var names []string
+	for name := range envs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		envCfg := envs[name]
+
+		server, namespace := i.serverURI, i.namespace
+		if envCfg.Destination != nil {
+			if envCfg.Destination.Server != "" {
+				server = envCfg.Destination.Server
+			}
+			if envCfg.Destination.Namespace != "" {
+				namespace = envCfg.Destination.Namespace
+			}
+		}
+
+		if server == "" {
+			return errors.Errorf("template environment %q does not have a server", name)
+		}
+
+		k8sSpecFlag := i.k8sSpecFlag
+		if envCfg.KubernetesVersion != "" {
+			k8sSpecFlag = "version:" + envCfg.KubernetesVersion
+		}
+
+		log.Debugf("creating template environment %s", name)
+		err := env.Create(
+			a,
+			env.NewDestination(server, namespace),
+			name,
+			k8sSpecFlag,
+			env.DefaultOverrideData,
+			env.DefaultParamsData,
+			false,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "creating environment %q", name)
+		}
+
+		if len(envCfg.Targets) > 0 {
+			if err = a.UpdateTargets(name, envCfg.Targets, false); err != nil {
+				return errors.Wrapf(err, "setting targets for environment %q", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// setupTemplateRegistries caches the registries defined by the template.
+func (i *initApp) setupTemplateRegistries(a app.App) error {
+	for _, name := range sortedRegistryNames(i.rendered.spec.Registries) {
+		r, err := registry.Locate(a, i.rendered.spec.Registries[name], i.httpClient)
+		if err != nil {
+			return errors.Wrapf(err, "locating registry %q", name)
+		}
+
+		if _, err = r.FetchRegistrySpec(); err != nil {
+			return errors.Wrapf(err, "caching registry %q", name)
+		}
+	}
+
 	return nil
 }
 
+// installTemplateLibraries installs the libraries defined by the template,
+// including the libraries of the template's environments.
+func (i *initApp) installTemplateLibraries(a app.App) error {
+	pm := registry.NewPackageManager(a, registry.HTTPClientOpt(i.httpClient))
+
+	if err := i.installLibraries(a, pm, "", i.rendered.spec.Libraries); err != nil {
+		return err
+	}
+
+	envs := i.rendered.spec.Environments
+
+	var envNames []string
+	for name := range envs {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+
+	for _, envName := range envNames {
+		if err := i.installLibraries(a, pm, envName, envs[envName].Libraries); err != nil {
+			return errors.Wrapf(err, "environment %q", envName)
+		}
+	}
+
+	return nil
+}
+
+// installLibraries installs libraries into the application, or into an
+// environment if envName is set.
+func (i *initApp) installLibraries(a app.App, pm registry.PackageManager, envName string, libs app.LibraryConfigs) error {
+	var names []string
+	for name := range libs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		libCfg := libs[name]
+
+		d := pkg.Descriptor{
+			Registry: libCfg.Registry,
+			Name:     libCfg.Name,
+			Version:  libCfg.Version,
+		}
+
+		log.Debugf("installing template library %s", d)
+		installed, err := registry.CacheDependency(a, pm, d, d.Name, false, i.httpClient)
+		if err != nil {
+			return errors.Wrapf(err, "installing library %s", d)
+		}
+
+		if _, err = a.UpdateLib(d.Name, envName, installed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTemplateFiles copies the template's files into the application. They
+// are written after environments are created so they can replace generated
+// scaffolding.
+func (i *initApp) writeTemplateFiles() error {
+	for _, rel := range i.rendered.paths() {
+		path := filepath.Join(i.rootPath, filepath.FromSlash(rel))
+		if !strings.HasPrefix(path, filepath.Clean(i.rootPath)+string(filepath.Separator)) {
+			return errors.Errorf("template file %q is outside of the application", rel)
+		}
+
+		if err := i.fs.MkdirAll(filepath.Dir(path), app.DefaultFolderPermissions); err != nil {
+			return err
+		}
+
+		log.Debugf("Creating file %s", path)
+		if err := afero.WriteFile(i.fs, path, i.rendered.files[rel], app.DefaultFilePermissions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedRegistryNames(registries app.RegistryConfigs) []string {
+	var names []string
+	for name := range registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (i *initApp) setupRegistry(r registry.Registry) error {
 	log := log.WithField("action", "initApp.setupRegistry")
 
@@ -141,7 +358,7 @@ func (i *initApp) setupRegistry(r registry.Registry) error {
 	return nil
 }
 
-func generateAppYAMLData(name string, refs ...*app.RegistryConfig) ([]byte, error) {
+func generateAppYAMLData(name string, base *app.Spec, refs ...*app.RegistryConfig) ([]byte, error) {
 	content := app.Spec{
 		APIVersion:   app.DefaultAPIVersion,
 		Kind:         app.Kind,
@@ -151,6 +368,16 @@ func generateAppYAMLData(name string, refs ...*app.RegistryConfig) ([]byte, erro
 		Environments: app.EnvironmentConfigs{},
 	}
 
+	if base != nil {
+		content.Description = base.Description
+		content.Authors = base.Authors
+		content.Contributors = base.Contributors
+		content.Repository = base.Repository
+		content.Bugs = base.Bugs
+		content.Keywords = base.Keywords
+		content.License = base.License
+	}
+
 	for _, ref := range refs {
 		err := content.AddRegistryConfig(ref)
 		if err != nil {
@@ -195,8 +422,16 @@ func (i *initApp) createAppDirTree() error {
 		specs = append(specs, r.MakeRegistryConfig())
 	}
 
+	var base *app.Spec
+	if i.rendered != nil {
+		base = i.rendered.spec
+		for _, name := range sortedRegistryNames(base.Registries) {
+			specs = append(specs, base.Registries[name])
+		}
+	}
+
 	// Generate data for `app.yaml`.
-	appYAMLData, err := generateAppYAMLData(i.name, specs...)
+	appYAMLData, err := generateAppYAMLData(i.name, base, specs...)
 	if err != nil {
 		return err
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/registry/mocks"
 	"github.com/ksonnet/ksonnet/pkg/util/test"
 	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,6 +43,7 @@ func TestInit(t *testing.T) {
 		Name: "incubator",
 	}
 	r.On("MakeRegistryConfig").Return(regRefSpec)
+	r.On("Name").Return("incubator")
 
 	regSpec := &registry.Spec{}
 	r.On("FetchRegistrySpec").Return(regSpec, nil)
@@ -120,6 +122,70 @@ func TestInit(t *testing.T) {
 
 }
 
+func TestInit_template(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	test.StageDir(t, fs, "registry", "/registry")
+	test.StageDir(t, fs, "template", "/template")
+
+	tmpl, err := LoadTemplate(fs, "/template")
+	require.NoError(t, err)
+
+	specReader, err := os.Open("../cluster/testdata/swagger.json")
+	require.NoError(t, err, "opening fixture: swagger.json")
+	defer specReader.Close()
+	httpClient := test.FakeHTTPClient(
+		&http.Response{
+			StatusCode: 200,
+			Body:       specReader,
+		}, nil)
+
+	i := newInitApp(fs, httpClient, "app", "/app", "", "version:v1.8.7", "http://example.com", "team", nil)
+	i.template = tmpl
+	require.NoError(t, i.Run())
+
+	a, err := app.Load(fs, httpClient, "/app")
+	require.NoError(t, err)
+
+	envs, err := a.Environments()
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Contains(t, envs, "dev")
+	assert.Equal(t, "http://example.com", envs["dev"].Destination.Server)
+	assert.Equal(t, "team-dev", envs["dev"].Destination.Namespace)
+	require.Contains(t, envs["dev"].Libraries, "local/redis")
+	assert.Equal(t, "local", envs["dev"].Libraries["local/redis"].Registry)
+
+	registries, err := a.Registries()
+	require.NoError(t, err)
+	require.Contains(t, registries, "local")
+
+	libs, err := a.Libraries()
+	require.NoError(t, err)
+	require.Contains(t, libs, "local/nginx")
+	assert.Equal(t, "local", libs["local/nginx"].Registry)
+	assert.NotContains(t, libs, "local/redis")
+
+	paths := []string{
+		filepath.Join("components", "params.libsonnet"),
+		filepath.Join("environments", "dev", "main.jsonnet"),
+		filepath.Join("lib", "util.libsonnet"),
+		filepath.Join("policies", "no-latest.rego"),
+		filepath.Join("vendor", "local", "nginx", "parts.yaml"),
+		filepath.Join("vendor", "local", "redis", "parts.yaml"),
+	}
+	for _, p := range paths {
+		assertExists(t, fs, filepath.Join("/app", p))
+	}
+
+	params, err := afero.ReadFile(fs, "/app/components/params.libsonnet")
+	require.NoError(t, err)
+	assert.Contains(t, string(params), `name: "app"`)
+
+	spec, err := afero.ReadFile(fs, "/app/app.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(spec), "description: app web service")
+}
+
 func checkApp(t *testing.T, fs afero.Fs, rootPath, version, namespace string) {
 	expectedDirs := []string{
 		".gitignore",
@@ -0,0 +1,202 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package appinit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// TemplateSpecFile is the name of the app.yaml fragment in an application template.
+	TemplateSpecFile = "app.yaml"
+
+	// templateSuffix marks template files which are rendered before they are copied.
+	templateSuffix = ".tmpl"
+)
+
+// TemplateVars are the variables available to an application template.
+type TemplateVars struct {
+	// Name is the name of the application.
+	Name string
+	// Namespace is the namespace of the initial environment.
+	Namespace string
+	// Server is the server of the initial environment.
+	Server string
+}
+
+// Template is an application template. It contains an app.yaml fragment which
+// supplies registries, environments and libraries for the new application,
+// and files which are copied into the application. The app.yaml fragment and
+// files ending in `.tmpl` are rendered with TemplateVars.
+type Template struct {
+	files map[string][]byte
+}
+
+// NewTemplate creates an instance of Template. Files are keyed by their slash
+// separated path relative to the template root.
+func NewTemplate(files map[string][]byte) *Template {
+	return &Template{files: files}
+}
+
+// LoadTemplate loads a template from a directory.
+func LoadTemplate(fs afero.Fs, dir string) (*Template, error) {
+	ok, err := afero.DirExists(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("template directory %q does not exist", dir)
+	}
+
+	files := make(map[string][]byte)
+	err = afero.Walk(fs, dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if fi.IsDir() {
+			if fi.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading template from %q", dir)
+	}
+
+	return NewTemplate(files), nil
+}
+
+// templateSpec is the app.yaml fragment of a template. It is a subset of
+// app.Spec without the fields ksonnet manages.
+type templateSpec struct {
+	Description  string                 `json:"description,omitempty"`
+	Authors      []string               `json:"authors,omitempty"`
+	Contributors app.ContributorSpecs   `json:"contributors,omitempty"`
+	Repository   *app.RepositorySpec    `json:"repository,omitempty"`
+	Bugs         string                 `json:"bugs,omitempty"`
+	Keywords     []string               `json:"keywords,omitempty"`
+	License      string                 `json:"license,omitempty"`
+	Registries   app.RegistryConfigs    `json:"registries,omitempty"`
+	Environments app.EnvironmentConfigs `json:"environments,omitempty"`
+	Libraries    app.LibraryConfigs     `json:"libraries,omitempty"`
+}
+
+func (ts *templateSpec) spec() *app.Spec {
+	return &app.Spec{
+		Description:  ts.Description,
+		Authors:      ts.Authors,
+		Contributors: ts.Contributors,
+		Repository:   ts.Repository,
+		Bugs:         ts.Bugs,
+		Keywords:     ts.Keywords,
+		License:      ts.License,
+		Registries:   ts.Registries,
+		Environments: ts.Environments,
+		Libraries:    ts.Libraries,
+	}
+}
+
+// renderedTemplate is a template after variable substitution.
+type renderedTemplate struct {
+	spec  *app.Spec
+	files map[string][]byte
+}
+
+// paths returns the file paths in the template in sorted order.
+func (rt *renderedTemplate) paths() []string {
+	var paths []string
+	for path := range rt.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (t *Template) render(vars TemplateVars) (*renderedTemplate, error) {
+	rt := &renderedTemplate{
+		spec:  &app.Spec{},
+		files: make(map[string][]byte),
+	}
+
+	for path, data := range t.files {
+		if path == TemplateSpecFile {
+			rendered, err := renderTemplate(path, data, vars)
+			if err != nil {
+				return nil, err
+			}
+
+			var fragment templateSpec
+			if err := yaml.Unmarshal(rendered, &fragment); err != nil {
+				return nil, errors.Wrapf(err, "parsing template %s", TemplateSpecFile)
+			}
+			rt.spec = fragment.spec()
+			continue
+		}
+
+		if strings.HasSuffix(path, templateSuffix) {
+			rendered, err := renderTemplate(path, data, vars)
+			if err != nil {
+				return nil, err
+			}
+
+			rt.files[strings.TrimSuffix(path, templateSuffix)] = rendered
+			continue
+		}
+
+		rt.files[path] = data
+	}
+
+	return rt, nil
+}
+
+func renderTemplate(name string, data []byte, vars TemplateVars) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing template file %s", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return nil, errors.Wrapf(err, "rendering template file %s", name)
+	}
+
+	return buf.Bytes(), nil
+}
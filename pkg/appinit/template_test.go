@@ -0,0 +1,86 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package appinit
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/util/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTemplate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	test.StageDir(t, fs, "template", "/template")
+	test.StageFile(t, fs, "registry.yaml", "/template/.git/config")
+
+	tmpl, err := LoadTemplate(fs, "/template")
+	require.NoError(t, err)
+
+	rt, err := tmpl.render(TemplateVars{Name: "app", Namespace: "team", Server: "http://example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "app web service", rt.spec.Description)
+	require.Contains(t, rt.spec.Environments, "dev")
+	assert.Equal(t, "team-dev", rt.spec.Environments["dev"].Destination.Namespace)
+	require.Contains(t, rt.spec.Registries, "local")
+	assert.Equal(t, "local", rt.spec.Registries["local"].Name)
+	require.Contains(t, rt.spec.Libraries, "local/nginx")
+
+	expected := []string{
+		"components/params.libsonnet",
+		"lib/util.libsonnet",
+		"policies/no-latest.rego",
+	}
+	assert.Equal(t, expected, rt.paths())
+	assert.Contains(t, string(rt.files["components/params.libsonnet"]), `name: "app"`)
+}
+
+func TestLoadTemplate_missing(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	_, err := LoadTemplate(fs, "/template")
+	require.Error(t, err)
+}
+
+func TestTemplate_render_invalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{
+			name:  "unknown variable",
+			files: map[string][]byte{"lib/a.libsonnet.tmpl": []byte("{{ .Cluster }}")},
+		},
+		{
+			name:  "invalid template",
+			files: map[string][]byte{"app.yaml": []byte("description: {{ .Name")},
+		},
+		{
+			name:  "invalid app.yaml",
+			files: map[string][]byte{"app.yaml": []byte("environments: [")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTemplate(tc.files).render(TemplateVars{Name: "app"})
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package ksonnet
+
+deny[msg] {
+  endswith(input.image, ":latest")
+  msg = "images must not use the latest tag"
+}
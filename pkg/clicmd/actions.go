@@ -26,23 +26,31 @@ type initName int
 const (
 	actionApply initName = iota
 	actionComponentList
+	actionComponentMv
 	actionComponentRm
 	actionDelete
 	actionDiff
 	actionEnvAdd
+	actionEnvClone
 	actionEnvCurrent
 	actionEnvDescribe
 	actionEnvList
+	actionEnvPromote
 	actionEnvRm
 	actionEnvSet
 	actionEnvTargets
 	actionEnvUpdate
+	actionImagesList
+	actionImagesPin
 	actionImport
 	actionInit
 	actionModuleCreate
 	actionModuleList
 	actionParamDelete
 	actionParamDiff
+	actionParamExplain
+	actionParamExport
+	actionParamImport
 	actionParamList
 	actionParamSet
 	actionParamUnset
@@ -51,15 +59,21 @@ const (
 	actionPkgList
 	actionPkgRemove
 	actionPrototypeDescribe
+	actionPrototypeLint
 	actionPrototypeList
 	actionPrototypePreview
 	actionPrototypeSearch
+	actionPrototypeTest
 	actionPrototypeUse
 	actionRegistryAdd
 	actionRegistryDescribe
 	actionRegistryList
 	actionRegistrySet
+	actionServe
 	actionShow
+	actionSnapshotCheck
+	actionSnapshotUpdate
+	actionTest
 	actionUpgrade
 	actionValidate
 )
@@ -70,23 +84,31 @@ var (
 	actionFns = map[initName]actionFn{
 		actionApply:             actions.RunApply,
 		actionComponentList:     actions.RunComponentList,
+		actionComponentMv:       actions.RunComponentMv,
 		actionComponentRm:       actions.RunComponentRm,
 		actionDelete:            actions.RunDelete,
 		actionDiff:              actions.RunDiff,
 		actionEnvAdd:            actions.RunEnvAdd,
+		actionEnvClone:          actions.RunEnvClone,
 		actionEnvCurrent:        actions.RunEnvCurrent,
 		actionEnvDescribe:       actions.RunEnvDescribe,
 		actionEnvList:           actions.RunEnvList,
+		actionEnvPromote:        actions.RunEnvPromote,
 		actionEnvRm:             actions.RunEnvRm,
 		actionEnvSet:            actions.RunEnvSet,
 		actionEnvTargets:        actions.RunEnvTargets,
 		actionEnvUpdate:         actions.RunEnvUpdate,
+		actionImagesList:        actions.RunImagesList,
+		actionImagesPin:         actions.RunImagesPin,
 		actionImport:            actions.RunImport,
 		actionInit:              actions.RunInit,
 		actionModuleCreate:      actions.RunModuleCreate,
 		actionModuleList:        actions.RunModuleList,
 		actionParamDiff:         actions.RunParamDiff,
 		actionParamDelete:       actions.RunParamDelete,
+		actionParamExplain:      actions.RunParamExplain,
+		actionParamExport:       actions.RunParamExport,
+		actionParamImport:       actions.RunParamImport,
 		actionParamUnset:        actions.RunParamDelete,
 		actionParamList:         actions.RunParamList,
 		actionParamSet:          actions.RunParamSet,
@@ -95,15 +117,21 @@ var (
 		actionPkgList:           actions.RunPkgList,
 		actionPkgRemove:         actions.RunPkgRemove,
 		actionPrototypeDescribe: actions.RunPrototypeDescribe,
+		actionPrototypeLint:     actions.RunPrototypeLint,
 		actionPrototypeList:     actions.RunPrototypeList,
 		actionPrototypePreview:  actions.RunPrototypePreview,
 		actionPrototypeSearch:   actions.RunPrototypeSearch,
+		actionPrototypeTest:     actions.RunPrototypeTest,
 		actionPrototypeUse:      actions.RunPrototypeUse,
 		actionRegistryAdd:       actions.RunRegistryAdd,
 		actionRegistryDescribe:  actions.RunRegistryDescribe,
 		actionRegistryList:      actions.RunRegistryList,
 		actionRegistrySet:       actions.RunRegistrySet,
+		actionServe:             actions.RunServe,
 		actionShow:              actions.RunShow,
+		actionSnapshotCheck:     actions.RunSnapshotCheck,
+		actionSnapshotUpdate:    actions.RunSnapshotUpdate,
+		actionTest:              actions.RunTest,
 		actionUpgrade:           actions.RunUpgrade,
 		actionValidate:          actions.RunValidate,
 	}
@@ -120,5 +148,6 @@ func runAction(name initName, args map[string]interface{}) error {
 
 func addGlobalOptions(m map[string]interface{}) {
 	m[actions.OptionTLSSkipVerify] = viper.GetBool(flagTLSSkipVerify)
+	m[actions.OptionTLSCABundle] = viper.GetString(flagTLSCABundle)
 	m[actions.OptionAppRoot] = viper.GetString(flagDir)
 }
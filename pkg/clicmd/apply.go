@@ -29,7 +29,9 @@ const (
 	vApplyCreate    = "apply-create"
 	vApplyGcTag     = "apply-gc-tag"
 	vApplyDryRun    = "apply-dry-run"
+	vApplyForce     = "apply-force"
 	vApplySkipGc    = "apply-skip-gc"
+	vApplyNoCache   = "apply-no-cache"
 
 	applyShortDesc = "Apply local Kubernetes manifests (components) to remote clusters"
 	applyLong      = `
@@ -44,6 +46,11 @@ expanded using the parameters of the specified environment.
 By default, all component manifests are applied. To apply a subset of components,
 use the ` + "`--component` " + `flag, as seen in the examples below.
 
+Objects tagged with ` + "`--gc-tag`" + ` which are no longer in the manifests are garbage
+collected, unless they are protected. Namespaces, PersistentVolumes,
+PersistentVolumeClaims and objects with the ` + "`ksonnet.io/protected: \"true\"`" + `
+annotation are protected. Use ` + "`--force`" + ` to garbage collect them as well.
+
 Note that this command needs to be run *within* a ksonnet app directory.
 
 ### Related Commands
@@ -100,8 +107,10 @@ func newApplyCmd(fs afero.Fs) *cobra.Command {
 				actions.OptionCreate:         viper.GetBool(vApplyCreate),
 				actions.OptionDryRun:         viper.GetBool(vApplyDryRun),
 				actions.OptionEnvName:        envName,
+				actions.OptionForce:          viper.GetBool(vApplyForce),
 				actions.OptionGcTag:          viper.GetString(vApplyGcTag),
 				actions.OptionSkipGc:         viper.GetBool(vApplySkipGc),
+				actions.OptionNoCache:        viper.GetBool(vApplyNoCache),
 			}
 			addGlobalOptions(m)
 
@@ -133,5 +142,11 @@ func newApplyCmd(fs afero.Fs) *cobra.Command {
 	applyCmd.Flags().Bool(flagDryRun, false, "Option to preview the list of operations without changing the cluster state")
 	viper.BindPFlag(vApplyDryRun, applyCmd.Flags().Lookup(flagDryRun))
 
+	applyCmd.Flags().Bool(flagNoCache, false, "Render all components without using the render cache")
+	viper.BindPFlag(vApplyNoCache, applyCmd.Flags().Lookup(flagNoCache))
+
+	applyCmd.Flags().Bool(flagForce, false, "Garbage collect protected objects")
+	viper.BindPFlag(vApplyForce, applyCmd.Flags().Lookup(flagForce))
+
 	return applyCmd
 }
@@ -32,8 +32,27 @@ func Test_applyCmd(t *testing.T) {
 			expected: map[string]interface{}{
 				actions.OptionApp:            mock.AnythingOfType("*app.App"),
 				actions.OptionEnvName:        "default",
+				actions.OptionForce:          false,
 				actions.OptionGcTag:          "",
 				actions.OptionSkipGc:         false,
+				actions.OptionNoCache:        false,
+				actions.OptionComponentNames: make([]string, 0),
+				actions.OptionCreate:         true,
+				actions.OptionDryRun:         false,
+				actions.OptionClientConfig:   mock.AnythingOfType("*client.Config"),
+			},
+		},
+		{
+			name:   "with force",
+			args:   []string{"apply", "default", "--gc-tag", "gc", "--force"},
+			action: actionApply,
+			expected: map[string]interface{}{
+				actions.OptionApp:            mock.AnythingOfType("*app.App"),
+				actions.OptionEnvName:        "default",
+				actions.OptionForce:          true,
+				actions.OptionGcTag:          "gc",
+				actions.OptionSkipGc:         false,
+				actions.OptionNoCache:        false,
 				actions.OptionComponentNames: make([]string, 0),
 				actions.OptionCreate:         true,
 				actions.OptionDryRun:         false,
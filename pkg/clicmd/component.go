@@ -35,6 +35,7 @@ func newComponentCmd() *cobra.Command {
 	}
 
 	componentCmd.AddCommand(newComponentListCmd())
+	componentCmd.AddCommand(newComponentMvCmd())
 	componentCmd.AddCommand(newComponentRmCmd())
 
 	return componentCmd
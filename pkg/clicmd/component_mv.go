@@ -0,0 +1,64 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+)
+
+var (
+	componentMvLong = `Rename a component, or move it to another module. The component file is moved,
+and the component's params, its parameter schema, and references to its params are
+renamed in its module and in every environment. A module in the new name, e.g.
+` + "`web.frontend`" + `, moves the component to that module, which is created if it doesn't
+exist. References only resolve within a module, so a component which references, or
+is referenced by, other components can't be moved to another module.
+
+Objects named after the component will have new names in the cluster the next
+time it is applied.`
+	componentMvExample = `# Rename the component 'guestbook' to 'guestbook-ui'.
+ks component mv guestbook guestbook-ui
+
+# Move the component 'guestbook' to the 'web' module as 'frontend'.
+ks component mv guestbook web.frontend`
+)
+
+func newComponentMvCmd() *cobra.Command {
+	componentMvCmd := &cobra.Command{
+		Use:     "mv <component-name> <new-component-name>",
+		Short:   "Rename a component or move it to another module",
+		Long:    componentMvLong,
+		Example: componentMvExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("'component mv' takes two arguments, the name of the component and its new name")
+			}
+
+			m := map[string]interface{}{
+				actions.OptionComponentName:    args[0],
+				actions.OptionNewComponentName: args[1],
+			}
+			addGlobalOptions(m)
+
+			return runAction(actionComponentMv, m)
+		},
+	}
+
+	return componentMvCmd
+}
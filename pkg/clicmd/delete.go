@@ -16,6 +16,8 @@
 package clicmd
 
 import (
+	"time"
+
 	"github.com/ksonnet/ksonnet/pkg/actions"
 	"github.com/ksonnet/ksonnet/pkg/client"
 	"github.com/pkg/errors"
@@ -26,7 +28,10 @@ import (
 
 const (
 	vDeleteComponent   = "delete-components"
+	vDeleteForce       = "delete-force"
 	vDeleteGracePeriod = "delete-grace-period"
+	vDeleteTimeout     = "delete-timeout"
+	vDeleteWait        = "delete-wait"
 
 	deleteShortDesc = "Remove component-specified Kubernetes resources from remote clusters"
 	deleteLong      = `
@@ -39,6 +44,21 @@ components.
 
 **This command can be considered the inverse of the ` + "`ks apply`" + ` command.**
 
+Namespaces, PersistentVolumes and PersistentVolumeClaims are protected and are
+skipped, because deleting them usually loses data. Any other object can be
+protected with the ` + "`ksonnet.io/protected: \"true\"`" + ` annotation, and protected kinds
+can opt out with ` + "`ksonnet.io/protected: \"false\"`" + `. Use ` + "`--force`" + ` to delete
+protected objects.
+
+Environments with ` + "`protected: true`" + ` in ` + "`app.yaml`" + ` list the objects to be
+deleted and ask for confirmation first. If the command isn't run in a terminal,
+` + "`--force`" + ` is required.
+
+Deletion happens in the background, so objects may still be terminating when the
+command exits. Use ` + "`--wait`" + ` to wait until every deleted object has been removed
+from the cluster. Objects that are still present when ` + "`--timeout`" + ` expires are
+reported along with the finalizers they are waiting on, and the command fails.
+
 ### Related Commands
 
 * ` + "`ks diff` " + `— Compare manifests, based on environment or location (local or remote)
@@ -54,7 +74,15 @@ ks delete dev
 # Delete resources described by the 'nginx' component. $KUBECONFIG is overridden by
 # the CLI-specified './kubeconfig', so these changes are deployed to the current
 # context's cluster (not the 'default' environment)
-ks delete --kubeconfig=./kubeconfig -c nginx`
+ks delete --kubeconfig=./kubeconfig -c nginx
+
+# Delete resources from the 'prod' environment, including protected objects such
+# as namespaces, without asking for confirmation.
+ks delete prod --force
+
+# Delete resources from the 'dev' environment and wait up to two minutes for
+# them to be removed from the cluster.
+ks delete dev --wait --timeout 2m`
 )
 
 func newDeleteCmd(fs afero.Fs) *cobra.Command {
@@ -75,7 +103,10 @@ func newDeleteCmd(fs afero.Fs) *cobra.Command {
 				actions.OptionClientConfig:   deleteClientConfig,
 				actions.OptionComponentNames: viper.GetStringSlice(vDeleteComponent),
 				actions.OptionEnvName:        envName,
+				actions.OptionForce:          viper.GetBool(vDeleteForce),
 				actions.OptionGracePeriod:    viper.GetInt64(vDeleteGracePeriod),
+				actions.OptionTimeout:        viper.GetDuration(vDeleteTimeout),
+				actions.OptionWait:           viper.GetBool(vDeleteWait),
 			}
 			addGlobalOptions(m)
 
@@ -96,5 +127,14 @@ func newDeleteCmd(fs afero.Fs) *cobra.Command {
 	deleteCmd.Flags().Int64(flagGracePeriod, -1, "Number of seconds given to resources to terminate gracefully. A negative value is ignored")
 	viper.BindPFlag(vDeleteGracePeriod, deleteCmd.Flags().Lookup(flagGracePeriod))
 
+	deleteCmd.Flags().Bool(flagWait, false, "Wait until deleted objects have been removed from the cluster")
+	viper.BindPFlag(vDeleteWait, deleteCmd.Flags().Lookup(flagWait))
+
+	deleteCmd.Flags().Duration(flagTimeout, 5*time.Minute, "How long to wait for deleted objects to be removed. Zero waits forever")
+	viper.BindPFlag(vDeleteTimeout, deleteCmd.Flags().Lookup(flagTimeout))
+
+	deleteCmd.Flags().Bool(flagForce, false, "Delete protected objects, and don't ask for confirmation in protected environments")
+	viper.BindPFlag(vDeleteForce, deleteCmd.Flags().Lookup(flagForce))
+
 	return deleteCmd
 }
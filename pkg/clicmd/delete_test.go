@@ -17,6 +17,7 @@ package clicmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ksonnet/ksonnet/pkg/actions"
 )
@@ -32,7 +33,40 @@ func Test_deleteCmd(t *testing.T) {
 				actions.OptionEnvName:        "default",
 				actions.OptionComponentNames: make([]string, 0),
 				actions.OptionClientConfig:   nil,
+				actions.OptionForce:          false,
 				actions.OptionGracePeriod:    int64(-1),
+				actions.OptionTimeout:        5 * time.Minute,
+				actions.OptionWait:           false,
+			},
+		},
+		{
+			name:   "with force",
+			args:   []string{"delete", "default", "--force"},
+			action: actionDelete,
+			expected: map[string]interface{}{
+				actions.OptionApp:            nil,
+				actions.OptionEnvName:        "default",
+				actions.OptionComponentNames: make([]string, 0),
+				actions.OptionClientConfig:   nil,
+				actions.OptionForce:          true,
+				actions.OptionGracePeriod:    int64(-1),
+				actions.OptionTimeout:        5 * time.Minute,
+				actions.OptionWait:           false,
+			},
+		},
+		{
+			name:   "with wait",
+			args:   []string{"delete", "default", "--wait", "--timeout", "2m"},
+			action: actionDelete,
+			expected: map[string]interface{}{
+				actions.OptionApp:            nil,
+				actions.OptionEnvName:        "default",
+				actions.OptionComponentNames: make([]string, 0),
+				actions.OptionClientConfig:   nil,
+				actions.OptionForce:          false,
+				actions.OptionGracePeriod:    int64(-1),
+				actions.OptionTimeout:        2 * time.Minute,
+				actions.OptionWait:           true,
 			},
 		},
 		{
@@ -0,0 +1,93 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vEnvCloneNamespace = "env-clone-namespace"
+	vEnvCloneServer    = "env-clone-server"
+	vEnvCloneOverride  = "env-clone-override"
+)
+
+var (
+	envCloneLong = `
+The ` + "`clone`" + ` command creates a new environment from an existing environment.
+The new environment has a copy of the source environment's params, globals,
+targets and libraries, and uses the same Kubernetes version.
+
+The new environment points to the server and namespace set with ` + "`--server`" + `
+and ` + "`--namespace`" + `. If they are not set, the source environment's values are used.
+
+### Related Commands
+
+* ` + "`ks env promote` " + `— ` + envShortDesc["promote"] + `
+* ` + "`ks env add` " + `— ` + envShortDesc["add"] + `
+* ` + "`ks env list` " + `— ` + envShortDesc["list"] + `
+
+### Syntax
+`
+	envCloneExample = `# Create a 'prod' environment from 'staging', deploying to the 'prod' namespace
+# on the same cluster.
+ks env clone staging prod --namespace=prod
+
+# Create a 'us-east/staging' environment from 'us-west/staging' on another cluster.
+ks env clone us-west/staging us-east/staging --server=https://192.168.99.100:8443`
+)
+
+func newEnvCloneCmd() *cobra.Command {
+	envCloneCmd := &cobra.Command{
+		Use:     "clone <src-env> <dst-env>",
+		Short:   envShortDesc["clone"],
+		Long:    envCloneLong,
+		Example: envCloneExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("'env clone' takes two arguments, the source and new environment names")
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName:    args[0],
+				actions.OptionNewEnvName: args[1],
+				actions.OptionNamespace:  viper.GetString(vEnvCloneNamespace),
+				actions.OptionServer:     viper.GetString(vEnvCloneServer),
+				actions.OptionOverride:   viper.GetBool(vEnvCloneOverride),
+			}
+			addGlobalOptions(m)
+
+			return runAction(actionEnvClone, m)
+		},
+	}
+
+	envCloneCmd.Flags().String(flagNamespace, "",
+		"Namespace for the new environment")
+	viper.BindPFlag(vEnvCloneNamespace, envCloneCmd.Flags().Lookup(flagNamespace))
+
+	envCloneCmd.Flags().String(flagServer, "",
+		"Server for the new environment")
+	viper.BindPFlag(vEnvCloneServer, envCloneCmd.Flags().Lookup(flagServer))
+
+	envCloneCmd.Flags().BoolP(flagOverride, shortOverride, false, "Add the new environment as override")
+	viper.BindPFlag(vEnvCloneOverride, envCloneCmd.Flags().Lookup(flagOverride))
+
+	return envCloneCmd
+}
@@ -24,8 +24,9 @@ import (
 )
 
 const (
-	vEnvCurrentSet   = "env-current-set"
-	vEnvCurrentUnset = "env-current-unset"
+	vEnvCurrentOutput = "env-current-output"
+	vEnvCurrentSet    = "env-current-set"
+	vEnvCurrentUnset  = "env-current-unset"
 )
 
 var (
@@ -44,6 +45,9 @@ ks env current --set us-west/staging
 #Retrieve the current environment
 ks env current
 
+#Retrieve the current environment as JSON
+ks env current -o json
+
 #Unset the current environment
 ks env current --unset`
 )
@@ -61,6 +65,7 @@ func newEnvCurrentCmd() *cobra.Command {
 
 			m := map[string]interface{}{
 				actions.OptionEnvName: viper.GetString(vEnvCurrentSet),
+				actions.OptionOutput:  viper.GetString(vEnvCurrentOutput),
 				actions.OptionUnset:   viper.GetBool(vEnvCurrentUnset),
 			}
 			addGlobalOptions(m)
@@ -77,5 +82,7 @@ func newEnvCurrentCmd() *cobra.Command {
 		"Unset current environment")
 	viper.BindPFlag(vEnvCurrentUnset, envCurrentCmd.Flags().Lookup(flagUnset))
 
+	addCmdOutput(envCurrentCmd, vEnvCurrentOutput)
+
 	return envCurrentCmd
 }
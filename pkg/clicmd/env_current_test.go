@@ -29,6 +29,7 @@ func Test_envCurrentCmd(t *testing.T) {
 			action: actionEnvCurrent,
 			expected: map[string]interface{}{
 				actions.OptionApp:     nil,
+				actions.OptionOutput:  "",
 				actions.OptionEnvName: "",
 				actions.OptionUnset:   false,
 			},
@@ -39,6 +40,7 @@ func Test_envCurrentCmd(t *testing.T) {
 			action: actionEnvCurrent,
 			expected: map[string]interface{}{
 				actions.OptionApp:     nil,
+				actions.OptionOutput:  "",
 				actions.OptionEnvName: "default",
 				actions.OptionUnset:   false,
 			},
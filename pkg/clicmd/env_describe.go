@@ -19,6 +19,11 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/actions"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vEnvDescribeOutput = "env-describe-output"
 )
 
 func newEnvDescribeCmd() *cobra.Command {
@@ -33,6 +38,7 @@ func newEnvDescribeCmd() *cobra.Command {
 
 			m := map[string]interface{}{
 				actions.OptionEnvName: args[0],
+				actions.OptionOutput:  viper.GetString(vEnvDescribeOutput),
 			}
 			addGlobalOptions(m)
 
@@ -40,6 +46,8 @@ func newEnvDescribeCmd() *cobra.Command {
 		},
 	}
 
+	addCmdOutput(envDescribeCmd, vEnvDescribeOutput)
+
 	return envDescribeCmd
 
 }
@@ -29,6 +29,7 @@ func Test_envDescribeCmd(t *testing.T) {
 			action: actionEnvDescribe,
 			expected: map[string]interface{}{
 				actions.OptionApp:     nil,
+				actions.OptionOutput:  "",
 				actions.OptionEnvName: "prod",
 			},
 		},
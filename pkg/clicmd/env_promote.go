@@ -0,0 +1,88 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vEnvPromoteComponent = "env-promote-component"
+	vEnvPromoteDryRun    = "env-promote-dry-run"
+)
+
+var (
+	envPromoteLong = `
+The ` + "`promote`" + ` command copies environment params from one environment to another.
+Params set in the source environment's ` + "`params.libsonnet`" + ` which are missing or have
+a different value in the destination environment are shown, and then set in the
+destination environment's ` + "`params.libsonnet`" + `.
+
+Params in the destination environment which aren't set in the source environment
+are left unchanged. Params which reference another component's param are skipped.
+
+Use ` + "`--dry-run`" + ` to show the differences without changing the destination
+environment.
+
+### Related Commands
+
+* ` + "`ks env clone` " + `— ` + envShortDesc["clone"] + `
+* ` + "`ks param diff` " + `— ` + paramShortDesc["diff"] + `
+
+### Syntax
+`
+	envPromoteExample = `# Show the params which would be promoted from 'staging' to 'prod'.
+ks env promote staging prod --dry-run
+
+# Promote the params of the 'guestbook' component from 'staging' to 'prod'.
+ks env promote staging prod -c guestbook`
+)
+
+func newEnvPromoteCmd() *cobra.Command {
+	envPromoteCmd := &cobra.Command{
+		Use:     "promote <src-env> <dst-env> [-c <component-name>] [--dry-run]",
+		Short:   envShortDesc["promote"],
+		Long:    envPromoteLong,
+		Example: envPromoteExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("'env promote' takes two arguments, the source and destination environment names")
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName1:      args[0],
+				actions.OptionEnvName2:      args[1],
+				actions.OptionComponentName: viper.GetString(vEnvPromoteComponent),
+				actions.OptionDryRun:        viper.GetBool(vEnvPromoteDryRun),
+			}
+			addGlobalOptions(m)
+
+			return runAction(actionEnvPromote, m)
+		},
+	}
+
+	envPromoteCmd.Flags().StringP(flagComponent, shortComponent, "", "Only promote params of this component")
+	viper.BindPFlag(vEnvPromoteComponent, envPromoteCmd.Flags().Lookup(flagComponent))
+
+	envPromoteCmd.Flags().Bool(flagDryRun, false, "Show the params which would be promoted without changing the destination environment")
+	viper.BindPFlag(vEnvPromoteDryRun, envPromoteCmd.Flags().Lookup(flagDryRun))
+
+	return envPromoteCmd
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+)
+
+func Test_envPromoteCmd(t *testing.T) {
+	cases := []cmdTestCase{
+		{
+			name:   "in general",
+			args:   []string{"env", "promote", "staging", "prod"},
+			action: actionEnvPromote,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionEnvName1:      "staging",
+				actions.OptionEnvName2:      "prod",
+				actions.OptionComponentName: "",
+				actions.OptionDryRun:        false,
+			},
+		},
+		{
+			name:   "component and dry run",
+			args:   []string{"env", "promote", "staging", "prod", "-c", "guestbook", "--dry-run"},
+			action: actionEnvPromote,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionEnvName1:      "staging",
+				actions.OptionEnvName2:      "prod",
+				actions.OptionComponentName: "guestbook",
+				actions.OptionDryRun:        true,
+			},
+		},
+		{
+			name:  "missing destination environment",
+			args:  []string{"env", "promote", "staging"},
+			isErr: true,
+		},
+	}
+
+	runTestCmd(t, cases)
+}
@@ -16,6 +16,7 @@
 package clicmd
 
 import (
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -23,6 +24,8 @@ import (
 const (
 	// For use in the commands (e.g., diff, apply, delete) that require either an
 	// environment or the -f flag.
+	flagAddress               = "address"
+	flagAdopt                 = "adopt"
 	flagAPISpec               = "api-spec"
 	flagAsString              = "as-string"
 	flagComponent             = "component"
@@ -30,30 +33,47 @@ const (
 	flagDir                   = "dir"
 	flagDryRun                = "dry-run"
 	flagEnv                   = "env"
+	flagExplain               = "explain"
 	flagExtVar                = "ext-str"
 	flagExtVarFile            = "ext-str-file"
 	flagFilename              = "filename"
 	flagForce                 = "force"
 	flagFormat                = "format"
+	flagFromCluster           = "from-cluster"
 	flagGcTag                 = "gc-tag"
 	flagGracePeriod           = "grace-period"
 	flagInstalled             = "installed"
+	flagInteractive           = "interactive"
 	flagJpath                 = "jpath"
+	flagJUnit                 = "junit"
+	flagKind                  = "kind"
 	flagModule                = "module"
 	flagNamespace             = "namespace"
+	flagNoCache               = "no-cache"
+	flagRef                   = "ref"
 	flagResolveImage          = "resolve-image"
+	flagSelector              = "selector"
 	flagServer                = "server"
 	flagSet                   = "set"
+	flagSingle                = "single"
 	flagSkipDefaultRegistries = "skip-default-registries"
 	flagSkipGc                = "skip-gc"
+	flagTemplate              = "template"
+	flagTimeout               = "timeout"
 	flagTlaVar                = "tla-str"
 	flagTlaVarFile            = "tla-str-file"
+	flagTLSCABundle           = "tls-ca-bundle"
 	flagTLSSkipVerify         = "tls-skip-verify"
 	flagOutput                = "output"
+	flagPackage               = "package"
+	flagRegistry              = "registry"
+	flagType                  = "type"
 	flagOverride              = "override"
 	flagUnset                 = "unset"
+	flagValuesFile            = "values-file"
 	flagVerbose               = "verbose"
 	flagVersion               = "version"
+	flagWait                  = "wait"
 	flagWithoutModules        = "without-modules"
 
 	shortComponent = "c"
@@ -61,11 +81,12 @@ const (
 	shortFormat    = "o"
 	shortOutput    = "o"
 	shortOverride  = "o"
+	shortSelector  = "l"
 )
 
 // addCmdOutput adds an output flag to a command. `name` is the name
 // of the viper assignment.
 func addCmdOutput(cmd *cobra.Command, name string) {
-	cmd.Flags().StringP(flagOutput, shortOutput, "", "Output format. Valid options: table|json")
+	cmd.Flags().StringP(flagOutput, shortOutput, "", "Output format. Valid options: "+table.Formats)
 	viper.BindPFlag(name, cmd.Flags().Lookup(flagOutput))
 }
@@ -94,7 +94,7 @@ func runTestCmd(t *testing.T, cases []cmdTestCase) {
 					case actions.OptionFs:
 						var expected *afero.MemMapFs
 						assert.IsType(t, expected, v)
-					case actions.OptionAppRoot, actions.OptionTLSSkipVerify:
+					case actions.OptionAppRoot, actions.OptionTLSSkipVerify, actions.OptionTLSCABundle:
 						if tc.expected[k] != nil {
 							assert.Equal(t, tc.expected[k], v, "unexpected value for %q", k)
 						}
@@ -0,0 +1,61 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var imagesLong = `
+The ` + "`images`" + ` subcommands manage the container images used by the manifests
+rendered for an environment.
+
+An image is *pinned* when it is referenced by digest (` + "`nginx@sha256:...`" + `) rather
+than by tag (` + "`nginx:1.15`" + `). Tags can be moved to other images, but a digest
+always refers to the same image, so pinning every image before a release makes
+sure the environment is deployed with the images that were tested.
+
+### Related Commands
+
+* ` + "`ks images list` " + `— ` + imagesListShortDesc + `
+* ` + "`ks images pin` " + `— ` + imagesPinShortDesc + `
+* ` + "`ks param set` " + `— ` + paramShortDesc["set"] + `
+
+### Syntax
+`
+
+func newImagesCmd(fs afero.Fs) *cobra.Command {
+	imagesCmd := &cobra.Command{
+		Use:   "images",
+		Short: "Manage the container images used in environments",
+		Long:  imagesLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("%s is not a valid subcommand\n\n%s", strings.Join(args, " "), cmd.UsageString())
+			}
+			return fmt.Errorf("Command 'images' requires a subcommand\n\n%s", cmd.UsageString())
+		},
+	}
+
+	imagesCmd.AddCommand(newImagesListCmd(fs))
+	imagesCmd.AddCommand(newImagesPinCmd(fs))
+
+	return imagesCmd
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	imagesListShortDesc = "List the container images used in an environment"
+	vImagesListOutput   = "images-list-output"
+)
+
+var (
+	imagesListLong = `
+The ` + "`list`" + ` command renders ` + "`<env-name>`" + ` and lists the images of every container
+and init container, with the component which uses the image, whether the image
+is pinned to a digest, and the component parameters which set the image.
+
+Images which aren't set by a parameter can't be pinned with ` + "`ks images pin`" + `.
+
+### Related Commands
+
+* ` + "`ks images pin` " + `— ` + imagesPinShortDesc + `
+
+### Syntax
+`
+	imagesListExample = `
+# List the images used in the 'dev' environment
+ks images list dev
+
+# List the images used in the current environment as JSON
+ks images list -o json
+`
+)
+
+func newImagesListCmd(fs afero.Fs) *cobra.Command {
+	imagesListCmd := &cobra.Command{
+		Use:     "list [<env-name>]",
+		Short:   imagesListShortDesc,
+		Long:    imagesListLong,
+		Example: imagesListExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var envName string
+			if len(args) == 1 {
+				envName = args[0]
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName: envName,
+				actions.OptionOutput:  viper.GetString(vImagesListOutput),
+			}
+			addGlobalOptions(m)
+
+			if err := extractJsonnetFlags(fs, "images-list"); err != nil {
+				return errors.Wrap(err, "handle jsonnet flags")
+			}
+
+			return runAction(actionImagesList, m)
+		},
+	}
+
+	bindJsonnetFlags(imagesListCmd, "images-list")
+	addCmdOutput(imagesListCmd, vImagesListOutput)
+
+	return imagesListCmd
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const (
+	imagesPinShortDesc = "Pin the container images used in an environment to digests"
+)
+
+var (
+	imagesPinLong = `
+The ` + "`pin`" + ` command renders ` + "`<env-name>`" + ` and looks up the digest of every
+container image which is referenced by tag. Each digest is set in the environment's
+` + "`params.libsonnet`" + `, in the component parameters which set the image, so the
+environment keeps using the same images when their tags are moved.
+
+Images which are already pinned are not changed. Images which aren't set by a
+parameter are reported, but can't be pinned. Nothing is written if any digest
+can't be found.
+
+Private registries are authenticated with credentials from the Docker config, in
+the same way as ` + "`ks param set --resolve-image`" + `.
+
+### Related Commands
+
+* ` + "`ks images list` " + `— ` + imagesListShortDesc + `
+* ` + "`ks param set` " + `— ` + paramShortDesc["set"] + `
+
+### Syntax
+`
+	imagesPinExample = `
+# Pin the images used in the 'prod' environment
+ks images pin prod
+`
+)
+
+func newImagesPinCmd(fs afero.Fs) *cobra.Command {
+	imagesPinCmd := &cobra.Command{
+		Use:     "pin [<env-name>]",
+		Short:   imagesPinShortDesc,
+		Long:    imagesPinLong,
+		Example: imagesPinExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var envName string
+			if len(args) == 1 {
+				envName = args[0]
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName: envName,
+			}
+			addGlobalOptions(m)
+
+			if err := extractJsonnetFlags(fs, "images-pin"); err != nil {
+				return errors.Wrap(err, "handle jsonnet flags")
+			}
+
+			return runAction(actionImagesPin, m)
+		},
+	}
+
+	bindJsonnetFlags(imagesPinCmd, "images-pin")
+
+	return imagesPinCmd
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+)
+
+func Test_imagesCmd(t *testing.T) {
+	cases := []cmdTestCase{
+		{
+			name:   "list",
+			args:   []string{"images", "list", "default"},
+			action: actionImagesList,
+			expected: map[string]interface{}{
+				actions.OptionEnvName: "default",
+				actions.OptionOutput:  "",
+			},
+		},
+		{
+			name:   "list as json",
+			args:   []string{"images", "list", "default", "-o", "json"},
+			action: actionImagesList,
+			expected: map[string]interface{}{
+				actions.OptionEnvName: "default",
+				actions.OptionOutput:  "json",
+			},
+		},
+		{
+			name:   "pin",
+			args:   []string{"images", "pin", "default"},
+			action: actionImagesPin,
+			expected: map[string]interface{}{
+				actions.OptionEnvName: "default",
+			},
+		},
+		{
+			name:   "pin current environment",
+			args:   []string{"images", "pin"},
+			action: actionImagesPin,
+			expected: map[string]interface{}{
+				actions.OptionEnvName: "",
+			},
+		},
+		{
+			name:  "no subcommand",
+			args:  []string{"images"},
+			isErr: true,
+		},
+	}
+
+	runTestCmd(t, cases)
+}
@@ -17,21 +17,65 @@ package clicmd
 
 import (
 	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/ksonnet/ksonnet/pkg/client"
 	"github.com/spf13/viper"
 
 	"github.com/spf13/cobra"
 )
 
 const (
-	vImportFilename = "import-filename"
-	vImportModule   = "import-module"
+	vImportAdopt       = "import-adopt"
+	vImportEnv         = "import-env"
+	vImportFilename    = "import-filename"
+	vImportFromCluster = "import-from-cluster"
+	vImportKind        = "import-kind"
+	vImportModule      = "import-module"
+	vImportSelector    = "import-selector"
+	vImportSingle      = "import-single"
+
+	importLong = `
+The ` + "`import`" + ` command creates components from existing manifests. Manifests
+can be imported from a file, a directory, or a URL.
+
+By default, one component is created for each object in a YAML file. With
+` + "`--single`" + `, the objects in each YAML file are kept together in one component
+containing a List, named after the file. Importing a newer version of the file
+updates the component instead, and prints the objects which were added, changed
+or removed, along with a diff of each changed object.
+
+With ` + "`--from-cluster`" + `, objects are imported from a namespace in the cluster of
+an environment instead. Fields populated by the server, such as status, uid and
+resourceVersion, are removed, and objects created by controllers are skipped. One
+component is created for each object. Use ` + "`--adopt`" + ` to label the imported
+objects in the cluster as managed by ksonnet, so the next ` + "`ks apply`" + ` takes
+ownership of them.
+
+### Syntax
+`
+	importExample = `# Import the manifests in 'manifests/' into the 'web' module.
+ks import -f manifests --module web
+
+# Import an upstream install manifest as a single component named 'exporter'.
+# Run again with a newer version of the manifest to update the component.
+ks import -f https://example.com/releases/v1.0.0/exporter.yaml --single
+
+# Import the deployments and services in the 'web' namespace of the 'dev'
+# environment's cluster.
+ks import --from-cluster --env dev --namespace web --kind deployment --kind service
+
+# Import the objects labeled 'app=guestbook' in the current environment's
+# namespace and label them as managed by ksonnet.
+ks import --from-cluster -l app=guestbook --adopt`
 )
 
 func newImportCmd() *cobra.Command {
+	importClientConfig := client.NewDefaultClientConfig()
+
 	importCmd := &cobra.Command{
-		Use:   "import",
-		Short: "Import manifest",
-		Long:  `Import manifest`,
+		Use:     "import [-f <filename>] [--from-cluster]",
+		Short:   "Import manifest",
+		Long:    importLong,
+		Example: importExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			m := map[string]interface{}{
 				actions.OptionPath: viper.GetString(vImportFilename),
@@ -42,14 +86,42 @@ func newImportCmd() *cobra.Command {
 				m[actions.OptionModule] = mod
 			}
 
+			if viper.GetBool(vImportSingle) {
+				m[actions.OptionSingle] = true
+			}
+
+			if viper.GetBool(vImportFromCluster) {
+				m[actions.OptionFromCluster] = true
+				m[actions.OptionClientConfig] = importClientConfig
+				m[actions.OptionEnvName] = viper.GetString(vImportEnv)
+				m[actions.OptionKinds] = viper.GetStringSlice(vImportKind)
+				m[actions.OptionSelector] = viper.GetString(vImportSelector)
+				m[actions.OptionAdopt] = viper.GetBool(vImportAdopt)
+			}
+
 			return runAction(actionImport, m)
 		},
 	}
 
+	importClientConfig.BindClientGoFlags(importCmd)
+
 	importCmd.Flags().StringP(flagFilename, shortFilename, "", "Filename, directory, or URL for component to import")
 	viper.BindPFlag(vImportFilename, importCmd.Flags().Lookup(flagFilename))
 	importCmd.Flags().String(flagModule, "/", "Component module")
 	viper.BindPFlag(vImportModule, importCmd.Flags().Lookup(flagModule))
+	importCmd.Flags().Bool(flagSingle, false, "Import each YAML file as a single List component")
+	viper.BindPFlag(vImportSingle, importCmd.Flags().Lookup(flagSingle))
+
+	importCmd.Flags().Bool(flagFromCluster, false, "Import objects from a cluster namespace")
+	viper.BindPFlag(vImportFromCluster, importCmd.Flags().Lookup(flagFromCluster))
+	importCmd.Flags().String(flagEnv, "", "Environment whose cluster objects are imported from (defaults to the current environment)")
+	viper.BindPFlag(vImportEnv, importCmd.Flags().Lookup(flagEnv))
+	importCmd.Flags().StringSlice(flagKind, nil, "Kind of objects to import from the cluster (multiple --kind flags accepted)")
+	viper.BindPFlag(vImportKind, importCmd.Flags().Lookup(flagKind))
+	importCmd.Flags().StringP(flagSelector, shortSelector, "", "Label selector for objects to import from the cluster")
+	viper.BindPFlag(vImportSelector, importCmd.Flags().Lookup(flagSelector))
+	importCmd.Flags().Bool(flagAdopt, false, "Label the imported cluster objects as managed by ksonnet")
+	viper.BindPFlag(vImportAdopt, importCmd.Flags().Lookup(flagAdopt))
 
 	return importCmd
 }
@@ -43,6 +43,33 @@ func Test_importCmd(t *testing.T) {
 				actions.OptionModule: "module",
 			},
 		},
+		{
+			name:   "import as a single component",
+			args:   []string{"import", "-f", "location", "--single"},
+			action: actionImport,
+			expected: map[string]interface{}{
+				actions.OptionApp:    nil,
+				actions.OptionPath:   "location",
+				actions.OptionModule: "/",
+				actions.OptionSingle: true,
+			},
+		},
+		{
+			name:   "import from cluster",
+			args:   []string{"import", "--from-cluster", "--env", "dev", "--namespace", "web", "--kind", "deployment", "--kind", "service", "-l", "app=web", "--adopt"},
+			action: actionImport,
+			expected: map[string]interface{}{
+				actions.OptionApp:          nil,
+				actions.OptionPath:         "",
+				actions.OptionModule:       "/",
+				actions.OptionFromCluster:  true,
+				actions.OptionClientConfig: nil,
+				actions.OptionEnvName:      "dev",
+				actions.OptionKinds:        []string{"deployment", "service"},
+				actions.OptionSelector:     "app=web",
+				actions.OptionAdopt:        true,
+			},
+		},
 	}
 
 	runTestCmd(t, cases)
@@ -34,6 +34,7 @@ const (
 	vInitAPISpec               = "init-api-spec"
 	vInitSkipDefaultRegistries = "init-skip-default-registries"
 	vInitEnvironment           = "init-environment"
+	vInitTemplate              = "init-template"
 )
 
 var (
@@ -61,6 +62,32 @@ Creating a ksonnet application results in the following directory tree.
 
 To begin populating your ksonnet application, see the docs for` + " `ks generate` " + `.
 
+### Templates
+
+Applications can be created from a template with` + " `--template`" + `. A template is a
+directory containing an` + " `app.yaml` " + `fragment and files to copy into the application.
+The fragment can define registries, environments (with their destinations and
+Kubernetes versions) and libraries, which are configured and installed when the
+application is created. All other files, such as` + " `lib/` " + `files or policies, are
+copied as-is, replacing generated scaffolding.
+
+The` + " `app.yaml` " + `fragment and files ending in` + " `.tmpl` " + `are rendered as Go templates
+before they are used, and the` + " `.tmpl` " + `suffix is removed. The following variables
+are available:
+
+* ` + "`{{ .Name }}`" + ` — the application name
+* ` + "`{{ .Namespace }}`" + ` — the namespace from the current context or` + " `--namespace`" + `
+* ` + "`{{ .Server }}`" + ` — the server from the current context or` + " `--server`" + `
+
+Environments in the fragment without a destination use the server and namespace
+above. If the fragment defines environments, the default environment is not created.
+
+A template can be:
+
+* a local directory
+* a git repository,` + " `git::<url>[//<subdir>][?ref=<branch-or-tag>]`" + `
+* a package in a default registry containing a` + " `template` " + `directory,` + " `<registry>/<package>[@<version>]`" + `
+
 [1] ` + "`ksonnet-lib`" + ` is a Jsonnet helper library that wraps Kubernetes-API-compatible
 types. A specific version of ` + "`ksonnet-lib`" + ` is automatically provided for each
 environment. Users can set flags to generate the library based on a variety of data,
@@ -100,7 +127,10 @@ ks init app-name --api-spec=file:swagger.json
 
 # Initialize a ksonnet application, outputting the application directory into
 # the specified 'custom-location'.
-ks init app-name --dir=custom-location`
+ks init app-name --dir=custom-location
+
+# Initialize a ksonnet application from a template in a git repository.
+ks init app-name --template=git::https://github.com/example/templates.git//web?ref=v1`
 )
 
 func newInitCmd(fs afero.Fs, wd string) *cobra.Command {
@@ -149,6 +179,7 @@ func newInitCmd(fs afero.Fs, wd string) *cobra.Command {
 				actions.OptionServer:                server,
 				actions.OptionNamespace:             namespace,
 				actions.OptionSkipDefaultRegistries: viper.GetBool(vInitSkipDefaultRegistries),
+				actions.OptionTemplate:              viper.GetString(vInitTemplate),
 				actions.OptionSkipCheckUpgrade:      true,
 			}
 			addGlobalOptions(m)
@@ -170,6 +201,9 @@ func newInitCmd(fs afero.Fs, wd string) *cobra.Command {
 	initCmd.Flags().String(flagEnv, "", "Name of initial environment to create")
 	viper.BindPFlag(vInitEnvironment, initCmd.Flag(flagEnv))
 
+	initCmd.Flags().String(flagTemplate, "", "Application template: a directory, git::<url>[//<subdir>][?ref=<ref>], or <registry>/<package>[@<version>]")
+	viper.BindPFlag(vInitTemplate, initCmd.Flag(flagTemplate))
+
 	return initCmd
 }
 
@@ -41,6 +41,7 @@ func Test_initCmd(t *testing.T) {
 				actions.OptionSpecFlag:              "version:v1.8.0",
 				actions.OptionNamespace:             "new-namespace",
 				actions.OptionSkipDefaultRegistries: false,
+				actions.OptionTemplate:              "",
 				actions.OptionTLSSkipVerify:         false,
 				actions.OptionSkipCheckUpgrade:      true,
 			},
@@ -63,6 +64,7 @@ func Test_initCmd(t *testing.T) {
 				actions.OptionSpecFlag:              "version:v1.8.0",
 				actions.OptionNamespace:             "new-namespace",
 				actions.OptionSkipDefaultRegistries: false,
+				actions.OptionTemplate:              "",
 				actions.OptionTLSSkipVerify:         false,
 				actions.OptionSkipCheckUpgrade:      true,
 			},
@@ -86,6 +88,30 @@ func Test_initCmd(t *testing.T) {
 				actions.OptionSpecFlag:              "version:v1.8.0",
 				actions.OptionNamespace:             "new-namespace",
 				actions.OptionSkipDefaultRegistries: false,
+				actions.OptionTemplate:              "",
+				actions.OptionTLSSkipVerify:         false,
+				actions.OptionSkipCheckUpgrade:      true,
+			},
+		},
+		{
+			name: "with template",
+			args: []string{"init", "app",
+				"--namespace", "new-namespace",
+				"--server", "http://127.0.0.1",
+				"--api-spec", "version:v1.8.0",
+				"--template", "incubator/web-template@1.0",
+			},
+			action: actionInit,
+			expected: map[string]interface{}{
+				actions.OptionFs:                    nil,
+				actions.OptionName:                  "app",
+				actions.OptionEnvName:               "",
+				actions.OptionNewRoot:               "/app",
+				actions.OptionServer:                "http://127.0.0.1",
+				actions.OptionSpecFlag:              "version:v1.8.0",
+				actions.OptionNamespace:             "new-namespace",
+				actions.OptionSkipDefaultRegistries: false,
+				actions.OptionTemplate:              "incubator/web-template@1.0",
 				actions.OptionTLSSkipVerify:         false,
 				actions.OptionSkipCheckUpgrade:      true,
 			},
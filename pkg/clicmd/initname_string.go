@@ -19,9 +19,9 @@ package clicmd
 
 import "strconv"
 
-const _initName_name = "actionApplyactionComponentListactionComponentRmactionDeleteactionDiffactionEnvAddactionEnvDescribeactionEnvListactionEnvRmactionEnvSetactionEnvTargetsactionImportactionInitactionModuleCreateactionModuleListactionParamDiffactionParamListactionParamSetactionPkgDescribeactionPkgInstallactionPkgListactionPrototypeDescribeactionPrototypeListactionPrototypePreviewactionPrototypeSearchactionPrototypeUseactionRegistryAddactionRegistryDescribeactionRegistryListactionShowactionUpgradeactionValidate"
+const _initName_name = "actionApplyactionComponentListactionComponentMvactionComponentRmactionDeleteactionDiffactionEnvAddactionEnvCloneactionEnvCurrentactionEnvDescribeactionEnvListactionEnvPromoteactionEnvRmactionEnvSetactionEnvTargetsactionEnvUpdateactionImagesListactionImagesPinactionImportactionInitactionModuleCreateactionModuleListactionParamDeleteactionParamDiffactionParamExplainactionParamExportactionParamImportactionParamListactionParamSetactionParamUnsetactionPkgDescribeactionPkgInstallactionPkgListactionPkgRemoveactionPrototypeDescribeactionPrototypeLintactionPrototypeListactionPrototypePreviewactionPrototypeSearchactionPrototypeTestactionPrototypeUseactionRegistryAddactionRegistryDescribeactionRegistryListactionRegistrySetactionServeactionShowactionSnapshotCheckactionSnapshotUpdateactionTestactionUpgradeactionValidate"
 
-var _initName_index = [...]uint16{0, 11, 30, 47, 59, 69, 81, 98, 111, 122, 134, 150, 162, 172, 190, 206, 221, 236, 250, 267, 283, 296, 319, 338, 360, 381, 399, 416, 438, 456, 466, 479, 493}
+var _initName_index = [...]uint16{0, 11, 30, 47, 64, 76, 86, 98, 112, 128, 145, 158, 174, 185, 197, 213, 228, 244, 259, 271, 281, 299, 315, 332, 347, 365, 382, 399, 414, 428, 444, 461, 477, 490, 505, 528, 547, 566, 588, 609, 628, 646, 663, 685, 703, 720, 731, 741, 760, 780, 790, 803, 817}
 
 func (i initName) String() string {
 	if i < 0 || i >= initName(len(_initName_index)-1) {
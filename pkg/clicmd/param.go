@@ -21,10 +21,13 @@ import (
 
 var (
 	paramShortDesc = map[string]string{
-		"delete": "Delete component or environment parameters",
-		"set":    "Change component or environment parameters (e.g. replica count, name)",
-		"list":   "List known component parameters",
-		"diff":   "Display differences between the component parameters of two environments",
+		"delete":  "Delete component or environment parameters",
+		"set":     "Change component or environment parameters (e.g. replica count, name)",
+		"list":    "List known component parameters",
+		"diff":    "Display differences between the component parameters of two environments",
+		"explain": "Explain where a component parameter's value comes from",
+		"export":  "Export the effective parameters of an environment",
+		"import":  "Set component and global parameters from a file",
 	}
 	paramLong = `
 Parameters are customizable fields that are used inside ksonnet *component*
@@ -53,6 +56,25 @@ Params are structured as follows:
 Note that all of these params are tracked **locally** in version-controllable
 Jsonnet files.
 
+A module can describe its component params with a JSON Schema for each component
+in ` + "`params.schema.libsonnet`" + `, next to its ` + "`params.libsonnet`" + `:
+
+    {
+      components: {
+        guestbook: {
+          type: "object",
+          required: ["image"],
+          properties: {
+            replicas: { type: "integer", minimum: 1 },
+            tier: { enum: ["front", "back"] },
+          },
+        },
+      },
+    }
+
+Params are validated against the schema by ` + "`ks param set`" + `, ` + "`ks param import`" + `,
+and ` + "`ks show`" + `, and every param which doesn't match is reported.
+
 ----
 `
 )
@@ -66,6 +88,9 @@ func newParamCmd() *cobra.Command {
 
 	paramCmd.AddCommand(newParamDeleteCmd())
 	paramCmd.AddCommand(newParamDiffCmd())
+	paramCmd.AddCommand(newParamExplainCmd())
+	paramCmd.AddCommand(newParamExportCmd())
+	paramCmd.AddCommand(newParamImportCmd())
 	paramCmd.AddCommand(newParamListCmd())
 	paramCmd.AddCommand(newParamSetCmd())
 
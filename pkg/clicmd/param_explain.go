@@ -0,0 +1,87 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vParamExplainEnv    = "param-explain-env"
+	vParamExplainOutput = "param-explain-output"
+)
+
+var (
+	paramExplainLong = `
+The ` + "`explain`" + ` command shows where a component parameter's value comes from in
+an environment. It lists every layer that defines the parameter, from lowest to
+highest precedence, with the file and line of each definition:
+
+* component — the component's params in the module's ` + "`params.libsonnet`" + `
+* module globals — the ` + "`global`" + ` params in the module's ` + "`params.libsonnet`" + `
+* environment — ` + "`environments/<env-name>/params.libsonnet`" + `
+* environment globals — ` + "`environments/<env-name>/globals.libsonnet`" + `
+
+The last row is the parameter's evaluated value in the environment.
+
+### Related Commands
+
+* ` + "`ks param list` " + `— ` + paramShortDesc["list"] + `
+* ` + "`ks param set` " + `— ` + paramShortDesc["set"] + `
+
+### Syntax
+`
+	paramExplainExample = `
+# Explain the "replicas" parameter of the "guestbook" component in the "dev"
+# environment
+ks param explain guestbook replicas --env=dev
+
+# Explain a nested parameter
+ks param explain guestbook labels.app --env=dev`
+)
+
+func newParamExplainCmd() *cobra.Command {
+	paramExplainCmd := &cobra.Command{
+		Use:     "explain <component-name> <param-key> --env <env-name>",
+		Short:   paramShortDesc["explain"],
+		Long:    paramExplainLong,
+		Example: paramExplainExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("'param explain' takes exactly two arguments: the component name and the parameter key")
+			}
+
+			m := map[string]interface{}{
+				actions.OptionComponentName: args[0],
+				actions.OptionPath:          args[1],
+				actions.OptionEnvName:       viper.GetString(vParamExplainEnv),
+				actions.OptionOutput:        viper.GetString(vParamExplainOutput),
+			}
+
+			return runAction(actionParamExplain, m)
+		},
+	}
+
+	addCmdOutput(paramExplainCmd, vParamExplainOutput)
+	paramExplainCmd.Flags().String(flagEnv, "", "Environment to explain the parameter in")
+	viper.BindPFlag(vParamExplainEnv, paramExplainCmd.Flags().Lookup(flagEnv))
+
+	return paramExplainCmd
+}
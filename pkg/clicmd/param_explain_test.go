@@ -0,0 +1,58 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+)
+
+func Test_paramExplainCmd(t *testing.T) {
+	cases := []cmdTestCase{
+		{
+			name:   "in general",
+			args:   []string{"param", "explain", "guestbook", "replicas", "--env", "dev"},
+			action: actionParamExplain,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionComponentName: "guestbook",
+				actions.OptionPath:          "replicas",
+				actions.OptionEnvName:       "dev",
+				actions.OptionOutput:        "",
+			},
+		},
+		{
+			name:   "with output",
+			args:   []string{"param", "explain", "guestbook", "labels.app", "--env", "dev", "-o", "json"},
+			action: actionParamExplain,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionComponentName: "guestbook",
+				actions.OptionPath:          "labels.app",
+				actions.OptionEnvName:       "dev",
+				actions.OptionOutput:        "json",
+			},
+		},
+		{
+			name:  "missing param",
+			args:  []string{"param", "explain", "guestbook"},
+			isErr: true,
+		},
+	}
+
+	runTestCmd(t, cases)
+}
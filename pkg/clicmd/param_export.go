@@ -0,0 +1,81 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vParamExportEnv    = "param-export-env"
+	vParamExportOutput = "param-export-output"
+)
+
+var (
+	paramExportLong = `
+The ` + "`export`" + ` command writes the effective parameters of every component in an
+environment, and the environment's global parameters, as YAML or JSON. Component
+values include module globals, environment overrides, and environment globals.
+
+Components in modules other than the root module are named ` + "`<module>.<component>`" + `.
+The output can be edited and applied with ` + "`ks param import`" + `.
+
+### Related Commands
+
+* ` + "`ks param import` " + `— ` + paramShortDesc["import"] + `
+* ` + "`ks param list` " + `— ` + paramShortDesc["list"] + `
+
+### Syntax
+`
+	paramExportExample = `
+# Export the parameters of the "dev" environment as YAML
+ks param export --env=dev
+
+# Export the parameters of the "dev" environment as JSON
+ks param export --env=dev -o json > dev-params.json`
+)
+
+func newParamExportCmd() *cobra.Command {
+	paramExportCmd := &cobra.Command{
+		Use:     "export --env <env-name> [-o yaml|json]",
+		Short:   paramShortDesc["export"],
+		Long:    paramExportLong,
+		Example: paramExportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("'param export' takes no arguments")
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName: viper.GetString(vParamExportEnv),
+				actions.OptionOutput:  viper.GetString(vParamExportOutput),
+			}
+
+			return runAction(actionParamExport, m)
+		},
+	}
+
+	paramExportCmd.Flags().String(flagEnv, "", "Environment to export parameters for")
+	viper.BindPFlag(vParamExportEnv, paramExportCmd.Flags().Lookup(flagEnv))
+	paramExportCmd.Flags().StringP(flagOutput, shortOutput, "yaml", "Output format. Valid options: yaml|json")
+	viper.BindPFlag(vParamExportOutput, paramExportCmd.Flags().Lookup(flagOutput))
+
+	return paramExportCmd
+}
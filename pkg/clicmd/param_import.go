@@ -0,0 +1,90 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vParamImportEnv = "param-import-env"
+)
+
+var (
+	paramImportLong = `
+The ` + "`import`" + ` command sets many parameters at once from a YAML or JSON file with
+the same format as ` + "`ks param export`" + `:
+
+    components:
+      guestbook:
+        replicas: 3
+    globals:
+      tier: front
+
+Without ` + "`--env`" + `, component parameters are set in their module's ` + "`params.libsonnet`" + `
+and globals are set in ` + "`components/params.libsonnet`" + `. With ` + "`--env`" + `, they are
+set in the environment's ` + "`params.libsonnet`" + ` and ` + "`globals.libsonnet`" + `, and only
+parameters whose values differ from the environment's current values are set.
+
+Either every file is updated, or none are. Files without changes are not rewritten.
+If a module has a ` + "`params.schema.libsonnet`" + `, the updated parameters are
+validated against it first, and nothing is written if any of them are invalid.
+
+### Related Commands
+
+* ` + "`ks param export` " + `— ` + paramShortDesc["export"] + `
+* ` + "`ks param set` " + `— ` + paramShortDesc["set"] + `
+
+### Syntax
+`
+	paramImportExample = `
+# Set component parameters from a file
+ks param import params.yaml
+
+# Set parameters for the "dev" environment from a file exported from "prod"
+ks param export --env=prod > prod-params.yaml
+ks param import prod-params.yaml --env=dev`
+)
+
+func newParamImportCmd() *cobra.Command {
+	paramImportCmd := &cobra.Command{
+		Use:     "import <file> [--env <env-name>]",
+		Short:   paramShortDesc["import"],
+		Long:    paramImportLong,
+		Example: paramImportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("'param import' takes exactly one argument: the file to import")
+			}
+
+			m := map[string]interface{}{
+				actions.OptionPath:    args[0],
+				actions.OptionEnvName: viper.GetString(vParamImportEnv),
+			}
+
+			return runAction(actionParamImport, m)
+		},
+	}
+
+	paramImportCmd.Flags().String(flagEnv, "", "Environment to set parameters for")
+	viper.BindPFlag(vParamImportEnv, paramImportCmd.Flags().Lookup(flagEnv))
+
+	return paramImportCmd
+}
@@ -26,12 +26,27 @@ var (
 	vParamSetEnv          = "param-set-env"
 	vParamSetAsString     = "param-set-as-string"
 	vParamSetResolveImage = "param-set-resolve-image"
+	vParamSetRef          = "param-set-ref"
 
 	paramSetLong = `
 The ` + "`set`" + ` command sets component or environment parameters such as replica count
 or name. Parameters are set individually, one at a time. All of these changes are
 reflected in the ` + "`params.libsonnet`" + ` files.
 
+A component parameter can also reference a parameter of another component in the
+same module with ` + "`--ref <component>.<param>`" + `. The referenced component must
+exist. References are resolved when the
+environment is evaluated, after environment overrides are applied, so the referenced
+value is always the effective value for that environment.
+
+If the component's module has a ` + "`params.schema.libsonnet`" + `, the new value is
+validated against the component's schema before it is set.
+
+With ` + "`--resolve-image`" + `, the image tag is replaced with its digest. Credentials for
+private registries are read from the Docker config (` + "`~/.docker/config.json`" + `, or
+` + "`$DOCKER_CONFIG/config.json`" + `), including credential helpers. Use
+` + "`--tls-ca-bundle`" + ` if the registry's certificate is signed by a private authority.
+
 For more details on how parameters are organized, see ` + "`ks param --help`" + `.
 
 *(If you need to customize multiple parameters at once, we suggest that you modify
@@ -52,12 +67,16 @@ ks param set guestbook replicas 4
 
 # Update the replica count of the 'guestbook' component to 2, but only for the
 # 'dev' environment
-ks param set guestbook replicas 2 --env=dev`
+ks param set guestbook replicas 2 --env=dev
+
+# Set the 'db_host' parameter of the 'web' component to the value of the
+# 'service_name' parameter of the 'db' component.
+ks param set web db_host --ref db.service_name`
 )
 
 func newParamSetCmd() *cobra.Command {
 	paramSetCmd := &cobra.Command{
-		Use:     "set <component-name> <param-key> <param-value>",
+		Use:     "set <component-name> <param-key> [<param-value>|--ref <component.param>]",
 		Short:   paramShortDesc["set"],
 		Long:    paramSetLong,
 		Example: paramSetExample,
@@ -66,14 +85,19 @@ func newParamSetCmd() *cobra.Command {
 			var path string
 			var value string
 
-			switch len(args) {
+			ref := viper.GetString(vParamSetRef)
+
+			switch {
 			default:
 				return errors.New("invalid arguments for 'param set'")
-			case 3:
+			case ref != "" && len(args) == 2:
+				name = args[0]
+				path = args[1]
+			case ref == "" && len(args) == 3:
 				name = args[0]
 				path = args[1]
 				value = args[2]
-			case 2:
+			case ref == "" && len(args) == 2:
 				path = args[0]
 				value = args[1]
 			}
@@ -85,7 +109,9 @@ func newParamSetCmd() *cobra.Command {
 				actions.OptionEnvName:      viper.GetString(vParamSetEnv),
 				actions.OptionAsString:     viper.GetBool(vParamSetAsString),
 				actions.OptionResolveImage: viper.GetBool(vParamSetResolveImage),
+				actions.OptionReference:    ref,
 			}
+			addGlobalOptions(m)
 
 			return runAction(actionParamSet, m)
 		},
@@ -100,5 +126,8 @@ func newParamSetCmd() *cobra.Command {
 	paramSetCmd.Flags().Bool(flagResolveImage, false, "Resolve Docker image tag to reference")
 	viper.BindPFlag(vParamSetResolveImage, paramSetCmd.Flags().Lookup(flagResolveImage))
 
+	paramSetCmd.Flags().String(flagRef, "", "Reference another component's parameter (component.param)")
+	viper.BindPFlag(vParamSetRef, paramSetCmd.Flags().Lookup(flagRef))
+
 	return paramSetCmd
 }
@@ -35,6 +35,7 @@ func Test_paramSetCmd(t *testing.T) {
 				actions.OptionEnvName:      "",
 				actions.OptionAsString:     false,
 				actions.OptionResolveImage: false,
+				actions.OptionReference:    "",
 			},
 		},
 		{
@@ -49,6 +50,7 @@ func Test_paramSetCmd(t *testing.T) {
 				actions.OptionEnvName:      "",
 				actions.OptionAsString:     false,
 				actions.OptionResolveImage: true,
+				actions.OptionReference:    "",
 			},
 		},
 
@@ -64,6 +66,22 @@ func Test_paramSetCmd(t *testing.T) {
 				actions.OptionEnvName:      "default",
 				actions.OptionAsString:     false,
 				actions.OptionResolveImage: false,
+				actions.OptionReference:    "",
+			},
+		},
+		{
+			name:   "reference",
+			args:   []string{"param", "set", "component-name", "param-name", "--ref", "other.param"},
+			action: actionParamSet,
+			expected: map[string]interface{}{
+				actions.OptionApp:          nil,
+				actions.OptionName:         "component-name",
+				actions.OptionPath:         "param-name",
+				actions.OptionValue:        "",
+				actions.OptionEnvName:      "",
+				actions.OptionAsString:     false,
+				actions.OptionResolveImage: false,
+				actions.OptionReference:    "other.param",
 			},
 		},
 		{
@@ -84,6 +102,7 @@ func Test_paramSetCmd(t *testing.T) {
 				actions.OptionEnvName:      "",
 				actions.OptionAsString:     true,
 				actions.OptionResolveImage: false,
+				actions.OptionReference:    "",
 			},
 		},
 	}
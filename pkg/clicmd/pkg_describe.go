@@ -20,6 +20,11 @@ import (
 
 	"github.com/ksonnet/ksonnet/pkg/actions"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vPkgDescribeOutput = "pkg-describe-output"
 )
 
 var (
@@ -32,6 +37,9 @@ known ` + "`<registry-name>`" + ` like *incubator*). The output includes:
 2. A brief description provided by the package authors
 3. A list of available prototypes provided by the package
 
+Use ` + "`--output`" + ` to print the description as JSON or YAML, or to select
+fields with a Go template or JSONPath expression.
+
 ### Related Commands
 
 * ` + "`ks pkg list` " + `— ` + pkgShortDesc["list"] + `
@@ -53,6 +61,7 @@ func newPkgDescribeCmd() *cobra.Command {
 			}
 
 			m := map[string]interface{}{
+				actions.OptionOutput:      viper.GetString(vPkgDescribeOutput),
 				actions.OptionPackageName: args[0],
 			}
 			addGlobalOptions(m)
@@ -61,5 +70,7 @@ func newPkgDescribeCmd() *cobra.Command {
 		},
 	}
 
+	addCmdOutput(pkgDescribeCmd, vPkgDescribeOutput)
+
 	return pkgDescribeCmd
 }
@@ -29,6 +29,18 @@ func Test_pkgDescribeCmd(t *testing.T) {
 			action: actionPkgDescribe,
 			expected: map[string]interface{}{
 				actions.OptionApp:           nil,
+				actions.OptionOutput:        "",
+				actions.OptionPackageName:   "package-name",
+				actions.OptionTLSSkipVerify: false,
+			},
+		},
+		{
+			name:   "with output",
+			args:   []string{"pkg", "describe", "package-name", "-o", "yaml"},
+			action: actionPkgDescribe,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionOutput:        "yaml",
 				actions.OptionPackageName:   "package-name",
 				actions.OptionTLSSkipVerify: false,
 			},
@@ -22,10 +22,12 @@ import (
 
 var (
 	protoShortDesc = map[string]string{
+		"lint":     "Check prototypes for problems",
 		"list":     "List all locally available ksonnet prototypes",
 		"describe": "See more info about a prototype's output and usage",
 		"preview":  "Preview a prototype's output without creating a component (stdout)",
 		"search":   "Search for a prototype",
+		"test":     "Render a prototype with values files and validate the output",
 		"use":      "Use the specified prototype to generate a component manifest",
 	}
 	protoLong = `
@@ -54,9 +56,11 @@ func newPrototypeCmd(fs afero.Fs) *cobra.Command {
 	}
 
 	prototypeCmd.AddCommand(newPrototypeDescribeCmd())
+	prototypeCmd.AddCommand(newPrototypeLintCmd())
 	prototypeCmd.AddCommand(newPrototypeListCmd())
 	prototypeCmd.AddCommand(newPrototypePreviewCmd())
 	prototypeCmd.AddCommand(newPrototypeSearchCmd())
+	prototypeCmd.AddCommand(newPrototypeTestCmd())
 	prototypeCmd.AddCommand(newPrototypeUseCmd(fs))
 
 	return prototypeCmd
@@ -20,6 +20,11 @@ import (
 
 	"github.com/ksonnet/ksonnet/pkg/actions"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vPrototypeDescribeOutput = "prototype-describe-output"
 )
 
 var (
@@ -32,6 +37,9 @@ the specified prototype (identified by name). Specifically, this describes:
      to customize the component
   3. The file format of the generated component manifest (currently, Jsonnet only)
 
+Use ` + "`--output`" + ` to print the description as JSON or YAML, or to select
+fields with a Go template or JSONPath expression.
+
 ### Related Commands
 
 * ` + "`ks prototype preview` " + `— ` + protoShortDesc["preview"] + `
@@ -41,7 +49,10 @@ the specified prototype (identified by name). Specifically, this describes:
 `
 	prototypeDescribeExample = `
 # Display documentation about the prototype 'io.ksonnet.pkg.single-port-deployment'
-ks prototype describe deployment`
+ks prototype describe deployment
+
+# List the names of the prototype's required parameters
+ks prototype describe deployment -o 'jsonpath={.data.requiredParams[*].name}'`
 )
 
 func newPrototypeDescribeCmd() *cobra.Command {
@@ -56,7 +67,8 @@ func newPrototypeDescribeCmd() *cobra.Command {
 			}
 
 			m := map[string]interface{}{
-				actions.OptionQuery: args[0],
+				actions.OptionOutput: viper.GetString(vPrototypeDescribeOutput),
+				actions.OptionQuery:  args[0],
 			}
 			addGlobalOptions(m)
 
@@ -64,5 +76,7 @@ func newPrototypeDescribeCmd() *cobra.Command {
 		},
 	}
 
+	addCmdOutput(prototypeDescribeCmd, vPrototypeDescribeOutput)
+
 	return prototypeDescribeCmd
 }
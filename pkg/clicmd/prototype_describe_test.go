@@ -29,6 +29,7 @@ func Test_prototypeDescribeCmd(t *testing.T) {
 			action: actionPrototypeDescribe,
 			expected: map[string]interface{}{
 				actions.OptionApp:           nil,
+				actions.OptionOutput:        "",
 				actions.OptionQuery:         "name",
 				actions.OptionTLSSkipVerify: false,
 			},
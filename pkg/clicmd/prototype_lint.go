@@ -0,0 +1,98 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vPrototypeLintEnv    = "prototype-lint-env"
+	vPrototypeLintOutput = "prototype-lint-output"
+)
+
+var (
+	prototypeLintLong = `
+The ` + "`prototype lint`" + ` command checks prototypes for problems. It checks that:
+
+* The prototype's directives can be parsed, and it has a name, an API version and
+  descriptions
+* Each parameter has a valid type, and its default is a value of that type once quoted
+* Parameters don't conflict with the flags of ` + "`ks prototype use`" + `
+* Templates only reference declared parameters
+* Each template type renders
+
+Jsonnet templates are evaluated with the libraries of an environment, which is the
+current environment unless ` + "`--env`" + ` is given. If no environment is set, Jsonnet
+templates are only parsed.
+
+The target is a prototype file, a directory of prototypes, or an installed package. Lint
+fails if any errors are found. Warnings are reported, but don't cause lint to fail.
+
+### Related Commands
+
+* ` + "`ks prototype test` " + `— ` + protoShortDesc["test"] + `
+* ` + "`ks prototype use` " + `— ` + protoShortDesc["use"] + `
+
+### Syntax
+`
+	prototypeLintExample = `
+# Lint a prototype.
+ks prototype lint lib/redis.jsonnet
+
+# Lint the prototypes in a package being developed.
+ks prototype lint ../registry/redis
+
+# Lint the prototypes in the installed 'incubator/redis' package.
+ks prototype lint incubator/redis
+
+# Lint a prototype, evaluating its Jsonnet template with the 'prod' environment's libraries.
+ks prototype lint lib/redis.jsonnet --env prod`
+)
+
+func newPrototypeLintCmd() *cobra.Command {
+	prototypeLintCmd := &cobra.Command{
+		Use:     "lint <file|directory|package> [--env <env>]",
+		Short:   protoShortDesc["lint"],
+		Long:    prototypeLintLong,
+		Example: prototypeLintExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Command 'prototype lint' requires a file, directory or package\n\n%s", cmd.UsageString())
+			}
+
+			m := map[string]interface{}{
+				actions.OptionPath:    args[0],
+				actions.OptionEnvName: viper.GetString(vPrototypeLintEnv),
+				actions.OptionOutput:  viper.GetString(vPrototypeLintOutput),
+			}
+			addGlobalOptions(m)
+
+			return runAction(actionPrototypeLint, m)
+		},
+	}
+
+	addCmdOutput(prototypeLintCmd, vPrototypeLintOutput)
+
+	prototypeLintCmd.Flags().String(flagEnv, "", "Environment whose libraries Jsonnet templates are evaluated with (defaults to the current environment)")
+	viper.BindPFlag(vPrototypeLintEnv, prototypeLintCmd.Flags().Lookup(flagEnv))
+
+	return prototypeLintCmd
+}
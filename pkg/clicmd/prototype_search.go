@@ -17,6 +17,7 @@ package clicmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ksonnet/ksonnet/pkg/actions"
 	"github.com/spf13/cobra"
@@ -24,13 +25,21 @@ import (
 )
 
 const (
-	vPrototypeSearchOutput = "prototype-search-output"
+	vPrototypeSearchOutput   = "prototype-search-output"
+	vPrototypeSearchPackage  = "prototype-search-package"
+	vPrototypeSearchRegistry = "prototype-search-registry"
+	vPrototypeSearchType     = "prototype-search-type"
 )
 
 var (
 	prototypeSearchLong = `
-The ` + "`prototype search`" + ` command allows you to search for specific prototypes by name.
-Specifically, it matches any prototypes with names that contain the string <name-substring>.
+The ` + "`prototype search`" + ` command allows you to search for prototypes. The query
+is matched against prototype names, descriptions and parameter names, and the keywords
+of the packages which contain the prototypes. Results are ranked, with the best match
+listed first. Matches in a prototype's name rank higher than matches in its description.
+
+Results can be limited to a registry, a package, or prototypes which support a template
+type.
 
 ### Related Commands
 
@@ -40,24 +49,33 @@ Specifically, it matches any prototypes with names that contain the string <name
 ### Syntax
 `
 	prototypeSearchExample = `
-# Search for prototypes with names that contain the string 'service'.
-ks prototype search service`
+# Search for prototypes related to services.
+ks prototype search service
+
+# Search for a redis prototype which uses persistent storage.
+ks prototype search redis with persistence
+
+# Search for prototypes in the 'incubator' registry which can be rendered as YAML.
+ks prototype search deployment --registry incubator --type yaml`
 )
 
 func newPrototypeSearchCmd() *cobra.Command {
 	prototypeSearchCmd := &cobra.Command{
-		Use:     "search <name-substring>",
+		Use:     "search <query> [--registry <name>] [--package <name>] [--type <type>]",
 		Short:   protoShortDesc["search"],
 		Long:    prototypeSearchLong,
 		Example: prototypeSearchExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 1 {
-				return fmt.Errorf("Command 'prototype search' requires a prototype name\n\n%s", cmd.UsageString())
+			if len(args) == 0 {
+				return fmt.Errorf("Command 'prototype search' requires a query\n\n%s", cmd.UsageString())
 			}
 
 			m := map[string]interface{}{
-				actions.OptionQuery:  args[0],
-				actions.OptionOutput: viper.GetString(vPrototypeSearchOutput),
+				actions.OptionQuery:        strings.Join(args, " "),
+				actions.OptionOutput:       viper.GetString(vPrototypeSearchOutput),
+				actions.OptionRegistry:     viper.GetString(vPrototypeSearchRegistry),
+				actions.OptionPackageName:  viper.GetString(vPrototypeSearchPackage),
+				actions.OptionTemplateType: viper.GetString(vPrototypeSearchType),
 			}
 			addGlobalOptions(m)
 
@@ -66,5 +84,13 @@ func newPrototypeSearchCmd() *cobra.Command {
 	}
 
 	addCmdOutput(prototypeSearchCmd, vPrototypeSearchOutput)
+
+	prototypeSearchCmd.Flags().String(flagRegistry, "", "Only show prototypes from this registry")
+	viper.BindPFlag(vPrototypeSearchRegistry, prototypeSearchCmd.Flags().Lookup(flagRegistry))
+	prototypeSearchCmd.Flags().String(flagPackage, "", "Only show prototypes from this package")
+	viper.BindPFlag(vPrototypeSearchPackage, prototypeSearchCmd.Flags().Lookup(flagPackage))
+	prototypeSearchCmd.Flags().String(flagType, "", "Only show prototypes with a template of this type. Valid options: yaml|json|jsonnet")
+	viper.BindPFlag(vPrototypeSearchType, prototypeSearchCmd.Flags().Lookup(flagType))
+
 	return prototypeSearchCmd
 }
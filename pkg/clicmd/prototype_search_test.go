@@ -31,6 +31,9 @@ func Test_prototypeSearchCmd(t *testing.T) {
 				actions.OptionApp:           nil,
 				actions.OptionQuery:         "name",
 				actions.OptionOutput:        "",
+				actions.OptionRegistry:      "",
+				actions.OptionPackageName:   "",
+				actions.OptionTemplateType:  "",
 				actions.OptionTLSSkipVerify: false,
 			},
 		},
@@ -42,6 +45,23 @@ func Test_prototypeSearchCmd(t *testing.T) {
 				actions.OptionApp:           nil,
 				actions.OptionQuery:         "name",
 				actions.OptionOutput:        "json",
+				actions.OptionRegistry:      "",
+				actions.OptionPackageName:   "",
+				actions.OptionTemplateType:  "",
+				actions.OptionTLSSkipVerify: false,
+			},
+		},
+		{
+			name:   "query with filters",
+			args:   []string{"prototype", "search", "redis", "persistence", "--registry", "incubator", "--package", "redis", "--type", "yaml"},
+			action: actionPrototypeSearch,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionQuery:         "redis persistence",
+				actions.OptionOutput:        "",
+				actions.OptionRegistry:      "incubator",
+				actions.OptionPackageName:   "redis",
+				actions.OptionTemplateType:  "yaml",
 				actions.OptionTLSSkipVerify: false,
 			},
 		},
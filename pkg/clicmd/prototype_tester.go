@@ -0,0 +1,90 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vPrototypeTestEnv        = "prototype-test-env"
+	vPrototypeTestOutput     = "prototype-test-output"
+	vPrototypeTestValuesFile = "prototype-test-values-file"
+)
+
+var (
+	prototypeTestLong = `
+The ` + "`prototype test`" + ` command renders each of a prototype's templates with
+sample values, and validates the rendered objects against the Kubernetes schema of an
+environment. Validation is offline, so the environment's cluster doesn't need to be
+available.
+
+Values are read from values files, which are Jsonnet files returning an object with
+a field for each parameter. Values which aren't set use the parameter's default. If
+no values files are given, the templates are rendered with the defaults, so prototypes
+with required parameters need a values file.
+
+### Related Commands
+
+* ` + "`ks prototype lint` " + `— ` + protoShortDesc["lint"] + `
+* ` + "`ks prototype preview` " + `— ` + protoShortDesc["preview"] + `
+
+### Syntax
+`
+	prototypeTestExample = `
+# Test a prototype with the defaults of its parameters.
+ks prototype test lib/redis.jsonnet
+
+# Test a prototype with two sets of values against the 'prod' environment's schema.
+ks prototype test lib/redis.jsonnet --values-file small.jsonnet --values-file large.jsonnet --env prod`
+)
+
+func newPrototypeTestCmd() *cobra.Command {
+	prototypeTestCmd := &cobra.Command{
+		Use:     "test <file> [--values-file <file>] [--env <env>]",
+		Short:   protoShortDesc["test"],
+		Long:    prototypeTestLong,
+		Example: prototypeTestExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Command 'prototype test' requires a prototype file\n\n%s", cmd.UsageString())
+			}
+
+			m := map[string]interface{}{
+				actions.OptionPath:        args[0],
+				actions.OptionEnvName:     viper.GetString(vPrototypeTestEnv),
+				actions.OptionValuesFiles: viper.GetStringSlice(vPrototypeTestValuesFile),
+				actions.OptionOutput:      viper.GetString(vPrototypeTestOutput),
+			}
+			addGlobalOptions(m)
+
+			return runAction(actionPrototypeTest, m)
+		},
+	}
+
+	addCmdOutput(prototypeTestCmd, vPrototypeTestOutput)
+
+	prototypeTestCmd.Flags().StringSlice(flagValuesFile, nil, "Values file to render the prototype with. May be given multiple times")
+	viper.BindPFlag(vPrototypeTestValuesFile, prototypeTestCmd.Flags().Lookup(flagValuesFile))
+	prototypeTestCmd.Flags().String(flagEnv, "", "Environment whose schema the output is validated against (defaults to the current environment)")
+	viper.BindPFlag(vPrototypeTestEnv, prototypeTestCmd.Flags().Lookup(flagEnv))
+
+	return prototypeTestCmd
+}
@@ -54,6 +54,12 @@ expand prototypes into Jsonnet files.
 command line flags, such as ` + " `--image` " + `in the example above. Note that
 different prototypes support their own unique flags.
 
+4. With ` + "`--interactive`" + `, ksonnet prompts for each parameter which wasn't set on
+the command line, showing its type and description. Values are checked against the
+parameter's type as they are entered, and the component is previewed before it is
+created. If stdin isn't a terminal, ksonnet doesn't prompt and the command runs as
+usual.
+
 ### Related Commands
 
 * ` + "`ks show` " + `— ` + showShortDesc + `
@@ -84,6 +90,10 @@ ks prototype use deployment nginx-depl \
 # 'nginx' image with values from 'ks-value'.
 ks prototype use single-port-deployment nginx-depl \
   --values-file=ks-value
+
+# Instantiate prototype 'io.ksonnet.pkg.single-port-deployment', prompting for
+# its parameters and previewing the component before it is created.
+ks prototype use single-port-deployment nginx-depl --interactive
 `
 )
 
@@ -119,9 +129,17 @@ func newPrototypeUseCmd(fs afero.Fs) *cobra.Command {
 
 			args = removePersistentFlags(cmd.Flags(), args)
 
+			// The flag is read from the command rather than viper, because
+			// `generate` and `prototype use` each define it.
+			interactive, err := cmd.Flags().GetBool(flagInteractive)
+			if err != nil {
+				return err
+			}
+
 			m := map[string]interface{}{
-				actions.OptionFs:        fs,
-				actions.OptionArguments: args,
+				actions.OptionFs:          fs,
+				actions.OptionArguments:   args,
+				actions.OptionInteractive: interactive,
 			}
 			addGlobalOptions(m)
 
@@ -129,6 +147,8 @@ func newPrototypeUseCmd(fs afero.Fs) *cobra.Command {
 		},
 	}
 
+	prototypeUseCmd.Flags().Bool(flagInteractive, false, "Prompt for parameters which aren't set, and preview the component before creating it")
+
 	return prototypeUseCmd
 }
 
@@ -30,6 +30,7 @@ func Test_prototypeUseCmd(t *testing.T) {
 			expected: map[string]interface{}{
 				actions.OptionApp:           nil,
 				actions.OptionArguments:     []string{"name", "--containerPort", "8080"},
+				actions.OptionInteractive:   false,
 				actions.OptionTLSSkipVerify: false,
 			},
 		},
@@ -40,6 +41,29 @@ func Test_prototypeUseCmd(t *testing.T) {
 			expected: map[string]interface{}{
 				actions.OptionApp:           nil,
 				actions.OptionArguments:     []string{"name", "--containerPort", "8080"},
+				actions.OptionInteractive:   false,
+				actions.OptionTLSSkipVerify: false,
+			},
+		},
+		{
+			name:   "interactive",
+			args:   []string{"prototype", "use", "deployment", "nginx", "--interactive", "--image", "nginx"},
+			action: actionPrototypeUse,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionArguments:     []string{"deployment", "nginx", "--image", "nginx"},
+				actions.OptionInteractive:   true,
+				actions.OptionTLSSkipVerify: false,
+			},
+		},
+		{
+			name:   "interactive using generate alias",
+			args:   []string{"generate", "deployment", "nginx", "--interactive", "--image", "nginx"},
+			action: actionPrototypeUse,
+			expected: map[string]interface{}{
+				actions.OptionApp:           nil,
+				actions.OptionArguments:     []string{"deployment", "nginx", "--image", "nginx"},
+				actions.OptionInteractive:   true,
 				actions.OptionTLSSkipVerify: false,
 			},
 		},
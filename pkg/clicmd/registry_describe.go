@@ -20,6 +20,11 @@ import (
 
 	"github.com/ksonnet/ksonnet/pkg/actions"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vRegistryDescribeOutput = "registry-describe-output"
 )
 
 var (
@@ -31,6 +36,9 @@ by ` + "`<registry-name>`" + `. Specifically, it displays the following:
 2. Protocol (e.g. ` + "`github`" + `)
 3. List of packages included in the registry
 
+Use ` + "`--output`" + ` to print the description as JSON or YAML, or to select
+fields with a Go template or JSONPath expression.
+
 ### Related Commands
 
 * ` + "`ks pkg install` " + `— ` + pkgShortDesc["install"] + `
@@ -50,7 +58,8 @@ func newRegistryDescribeCmd() *cobra.Command {
 			}
 
 			m := map[string]interface{}{
-				actions.OptionName: args[0],
+				actions.OptionName:   args[0],
+				actions.OptionOutput: viper.GetString(vRegistryDescribeOutput),
 			}
 			addGlobalOptions(m)
 
@@ -58,5 +67,7 @@ func newRegistryDescribeCmd() *cobra.Command {
 		},
 	}
 
+	addCmdOutput(registryDescribeCmd, vRegistryDescribeOutput)
+
 	return registryDescribeCmd
 }
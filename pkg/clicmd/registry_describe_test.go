@@ -29,6 +29,7 @@ func Test_envRegistryDescribe(t *testing.T) {
 			action: actionRegistryDescribe,
 			expected: map[string]interface{}{
 				actions.OptionApp:           nil,
+				actions.OptionOutput:        "",
 				actions.OptionName:          "name",
 				actions.OptionTLSSkipVerify: false,
 			},
@@ -130,8 +130,10 @@ func NewRoot(appFs afero.Fs, wd string, args []string) (*cobra.Command, error) {
 	rootCmd.PersistentFlags().CountP(flagVerbose, "v", "Increase verbosity. May be given multiple times.")
 	rootCmd.PersistentFlags().Set("logtostderr", "true")
 	rootCmd.PersistentFlags().Bool(flagTLSSkipVerify, false, "Skip verification of TLS server certificates")
+	rootCmd.PersistentFlags().String(flagTLSCABundle, "", "PEM file with additional certificate authorities to trust")
 	rootCmd.PersistentFlags().String(flagDir, wd, "Ksonnet application root to use; Defaults to CWD")
 	viper.BindPFlag(flagTLSSkipVerify, rootCmd.PersistentFlags().Lookup(flagTLSSkipVerify))
+	viper.BindPFlag(flagTLSCABundle, rootCmd.PersistentFlags().Lookup(flagTLSCABundle))
 	viper.BindPFlag(flagDir, rootCmd.PersistentFlags().Lookup(flagDir))
 
 	rootCmd.AddCommand(newApplyCmd(appFs))
@@ -140,6 +142,7 @@ func NewRoot(appFs afero.Fs, wd string, args []string) (*cobra.Command, error) {
 	rootCmd.AddCommand(newDiffCmd(appFs))
 	rootCmd.AddCommand(newEnvCmd())
 	rootCmd.AddCommand(newGenerateCmd(appFs))
+	rootCmd.AddCommand(newImagesCmd(appFs))
 	rootCmd.AddCommand(newImportCmd())
 	rootCmd.AddCommand(newInitCmd(appFs, wd))
 	rootCmd.AddCommand(newModuleCmd())
@@ -147,7 +150,10 @@ func NewRoot(appFs afero.Fs, wd string, args []string) (*cobra.Command, error) {
 	rootCmd.AddCommand(newPkgCmd())
 	rootCmd.AddCommand(newPrototypeCmd(appFs))
 	rootCmd.AddCommand(newRegistryCmd())
+	rootCmd.AddCommand(newServeCmd(appFs))
 	rootCmd.AddCommand(newShowCmd(appFs))
+	rootCmd.AddCommand(newSnapshotCmd(appFs))
+	rootCmd.AddCommand(newTestCmd(appFs))
 	rootCmd.AddCommand(newValidateCmd(appFs))
 	rootCmd.AddCommand(newUpgradeCmd())
 	rootCmd.AddCommand(newVersionCmd())
@@ -0,0 +1,103 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	serveShortDesc = "Serve rendered manifests and parameters over HTTP"
+	vServeAddress  = "serve-address"
+)
+
+var (
+	serveLong = `
+The ` + "`serve`" + ` command serves the application over HTTP, so other tools can render
+it without running ` + "`ks`" + ` for every request. Responses are JSON.
+
+* ` + "`GET /v1/environments`" + ` lists environments.
+* ` + "`GET /v1/environments/<env-name>/objects`" + ` renders an environment, like ` + "`ks show`" + `.
+* ` + "`GET /v1/environments/<env-name>/params`" + ` lists an environment's parameters.
+* ` + "`GET /v1/environments/<env-name>/validate`" + ` validates an environment's objects
+  against the OpenAPI schema of its Kubernetes version.
+* ` + "`GET /v1/components`" + ` lists components.
+* ` + "`GET /v1/params?module=<module>`" + ` lists a module's parameters.
+* ` + "`GET /v1/diff?from=<env-name>&to=<env-name>`" + ` compares two environments, and
+  returns the differences as text.
+* ` + "`GET /healthz`" + ` reports the server is running.
+
+The ` + "`objects`" + `, ` + "`params`" + `, ` + "`validate`" + ` and ` + "`diff`" + ` endpoints accept one or
+more ` + "`component`" + ` query parameters to limit the response to those components.
+
+Rendered modules are cached in memory until their sources change, an
+environment's packages are copied once until their versions change, and OpenAPI
+schemas are loaded once, so repeated requests are fast. Changes to ` + "`app.yaml`" + `
+are picked up by the next request. The Jsonnet flags are applied to every
+request.
+
+Unlike ` + "`ks validate`" + `, the ` + "`validate`" + ` endpoint does not contact a cluster.
+
+### Related Commands
+
+* ` + "`ks show` " + `— ` + showShortDesc + `
+* ` + "`ks validate` " + `— ` + valShortDesc + `
+
+### Syntax
+`
+	serveExample = `
+# Serve the application on the default address, 127.0.0.1:8080
+ks serve
+
+# Serve the application on all interfaces, port 9000
+ks serve --address :9000
+
+# Render the 'dev' environment's 'redis' component
+curl 'http://127.0.0.1:8080/v1/environments/dev/objects?component=redis'
+`
+)
+
+func newServeCmd(fs afero.Fs) *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:     "serve [--address <address>]",
+		Short:   serveShortDesc,
+		Long:    serveLong,
+		Example: serveExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m := map[string]interface{}{
+				actions.OptionAddress: viper.GetString(vServeAddress),
+			}
+
+			if err := extractJsonnetFlags(fs, "serve"); err != nil {
+				return errors.Wrap(err, "handle jsonnet flags")
+			}
+
+			return runAction(actionServe, m)
+		},
+	}
+
+	bindJsonnetFlags(serveCmd, "serve")
+
+	serveCmd.Flags().String(flagAddress, actions.DefaultServeAddress, "Address to listen on")
+	viper.BindPFlag(vServeAddress, serveCmd.Flags().Lookup(flagAddress))
+
+	return serveCmd
+}
@@ -26,7 +26,9 @@ import (
 const (
 	showShortDesc  = "Show expanded manifests for a specific environment."
 	vShowComponent = "show-components"
+	vShowExplain   = "show-explain"
 	vShowFormat    = "show-format"
+	vShowNoCache   = "show-no-cache"
 )
 
 var (
@@ -41,9 +43,21 @@ the files in the ` + "`components/`" + ` directory into a list of resource defin
 This is the YAML version of what gets deployed to your cluster with
 ` + "`ks apply <env-name>`" + `.
 
+Component parameters are validated against their module's
+` + "`params.schema.libsonnet`" + `, if it has one, and every invalid parameter is reported.
+
 When a component IS specified via the ` + "`-c`" + ` flag, this command only expands the
 manifest for that particular component.
 
+Rendered modules are cached in ` + "`.ksonnet/cache`" + `. A module is only re-evaluated
+when its components, params, environment, libraries, vendored packages or Jsonnet
+flags change. Use ` + "`--no-cache`" + ` to render every module from scratch.
+
+Use ` + "`--explain`" + ` to annotate each object with where it came from. The
+` + "`ksonnet.io/provenance`" + ` annotation lists the object's module, component source
+file, params file, environment params file, and the parameters which the
+environment params file overrides.
+
 ### Related Commands
 
 * ` + "`ks validate` " + `— ` + valShortDesc + `
@@ -61,6 +75,9 @@ ks show prod -c redis -o json
 
 # Show multiple components from the 'dev' environment, in YAML
 ks show dev -c redis -c nginx-server
+
+# Show the 'redis' component, and where its objects and parameters come from
+ks show dev -c redis --explain
 `
 )
 
@@ -79,7 +96,9 @@ func newShowCmd(fs afero.Fs) *cobra.Command {
 			m := map[string]interface{}{
 				actions.OptionComponentNames: viper.GetStringSlice(vShowComponent),
 				actions.OptionEnvName:        envName,
+				actions.OptionExplain:        viper.GetBool(vShowExplain),
 				actions.OptionFormat:         viper.GetString(vShowFormat),
+				actions.OptionNoCache:        viper.GetBool(vShowNoCache),
 			}
 
 			if err := extractJsonnetFlags(fs, "show"); err != nil {
@@ -97,5 +116,11 @@ func newShowCmd(fs afero.Fs) *cobra.Command {
 	showCmd.Flags().StringP(flagFormat, shortFormat, "yaml", "Output format.  Supported values are: json, yaml")
 	viper.BindPFlag(vShowFormat, showCmd.Flags().Lookup(flagFormat))
 
+	showCmd.Flags().Bool(flagNoCache, false, "Render all components without using the render cache")
+	viper.BindPFlag(vShowNoCache, showCmd.Flags().Lookup(flagNoCache))
+
+	showCmd.Flags().Bool(flagExplain, false, "Annotate objects with the files they were rendered from")
+	viper.BindPFlag(vShowExplain, showCmd.Flags().Lookup(flagExplain))
+
 	return showCmd
 }
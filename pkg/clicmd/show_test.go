@@ -31,7 +31,22 @@ func Test_showCmd(t *testing.T) {
 				actions.OptionApp:            nil,
 				actions.OptionEnvName:        "default",
 				actions.OptionComponentNames: make([]string, 0),
+				actions.OptionExplain:        false,
 				actions.OptionFormat:         "yaml",
+				actions.OptionNoCache:        false,
+			},
+		},
+		{
+			name:   "with explain",
+			args:   []string{"show", "default", "--explain"},
+			action: actionShow,
+			expected: map[string]interface{}{
+				actions.OptionApp:            nil,
+				actions.OptionEnvName:        "default",
+				actions.OptionComponentNames: make([]string, 0),
+				actions.OptionExplain:        true,
+				actions.OptionFormat:         "yaml",
+				actions.OptionNoCache:        false,
 			},
 		},
 		{
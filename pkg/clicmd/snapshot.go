@@ -0,0 +1,61 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var snapshotLong = `
+The ` + "`snapshot`" + ` subcommands manage golden file snapshots of the manifests
+rendered for an environment. A snapshot is checked in alongside the application,
+so unintended manifest changes show up in code review and can be caught in CI.
+
+Snapshots are stored in ` + "`snapshots/<env-name>`" + `, with one YAML file per
+component. Each file contains the component's objects in the same format and
+order as ` + "`ks show`" + `.
+
+### Related Commands
+
+* ` + "`ks snapshot update` " + `— ` + snapshotUpdateShortDesc + `
+* ` + "`ks snapshot check` " + `— ` + snapshotCheckShortDesc + `
+* ` + "`ks show` " + `— ` + showShortDesc + `
+
+### Syntax
+`
+
+func newSnapshotCmd(fs afero.Fs) *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage snapshots of rendered environments",
+		Long:  snapshotLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("%s is not a valid subcommand\n\n%s", strings.Join(args, " "), cmd.UsageString())
+			}
+			return fmt.Errorf("Command 'snapshot' requires a subcommand\n\n%s", cmd.UsageString())
+		},
+	}
+
+	snapshotCmd.AddCommand(newSnapshotUpdateCmd(fs))
+	snapshotCmd.AddCommand(newSnapshotCheckCmd(fs))
+
+	return snapshotCmd
+}
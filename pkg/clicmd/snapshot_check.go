@@ -0,0 +1,85 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	snapshotCheckShortDesc = "Compare an environment to its snapshot"
+	vSnapshotCheckDir      = "snapshot-check-dir"
+)
+
+var (
+	snapshotCheckLong = `
+The ` + "`check`" + ` command renders ` + "`<env-name>`" + ` and compares it to its snapshot.
+Objects are matched by API version, kind, namespace and name. If the rendered
+objects differ from the snapshot, the differences are printed and the command
+fails.
+
+### Related Commands
+
+* ` + "`ks snapshot update` " + `— ` + snapshotUpdateShortDesc + `
+
+### Syntax
+`
+	snapshotCheckExample = `
+# Check that the 'dev' environment matches snapshots/dev
+ks snapshot check dev
+
+# Check that the 'dev' environment matches golden/dev
+ks snapshot check dev --dir golden
+`
+)
+
+func newSnapshotCheckCmd(fs afero.Fs) *cobra.Command {
+	snapshotCheckCmd := &cobra.Command{
+		Use:     "check [<env-name>]",
+		Short:   snapshotCheckShortDesc,
+		Long:    snapshotCheckLong,
+		Example: snapshotCheckExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var envName string
+			if len(args) == 1 {
+				envName = args[0]
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName:     envName,
+				actions.OptionSnapshotDir: viper.GetString(vSnapshotCheckDir),
+			}
+
+			if err := extractJsonnetFlags(fs, "snapshot-check"); err != nil {
+				return errors.Wrap(err, "handle jsonnet flags")
+			}
+
+			return runAction(actionSnapshotCheck, m)
+		},
+	}
+
+	bindJsonnetFlags(snapshotCheckCmd, "snapshot-check")
+
+	snapshotCheckCmd.Flags().String(flagDir, "snapshots", "Snapshot directory, relative to the application root")
+	viper.BindPFlag(vSnapshotCheckDir, snapshotCheckCmd.Flags().Lookup(flagDir))
+
+	return snapshotCheckCmd
+}
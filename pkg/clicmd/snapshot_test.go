@@ -0,0 +1,70 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/actions"
+)
+
+func Test_snapshotCmd(t *testing.T) {
+	cases := []cmdTestCase{
+		{
+			name:   "update",
+			args:   []string{"snapshot", "update", "default"},
+			action: actionSnapshotUpdate,
+			expected: map[string]interface{}{
+				actions.OptionEnvName:     "default",
+				actions.OptionSnapshotDir: "snapshots",
+			},
+		},
+		{
+			name:   "update with dir",
+			args:   []string{"snapshot", "update", "default", "--dir", "golden"},
+			action: actionSnapshotUpdate,
+			expected: map[string]interface{}{
+				actions.OptionEnvName:     "default",
+				actions.OptionSnapshotDir: "golden",
+			},
+		},
+		{
+			name:   "check",
+			args:   []string{"snapshot", "check", "default"},
+			action: actionSnapshotCheck,
+			expected: map[string]interface{}{
+				actions.OptionEnvName:     "default",
+				actions.OptionSnapshotDir: "snapshots",
+			},
+		},
+		{
+			name:   "check with dir",
+			args:   []string{"snapshot", "check", "default", "--dir", "golden"},
+			action: actionSnapshotCheck,
+			expected: map[string]interface{}{
+				actions.OptionEnvName:     "default",
+				actions.OptionSnapshotDir: "golden",
+			},
+		},
+		{
+			name:  "no subcommand",
+			args:  []string{"snapshot"},
+			isErr: true,
+		},
+	}
+
+	runTestCmd(t, cases)
+}
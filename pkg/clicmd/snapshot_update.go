@@ -0,0 +1,83 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	snapshotUpdateShortDesc = "Write the snapshot of an environment"
+	vSnapshotUpdateDir      = "snapshot-update-dir"
+)
+
+var (
+	snapshotUpdateLong = `
+The ` + "`update`" + ` command renders ` + "`<env-name>`" + ` and writes its snapshot. Snapshot
+files for components which are no longer rendered are removed.
+
+### Related Commands
+
+* ` + "`ks snapshot check` " + `— ` + snapshotCheckShortDesc + `
+
+### Syntax
+`
+	snapshotUpdateExample = `
+# Write the snapshot for the 'dev' environment to snapshots/dev
+ks snapshot update dev
+
+# Write the snapshot for the 'dev' environment to golden/dev
+ks snapshot update dev --dir golden
+`
+)
+
+func newSnapshotUpdateCmd(fs afero.Fs) *cobra.Command {
+	snapshotUpdateCmd := &cobra.Command{
+		Use:     "update [<env-name>]",
+		Short:   snapshotUpdateShortDesc,
+		Long:    snapshotUpdateLong,
+		Example: snapshotUpdateExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var envName string
+			if len(args) == 1 {
+				envName = args[0]
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName:     envName,
+				actions.OptionSnapshotDir: viper.GetString(vSnapshotUpdateDir),
+			}
+
+			if err := extractJsonnetFlags(fs, "snapshot-update"); err != nil {
+				return errors.Wrap(err, "handle jsonnet flags")
+			}
+
+			return runAction(actionSnapshotUpdate, m)
+		},
+	}
+
+	bindJsonnetFlags(snapshotUpdateCmd, "snapshot-update")
+
+	snapshotUpdateCmd.Flags().String(flagDir, "snapshots", "Snapshot directory, relative to the application root")
+	viper.BindPFlag(vSnapshotUpdateDir, snapshotUpdateCmd.Flags().Lookup(flagDir))
+
+	return snapshotUpdateCmd
+}
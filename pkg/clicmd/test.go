@@ -0,0 +1,102 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package clicmd
+
+import (
+	"github.com/ksonnet/ksonnet/pkg/actions"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	testShortDesc = "Run Jsonnet unit tests for an environment"
+	vTestJUnit    = "test-junit"
+)
+
+var (
+	testLong = `
+The ` + "`test`" + ` command runs the Jsonnet unit tests in an application. Tests are
+Jsonnet files ending in ` + "`_test.jsonnet`" + ` in the ` + "`lib/`" + ` and ` + "`components/`" + `
+directories. They are evaluated in the context of ` + "`<env-name>`" + `, with the same
+library paths, environment parameters and Jsonnet flags used by ` + "`ks show`" + `.
+Tests in a module have access to that module's parameters through
+` + "`std.extVar(\"__ksonnet/params\")`" + `.
+
+A test file evaluates to an object, and each field of the object is a test. A
+test passes if it evaluates to ` + "`true`" + `, or to an object with ` + "`actual`" + ` and
+` + "`expected`" + ` fields which are equal. It fails if it evaluates to ` + "`false`" + `, if
+` + "`actual`" + ` and ` + "`expected`" + ` differ, or if evaluation fails, e.g. with
+` + "`std.assertEqual`" + `.
+
+Use ` + "`--junit`" + ` to also write the results as a JUnit XML report for CI systems.
+
+### Related Commands
+
+* ` + "`ks show` " + `— ` + showShortDesc + `
+
+### Syntax
+`
+	testExample = `
+# Run the tests for the 'dev' environment
+ks test dev
+
+# Run the tests for the current environment and write a JUnit report
+ks test --junit report.xml
+
+# Example test file, lib/helpers_test.jsonnet
+local helpers = import "helpers.libsonnet";
+{
+  double: helpers.double(2) == 4,
+  name: { actual: helpers.name("web"), expected: "web-svc" },
+}
+`
+)
+
+func newTestCmd(fs afero.Fs) *cobra.Command {
+	testCmd := &cobra.Command{
+		Use:     "test [<env-name>] [--junit <file>]",
+		Short:   testShortDesc,
+		Long:    testLong,
+		Example: testExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var envName string
+			if len(args) == 1 {
+				envName = args[0]
+			}
+
+			m := map[string]interface{}{
+				actions.OptionEnvName: envName,
+				actions.OptionJUnit:   viper.GetString(vTestJUnit),
+			}
+
+			if err := extractJsonnetFlags(fs, "test"); err != nil {
+				return errors.Wrap(err, "handle jsonnet flags")
+			}
+
+			return runAction(actionTest, m)
+		},
+	}
+
+	bindJsonnetFlags(testCmd, "test")
+
+	testCmd.Flags().String(flagJUnit, "", "Write test results to a JUnit XML file")
+	viper.BindPFlag(vTestJUnit, testCmd.Flags().Lookup(flagJUnit))
+
+	return testCmd
+}
@@ -45,6 +45,9 @@ get deployed to your cluster with ` + "`ks apply <env-name>`" + `.
 When a component IS specified via the ` + "`-c`" + ` flag, this command only checks
 the manifest for that particular component.
 
+Errors include the component source file an object was rendered from, and the
+parameters which the environment's params file overrides.
+
 ### Related Commands
 
 * ` + "`ks show` " + `— ` + showShortDesc + `
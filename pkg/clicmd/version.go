@@ -17,9 +17,16 @@ package clicmd
 
 import (
 	"fmt"
+	"io"
 
 	jsonnet "github.com/google/go-jsonnet"
+	"github.com/ksonnet/ksonnet/pkg/util/table"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	vVersionOutput = "version-output"
 )
 
 var (
@@ -27,6 +34,9 @@ var (
 The ` + "`version`" + ` command prints out version info about the current ksonnet CLI,
 as well as for any of its helper libraries (e.g. ` + "`client-go`" + `).
 
+Use ` + "`--output`" + ` to print the versions as JSON or YAML, or to select
+fields with a Go template or JSONPath expression.
+
 ### Syntax
 `
 )
@@ -42,15 +52,31 @@ func newVersionCmd() *cobra.Command {
 		Use:   "version",
 		Short: "Print version information for this ksonnet binary",
 		Long:  versionLong,
-		Run: func(cmd *cobra.Command, args []string) {
-			out := cmd.OutOrStdout()
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := versionInfo{
+				Ksonnet:  Version,
+				Jsonnet:  jsonnet.Version(),
+				ClientGo: APImachineryVersion,
+			}
 
-			fmt.Fprintln(out, "ksonnet version:", Version)
-			fmt.Fprintln(out, "jsonnet version:", jsonnet.Version())
-			fmt.Fprintln(out, "client-go version:", APImachineryVersion)
+			return table.RenderObject(cmd.OutOrStdout(), "version", viper.GetString(vVersionOutput), v, func(w io.Writer) error {
+				fmt.Fprintln(w, "ksonnet version:", v.Ksonnet)
+				fmt.Fprintln(w, "jsonnet version:", v.Jsonnet)
+				fmt.Fprintln(w, "client-go version:", v.ClientGo)
+				return nil
+			})
 		},
 	}
 
+	addCmdOutput(versionCmd, vVersionOutput)
+
 	return versionCmd
 
 }
+
+// versionInfo is the structured output of `version`.
+type versionInfo struct {
+	Ksonnet  string `json:"ksonnet"`
+	Jsonnet  string `json:"jsonnet"`
+	ClientGo string `json:"clientGo"`
+}
@@ -17,9 +17,11 @@ package clicmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"regexp"
 	"testing"
 
+	jsonnet "github.com/google/go-jsonnet"
 	"github.com/ksonnet/ksonnet/pkg/util/test"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
@@ -34,6 +36,20 @@ func TestVersion(t *testing.T) {
 	}
 }
 
+func TestVersion_json(t *testing.T) {
+	output := cmdOutput(t, []string{"version", "-o", "json"})
+
+	var v struct {
+		Kind string      `json:"kind"`
+		Data versionInfo `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &v))
+
+	require.Equal(t, "version", v.Kind)
+	require.Equal(t, Version, v.Data.Ksonnet)
+	require.Equal(t, jsonnet.Version(), v.Data.Jsonnet)
+}
+
 func cmdOutput(t *testing.T, args []string) string {
 	fs := afero.NewMemMapFs()
 	test.StageFile(t, fs, "app.yaml", "/app.yaml")
@@ -58,8 +58,11 @@ type ApplyConfig struct {
 	Create         bool
 	DryRun         bool
 	EnvName        string
-	GcTag          string
-	SkipGc         bool
+	// Force garbage collects protected objects.
+	Force   bool
+	GcTag   string
+	NoCache bool
+	SkipGc  bool
 }
 
 // ApplyOpts are options for configuring Apply.
@@ -87,7 +90,7 @@ func RunApply(config ApplyConfig, opts ...ApplyOpts) error {
 
 	a := &Apply{
 		ApplyConfig:           config,
-		findObjectsFn:         findObjects,
+		findObjectsFn:         selectFindObjects(config.NoCache),
 		resourceClientFactory: resourceClientFactory,
 		objectInfo:            &objectInfo{},
 		ksonnetObjectFactory: func() ksonnetObject {
@@ -254,6 +257,11 @@ func (a *Apply) runGc(seenUids sets.String) error {
 			utils.ResourceNameFor(co.discovery, o), utils.FqName(metav1Object), gvk.GroupVersion())
 		log.Debugf("Considering %v for gc", desc)
 		if eligibleForGc(metav1Object, a.GcTag) && !seenUids.Has(string(metav1Object.GetUID())) {
+			if !a.Force && isProtected(gvk.Kind, metav1Object.GetAnnotations()) {
+				log.Warnf("Skipping garbage collection of protected %s; use --force to delete it", desc)
+				return nil
+			}
+
 			log.Info("Garbage collecting ", desc, a.dryRunText())
 			if !a.DryRun {
 				err = gcDelete(*co, a.resourceClientFactory, &version, o)
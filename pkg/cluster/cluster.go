@@ -53,6 +53,20 @@ func findObjects(a app.App, envName string, componentNames []string) ([]*unstruc
 	return p.Objects(componentNames)
 }
 
+// selectFindObjects selects how objects are found based on whether the render
+// cache should be used. Additional options are passed to the pipeline.
+func selectFindObjects(noCache bool, opts ...pipeline.Opt) findObjectsFn {
+	return func(a app.App, envName string, componentNames []string) ([]*unstructured.Unstructured, error) {
+		pipelineOpts := append([]pipeline.Opt{}, opts...)
+		if !noCache {
+			pipelineOpts = append(pipelineOpts, pipeline.WithCache(pipeline.NewFsCache(a)))
+		}
+
+		p := pipeline.New(a, envName, pipelineOpts...)
+		return p.Objects(componentNames)
+	}
+}
+
 func stringListContains(list []string, value string) bool {
 	for _, item := range list {
 		if item == value {
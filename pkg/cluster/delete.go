@@ -17,15 +17,21 @@ package cluster
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/util/prompt"
 	"github.com/ksonnet/ksonnet/utils"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // DeleteConfig is configuration for Delete.
@@ -34,7 +40,15 @@ type DeleteConfig struct {
 	ClientConfig   *client.Config
 	ComponentNames []string
 	EnvName        string
-	GracePeriod    int64
+	// Force deletes protected objects, and deletes objects from protected
+	// environments without confirmation.
+	Force       bool
+	GracePeriod int64
+	// Wait waits until deleted objects have been removed from the cluster.
+	Wait bool
+	// Timeout limits how long to wait for deleted objects to be removed. A
+	// zero timeout waits forever.
+	Timeout time.Duration
 }
 
 // DeleteOpts is an option for configuring Delete.
@@ -49,6 +63,10 @@ type Delete struct {
 	genClientOptsFn       genClientOptsFn
 	objectInfo            ObjectInfo
 	resourceClientFactory resourceClientFactoryFn
+	in                    io.Reader
+	out                   io.Writer
+	isTerminalFn          func() bool
+	pollInterval          time.Duration
 }
 
 // RunDelete runs delete against a cluster for a given configuration.
@@ -59,6 +77,10 @@ func RunDelete(config DeleteConfig, opts ...DeleteOpts) error {
 		genClientOptsFn:       GenClients,
 		resourceClientFactory: resourceClientFactory,
 		objectInfo:            &objectInfo{},
+		in:                    os.Stdin,
+		out:                   os.Stdout,
+		isTerminalFn:          prompt.StdinIsTerminal,
+		pollInterval:          2 * time.Second,
 	}
 
 	for _, opt := range opts {
@@ -100,23 +122,162 @@ func (d *Delete) Delete() error {
 		deleteOpts.GracePeriodSeconds = &d.GracePeriod
 	}
 
+	var deletions []deletion
 	for _, obj := range apiObjects {
 		desc := fmt.Sprintf("%s %s", d.objectInfo.ResourceName(co.discovery, obj), utils.FqName(obj))
-		log.Info("Deleting ", desc)
 
 		client, err := d.resourceClientFactory(co, obj)
 		if err != nil {
 			return err
 		}
 
+		if !d.Force {
+			protected, err := d.isProtected(client, obj)
+			if err != nil {
+				return err
+			}
+
+			if protected {
+				log.Warnf("Skipping protected %s; use --force to delete it", desc)
+				continue
+			}
+		}
+
+		deletions = append(deletions, deletion{desc: desc, obj: obj, client: client})
+	}
+
+	ok, err := d.confirm(deletions)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Info("Nothing was deleted")
+		return nil
+	}
+
+	var deleted []deletion
+	for _, del := range deletions {
+		desc, obj, client := del.desc, del.obj, del.client
+		log.Info("Deleting ", desc)
+
 		err = client.Delete(&deleteOpts)
-		if err != nil && !kerrors.IsNotFound(err) {
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
 			return fmt.Errorf("Error deleting %s: %s", desc, err)
 		}
 
 		log.Debugf("Deleted object: %v", obj)
+		deleted = append(deleted, del)
+	}
+
+	if d.Wait {
+		return d.wait(deleted)
 	}
 
 	return nil
 
 }
+
+// deletion is an object which will be deleted.
+type deletion struct {
+	desc   string
+	obj    *unstructured.Unstructured
+	client ResourceClient
+	// live is the last version of the object fetched from the cluster.
+	live *unstructured.Unstructured
+}
+
+// isProtected returns true if the rendered or live version of an object is
+// protected.
+func (d *Delete) isProtected(client ResourceClient, obj *unstructured.Unstructured) (bool, error) {
+	if isProtected(obj.GetKind(), obj.GetAnnotations()) {
+		return true, nil
+	}
+
+	live, err := client.Get(metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return isProtected(live.GetKind(), live.GetAnnotations()), nil
+}
+
+// confirm asks for confirmation before objects are deleted from a protected
+// environment. Confirmation isn't needed when deletion is forced.
+func (d *Delete) confirm(deletions []deletion) (bool, error) {
+	if d.Force || len(deletions) == 0 {
+		return true, nil
+	}
+
+	env, err := d.App.Environment(d.EnvName)
+	if err != nil {
+		return false, err
+	}
+
+	if !env.Protected {
+		return true, nil
+	}
+
+	if !d.isTerminalFn() {
+		return false, errors.Errorf("environment %q is protected; use --force to delete objects without confirmation", d.EnvName)
+	}
+
+	var descs []string
+	for _, del := range deletions {
+		descs = append(descs, del.desc)
+	}
+
+	return confirmDelete(d.in, d.out, d.EnvName, descs)
+}
+
+// wait polls deleted objects until they have been removed from the cluster.
+// If the timeout expires first, the objects which remain are reported along
+// with the finalizers they are waiting on.
+func (d *Delete) wait(deletions []deletion) error {
+	deadline := time.Now().Add(d.Timeout)
+	pending := deletions
+
+	for {
+		var remaining []deletion
+		for _, del := range pending {
+			live, err := del.client.Get(metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					log.Info("Removed ", del.desc)
+					continue
+				}
+				return errors.Wrapf(err, "checking %s", del.desc)
+			}
+
+			del.live = live
+			remaining = append(remaining, del)
+		}
+
+		pending = remaining
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if d.Timeout > 0 && !time.Now().Before(deadline) {
+			break
+		}
+
+		time.Sleep(d.pollInterval)
+	}
+
+	for _, del := range pending {
+		if finalizers := del.live.GetFinalizers(); len(finalizers) > 0 {
+			log.Warnf("%s is waiting on finalizers: %s", del.desc, strings.Join(finalizers, ", "))
+			continue
+		}
+
+		log.Warnf("%s has not been removed", del.desc)
+	}
+
+	return errors.Errorf("timed out after %s waiting for %d object(s) to be removed", d.Timeout, len(pending))
+}
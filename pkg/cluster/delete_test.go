@@ -0,0 +1,332 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/cluster/mocks"
+	"github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/ksonnet/ksonnet/pkg/util/test"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func Test_Delete(t *testing.T) {
+	cases := []struct {
+		name            string
+		force           bool
+		protectedEnv    bool
+		isTerminal      bool
+		in              string
+		liveAnnotations map[string]string
+		deleted         []string
+		out             string
+		isErr           bool
+	}{
+		{
+			name:    "protected kinds are skipped",
+			deleted: []string{"guestbook-ui", "unprotected"},
+		},
+		{
+			name:            "live objects with the protected annotation are skipped",
+			liveAnnotations: map[string]string{metadata.AnnotationProtected: "true"},
+		},
+		{
+			name:    "force deletes protected objects",
+			force:   true,
+			deleted: []string{"guestbook-ui", "data", "unprotected"},
+		},
+		{
+			name:         "protected environment confirmed",
+			protectedEnv: true,
+			isTerminal:   true,
+			in:           "y\n",
+			deleted:      []string{"guestbook-ui", "unprotected"},
+			out:          "Environment \"default\" is protected. The following objects will be deleted:\n  deployments default.guestbook-ui\n  namespaces default.unprotected\nDelete these objects? [y/N]: ",
+		},
+		{
+			name:         "protected environment declined",
+			protectedEnv: true,
+			isTerminal:   true,
+			in:           "n\n",
+			out:          "Environment \"default\" is protected. The following objects will be deleted:\n  deployments default.guestbook-ui\n  namespaces default.unprotected\nDelete these objects? [y/N]: ",
+		},
+		{
+			name:         "protected environment without a terminal",
+			protectedEnv: true,
+			isErr:        true,
+		},
+		{
+			name:         "protected environment with force",
+			protectedEnv: true,
+			force:        true,
+			deleted:      []string{"guestbook-ui", "data", "unprotected"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			test.WithApp(t, "/app", func(a *amocks.App, fs afero.Fs) {
+				a.On("Environment", "default").Return(&app.EnvironmentConfig030{
+					Name:      "default",
+					Protected: tc.protectedEnv,
+				}, nil)
+
+				deployment := deleteTestObject("Deployment", "guestbook-ui", nil)
+				pvc := deleteTestObject("PersistentVolumeClaim", "data", nil)
+				optOut := deleteTestObject("Namespace", "unprotected",
+					map[string]string{metadata.AnnotationProtected: "false"})
+
+				config := DeleteConfig{
+					App:          a,
+					ClientConfig: &client.Config{},
+					EnvName:      "default",
+					Force:        tc.force,
+					GracePeriod:  -1,
+				}
+
+				var deleted []string
+				var out bytes.Buffer
+
+				setup := func(d *Delete) {
+					d.findObjectsFn = func(a app.App, envName string, componentNames []string) ([]*unstructured.Unstructured, error) {
+						return []*unstructured.Unstructured{deployment, pvc, optOut}, nil
+					}
+
+					d.genClientOptsFn = func(a app.App, c *client.Config, envName string) (Clients, error) {
+						discovery := &mocks.DiscoveryInterface{}
+						discovery.On("ServerVersion").Return(&version.Info{GitVersion: "v1.10.0", Major: "1", Minor: "10"}, nil)
+						return Clients{discovery: discovery}, nil
+					}
+
+					objectInfo := &mocks.ObjectInfo{}
+					objectInfo.On("ResourceName", mock.Anything, deployment).Return("deployments")
+					objectInfo.On("ResourceName", mock.Anything, pvc).Return("persistentvolumeclaims")
+					objectInfo.On("ResourceName", mock.Anything, optOut).Return("namespaces")
+					d.objectInfo = objectInfo
+
+					d.resourceClientFactory = func(opts Clients, object runtime.Object) (ResourceClient, error) {
+						obj := object.(*unstructured.Unstructured)
+
+						live := obj.DeepCopy()
+						if tc.liveAnnotations != nil {
+							live.SetAnnotations(tc.liveAnnotations)
+						}
+
+						rc := &mocks.ResourceClient{}
+						rc.On("Get", metav1.GetOptions{}).Return(live, nil)
+						rc.On("Delete", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+							deleted = append(deleted, obj.GetName())
+						})
+						return rc, nil
+					}
+
+					d.in = strings.NewReader(tc.in)
+					d.out = &out
+					d.isTerminalFn = func() bool { return tc.isTerminal }
+				}
+
+				err := RunDelete(config, setup)
+				if tc.isErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				assert.Equal(t, tc.deleted, deleted)
+				assert.Equal(t, tc.out, out.String())
+			})
+		})
+	}
+}
+
+func Test_Delete_wait(t *testing.T) {
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "guestbook-ui")
+
+	cases := []struct {
+		name       string
+		wait       bool
+		finalizers []string
+		removed    bool
+		logged     string
+		isErr      bool
+	}{
+		{
+			name: "without waiting",
+		},
+		{
+			name:    "removed",
+			wait:    true,
+			removed: true,
+			logged:  "Removed deployments default.guestbook-ui",
+		},
+		{
+			name:       "stuck on finalizers",
+			wait:       true,
+			finalizers: []string{"example.com/cleanup", "example.com/backup"},
+			logged:     "deployments default.guestbook-ui is waiting on finalizers: example.com/cleanup, example.com/backup",
+			isErr:      true,
+		},
+		{
+			name:   "not removed",
+			wait:   true,
+			logged: "deployments default.guestbook-ui has not been removed",
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			test.WithApp(t, "/app", func(a *amocks.App, fs afero.Fs) {
+				a.On("Environment", "default").Return(&app.EnvironmentConfig030{Name: "default"}, nil)
+
+				obj := deleteTestObject("Deployment", "guestbook-ui", nil)
+
+				terminating := obj.DeepCopy()
+				terminating.SetFinalizers(tc.finalizers)
+
+				config := DeleteConfig{
+					App:          a,
+					ClientConfig: &client.Config{},
+					EnvName:      "default",
+					GracePeriod:  -1,
+					Wait:         tc.wait,
+					Timeout:      20 * time.Millisecond,
+				}
+
+				rc := &mocks.ResourceClient{}
+				rc.On("Get", metav1.GetOptions{}).Return(obj, nil).Once()
+				rc.On("Delete", mock.Anything).Return(nil)
+				if tc.removed {
+					rc.On("Get", metav1.GetOptions{}).Return(terminating, nil).Once()
+					rc.On("Get", metav1.GetOptions{}).Return(nil, notFound)
+				} else {
+					rc.On("Get", metav1.GetOptions{}).Return(terminating, nil)
+				}
+
+				setup := func(d *Delete) {
+					d.findObjectsFn = func(a app.App, envName string, componentNames []string) ([]*unstructured.Unstructured, error) {
+						return []*unstructured.Unstructured{obj}, nil
+					}
+
+					d.genClientOptsFn = func(a app.App, c *client.Config, envName string) (Clients, error) {
+						discovery := &mocks.DiscoveryInterface{}
+						discovery.On("ServerVersion").Return(&version.Info{GitVersion: "v1.10.0", Major: "1", Minor: "10"}, nil)
+						return Clients{discovery: discovery}, nil
+					}
+
+					objectInfo := &mocks.ObjectInfo{}
+					objectInfo.On("ResourceName", mock.Anything, obj).Return("deployments")
+					d.objectInfo = objectInfo
+
+					d.resourceClientFactory = func(opts Clients, object runtime.Object) (ResourceClient, error) {
+						return rc, nil
+					}
+
+					d.pollInterval = time.Millisecond
+				}
+
+				var buf bytes.Buffer
+				log.SetOutput(&buf)
+				defer log.SetOutput(os.Stderr)
+
+				err := RunDelete(config, setup)
+				if tc.isErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				if !tc.wait {
+					rc.AssertNumberOfCalls(t, "Get", 1)
+				}
+
+				assert.Contains(t, buf.String(), tc.logged)
+			})
+		})
+	}
+}
+
+func Test_isProtected(t *testing.T) {
+	cases := []struct {
+		name        string
+		kind        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{name: "namespace", kind: "Namespace", expected: true},
+		{name: "persistent volume", kind: "PersistentVolume", expected: true},
+		{name: "persistent volume claim", kind: "PersistentVolumeClaim", expected: true},
+		{name: "deployment", kind: "Deployment"},
+		{
+			name:        "opt in",
+			kind:        "Deployment",
+			annotations: map[string]string{metadata.AnnotationProtected: "true"},
+			expected:    true,
+		},
+		{
+			name:        "opt out",
+			kind:        "Namespace",
+			annotations: map[string]string{metadata.AnnotationProtected: "false"},
+		},
+		{
+			name:        "invalid annotation",
+			kind:        "Deployment",
+			annotations: map[string]string{metadata.AnnotationProtected: "maybe"},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isProtected(tc.kind, tc.annotations))
+		})
+	}
+}
+
+func deleteTestObject(kind, name string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+	}}
+
+	if annotations != nil {
+		obj.SetAnnotations(annotations)
+	}
+
+	return obj
+}
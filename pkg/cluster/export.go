@@ -0,0 +1,355 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/ksonnet/ksonnet/utils"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+)
+
+// Kinds which are never exported. These are either generated by the cluster
+// or are not meaningful outside of it.
+var unexportedKinds = map[string]bool{
+	"ComponentStatus": true,
+	"Endpoints":       true,
+	"Event":           true,
+}
+
+// Metadata fields which are populated by the server.
+var serverMetadataFields = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"namespace",
+	"ownerReferences",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// Annotations which are added by the server or by other clients.
+var serverAnnotations = []string{
+	"deployment.kubernetes.io/revision",
+	"kubectl.kubernetes.io/last-applied-configuration",
+	metadata.AnnotationManaged,
+}
+
+// ExportConfig is configuration for Export.
+type ExportConfig struct {
+	App          app.App
+	ClientConfig *client.Config
+	EnvName      string
+	// Kinds limits the export to these kinds. A kind can be specified by its
+	// kind, resource name, singular name or short name.
+	Kinds []string
+	// Selector is a label selector for the exported objects.
+	Selector string
+}
+
+// ExportOpts is an option for configuring Export.
+type ExportOpts func(*Export)
+
+// Export exports objects from a cluster namespace.
+type Export struct {
+	ExportConfig
+
+	// these make it easier to test Export.
+	genClientOptsFn       genClientOptsFn
+	listObjectsFn         func(clients Clients, kinds []string, selector string) ([]*unstructured.Unstructured, error)
+	resourceClientFactory resourceClientFactoryFn
+}
+
+// RunExport exports objects from the environment's namespace for a given
+// configuration. Server populated fields are removed from the returned objects.
+func RunExport(config ExportConfig, opts ...ExportOpts) ([]*unstructured.Unstructured, error) {
+	return newExport(config, opts...).Export()
+}
+
+// RunAdopt labels exported objects in the environment's namespace as deployed
+// by ksonnet. Objects should only be adopted once they have been imported, so
+// a failed import doesn't leave objects which ksonnet considers its own.
+func RunAdopt(config ExportConfig, objects []*unstructured.Unstructured, opts ...ExportOpts) error {
+	return newExport(config, opts...).Adopt(objects)
+}
+
+func newExport(config ExportConfig, opts ...ExportOpts) *Export {
+	e := &Export{
+		ExportConfig:          config,
+		genClientOptsFn:       GenClients,
+		listObjectsFn:         listObjects,
+		resourceClientFactory: resourceClientFactory,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Export exports objects from a cluster.
+func (e *Export) Export() ([]*unstructured.Unstructured, error) {
+	co, err := e.genClientOptsFn(e.App, e.ClientConfig, e.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := e.listObjectsFn(co, e.Kinds, e.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var exported []*unstructured.Unstructured
+	for _, obj := range objects {
+		if !isExportable(obj) {
+			log.Debugf("skipping %s %s", obj.GetKind(), utils.FqName(obj))
+			continue
+		}
+
+		exported = append(exported, cleanExportedObject(obj))
+	}
+
+	sort.Slice(exported, func(i, j int) bool {
+		if exported[i].GetKind() != exported[j].GetKind() {
+			return exported[i].GetKind() < exported[j].GetKind()
+		}
+		return exported[i].GetName() < exported[j].GetName()
+	})
+
+	return exported, nil
+}
+
+// Adopt labels objects in the cluster so they are managed by ksonnet.
+func (e *Export) Adopt(objects []*unstructured.Unstructured) error {
+	co, err := e.genClientOptsFn(e.App, e.ClientConfig, e.EnvName)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if err := e.adopt(co, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// adopt labels a live object so it is managed by ksonnet.
+func (e *Export) adopt(co Clients, obj *unstructured.Unstructured) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				metadata.LabelDeployManager: appKsonnet,
+			},
+		},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "marshal label patch")
+	}
+
+	rc, err := e.resourceClientFactory(co, obj)
+	if err != nil {
+		return err
+	}
+
+	if _, err = rc.Patch(types.MergePatchType, data); err != nil {
+		return errors.Wrapf(err, "labeling %s %s", obj.GetKind(), utils.FqName(obj))
+	}
+
+	return nil
+}
+
+// isExportable returns true if an object was created by a user rather than
+// by a controller or the cluster itself.
+func isExportable(obj *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			return false
+		}
+	}
+
+	switch obj.GetKind() {
+	case "ServiceAccount":
+		return obj.GetName() != "default"
+	case "Secret":
+		t, _, _ := unstructured.NestedString(obj.Object, "type")
+		return t != "kubernetes.io/service-account-token"
+	case "ConfigMap":
+		return obj.GetName() != "kube-root-ca.crt"
+	}
+
+	return true
+}
+
+// cleanExportedObject returns a copy of an object without its status and
+// server populated fields.
+func cleanExportedObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+
+	unstructured.RemoveNestedField(out.Object, "status")
+	for _, field := range serverMetadataFields {
+		unstructured.RemoveNestedField(out.Object, "metadata", field)
+	}
+
+	annotations := out.GetAnnotations()
+	for _, key := range serverAnnotations {
+		delete(annotations, key)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(out.Object, "metadata", "annotations")
+	} else {
+		out.SetAnnotations(annotations)
+	}
+
+	if out.GetKind() == "Service" {
+		// A cluster IP is allocated by the server unless the service is headless.
+		if ip, _, _ := unstructured.NestedString(out.Object, "spec", "clusterIP"); ip != "None" {
+			unstructured.RemoveNestedField(out.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(out.Object, "spec", "clusterIPs")
+		}
+	}
+
+	return out
+}
+
+// listObjects lists the objects in the clients' namespace.
+func listObjects(clients Clients, kinds []string, selector string) ([]*unstructured.Unstructured, error) {
+	if clients.discovery == nil {
+		return nil, errors.New("nil discovery client")
+	}
+	if clients.clientPool == nil {
+		return nil, errors.New("nil client pool")
+	}
+
+	resources, err := clients.discovery.ServerPreferredNamespacedResources()
+	if err != nil {
+		return nil, errors.Wrap(err, "ServerPreferredNamespacedResources")
+	}
+	sortResources(resources)
+
+	filtered := discovery.FilteredBy(
+		discovery.ResourcePredicateFunc(
+			func(groupVersion string, r *metav1.APIResource) bool {
+				return !unexportedKinds[r.Kind] &&
+					!strings.Contains(r.Name, "/") &&
+					matchesKinds(r, kinds) &&
+					discovery.SupportsAllVerbs{Verbs: []string{"list", "get"}}.Match(groupVersion, r)
+			},
+		),
+		resources,
+	)
+
+	if len(kinds) > 0 && len(filtered) == 0 {
+		return nil, errors.Errorf("no resources in the cluster match kinds %s", strings.Join(kinds, ", "))
+	}
+
+	uids := make(map[types.UID]bool)
+	var results []*unstructured.Unstructured
+
+	for _, lst := range filtered {
+		gv, err := schema.ParseGroupVersion(lst.GroupVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing GroupVersion: %s", lst.GroupVersion)
+		}
+
+		for _, resource := range lst.APIResources {
+			gvk := gv.WithKind(resource.Kind)
+			log.Debugf("listing resources: %s", gvk.String())
+			dynamic, err := clients.clientPool.ClientForGroupVersionKind(gvk)
+			if err != nil {
+				return nil, errors.Wrapf(err, "creating client for resource: %s", gvk.String())
+			}
+
+			obj, err := dynamic.Resource(&resource, clients.namespace).List(metav1.ListOptions{
+				LabelSelector: selector,
+			})
+			if err != nil {
+				log.Warnf("skipping %s due to error: %v", resource.Kind, err)
+				continue
+			}
+
+			ul, ok := obj.(*unstructured.UnstructuredList)
+			if !ok {
+				continue
+			}
+
+			err = ul.EachListItem(func(o runtime.Object) error {
+				u, ok := o.(*unstructured.Unstructured)
+				if !ok {
+					return nil
+				}
+
+				// Filter out duplicates, e.g apps/v1/Deployment vs. extensions/v1beta1/Deployment
+				if uids[u.GetUID()] {
+					return nil
+				}
+				uids[u.GetUID()] = true
+
+				// Items in a list don't always include their type.
+				if u.GetKind() == "" {
+					u.SetAPIVersion(lst.GroupVersion)
+					u.SetKind(resource.Kind)
+				}
+
+				results = append(results, u)
+				return nil
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "iterating %s", resource.Kind)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// matchesKinds returns true if a resource matches one of kinds. An empty
+// list of kinds matches every resource.
+func matchesKinds(r *metav1.APIResource, kinds []string) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+
+	names := append([]string{r.Kind, r.Name, r.SingularName}, r.ShortNames...)
+	for _, kind := range kinds {
+		for _, name := range names {
+			if name != "" && strings.EqualFold(kind, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,260 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/client"
+	"github.com/ksonnet/ksonnet/pkg/cluster/mocks"
+	"github.com/ksonnet/ksonnet/pkg/util/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_Export(t *testing.T) {
+	test.WithApp(t, "/app", func(a *amocks.App, fs afero.Fs) {
+		liveObjects := func() []*unstructured.Unstructured {
+			return []*unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "Service",
+						"metadata": map[string]interface{}{
+							"name":              "web",
+							"namespace":         "default",
+							"uid":               "1",
+							"resourceVersion":   "100",
+							"selfLink":          "/api/v1/namespaces/default/services/web",
+							"creationTimestamp": "2018-01-01T00:00:00Z",
+							"annotations": map[string]interface{}{
+								"kubectl.kubernetes.io/last-applied-configuration": "{}",
+							},
+						},
+						"spec": map[string]interface{}{
+							"clusterIP": "10.0.0.1",
+							"ports": []interface{}{
+								map[string]interface{}{"port": int64(80)},
+							},
+						},
+						"status": map[string]interface{}{
+							"loadBalancer": map[string]interface{}{},
+						},
+					},
+				},
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"name":       "web",
+							"namespace":  "default",
+							"uid":        "2",
+							"generation": int64(3),
+							"annotations": map[string]interface{}{
+								"deployment.kubernetes.io/revision": "3",
+								"team":                              "web",
+							},
+						},
+						"spec": map[string]interface{}{
+							"replicas": int64(1),
+						},
+						"status": map[string]interface{}{
+							"replicas": int64(1),
+						},
+					},
+				},
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "ReplicaSet",
+						"metadata": map[string]interface{}{
+							"name":      "web-1234",
+							"namespace": "default",
+							"ownerReferences": []interface{}{
+								map[string]interface{}{
+									"apiVersion": "apps/v1",
+									"kind":       "Deployment",
+									"name":       "web",
+									"uid":        "2",
+									"controller": true,
+								},
+							},
+						},
+					},
+				},
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ServiceAccount",
+						"metadata": map[string]interface{}{
+							"name":      "default",
+							"namespace": "default",
+						},
+					},
+				},
+			}
+		}
+
+		expected := []*unstructured.Unstructured{
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]interface{}{
+						"name": "web",
+						"annotations": map[string]interface{}{
+							"team": "web",
+						},
+					},
+					"spec": map[string]interface{}{
+						"replicas": int64(1),
+					},
+				},
+			},
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Service",
+					"metadata": map[string]interface{}{
+						"name": "web",
+					},
+					"spec": map[string]interface{}{
+						"ports": []interface{}{
+							map[string]interface{}{"port": int64(80)},
+						},
+					},
+				},
+			},
+		}
+
+		config := ExportConfig{
+			App:          a,
+			ClientConfig: &client.Config{},
+			EnvName:      "default",
+			Kinds:        []string{"svc", "deployment"},
+			Selector:     "app=web",
+		}
+
+		setup := func(e *Export) {
+			e.genClientOptsFn = func(a app.App, c *client.Config, envName string) (Clients, error) {
+				assert.Equal(t, "default", envName)
+				return Clients{namespace: "default"}, nil
+			}
+
+			e.listObjectsFn = func(clients Clients, kinds []string, selector string) ([]*unstructured.Unstructured, error) {
+				assert.Equal(t, []string{"svc", "deployment"}, kinds)
+				assert.Equal(t, "app=web", selector)
+				return liveObjects(), nil
+			}
+
+			e.resourceClientFactory = func(opts Clients, object runtime.Object) (ResourceClient, error) {
+				t.Fatal("export changed a live object")
+				return nil, nil
+			}
+		}
+
+		objects, err := RunExport(config, setup)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, objects)
+	})
+}
+
+func TestRunAdopt(t *testing.T) {
+	test.WithApp(t, "/app", func(a *amocks.App, fs afero.Fs) {
+		objects := []*unstructured.Unstructured{
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Service",
+					"metadata":   map[string]interface{}{"name": "web"},
+				},
+			},
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata":   map[string]interface{}{"name": "web"},
+				},
+			},
+		}
+
+		config := ExportConfig{
+			App:          a,
+			ClientConfig: &client.Config{},
+			EnvName:      "default",
+		}
+
+		var patched []string
+
+		setup := func(e *Export) {
+			e.genClientOptsFn = func(a app.App, c *client.Config, envName string) (Clients, error) {
+				assert.Equal(t, "default", envName)
+				return Clients{namespace: "default"}, nil
+			}
+
+			e.resourceClientFactory = func(opts Clients, object runtime.Object) (ResourceClient, error) {
+				obj := object.(*unstructured.Unstructured)
+				patched = append(patched, obj.GetKind())
+
+				rc := &mocks.ResourceClient{}
+				rc.On("Patch", types.MergePatchType, []byte(`{"metadata":{"labels":{"app.kubernetes.io/deploy-manager":"ksonnet"}}}`)).
+					Return(obj, nil)
+				return rc, nil
+			}
+		}
+
+		err := RunAdopt(config, objects, setup)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"Service", "Deployment"}, patched)
+	})
+}
+
+func Test_matchesKinds(t *testing.T) {
+	r := &metav1.APIResource{
+		Name:         "deployments",
+		SingularName: "deployment",
+		Kind:         "Deployment",
+		ShortNames:   []string{"deploy"},
+	}
+
+	cases := []struct {
+		name     string
+		kinds    []string
+		expected bool
+	}{
+		{name: "no kinds", expected: true},
+		{name: "kind", kinds: []string{"deployment"}, expected: true},
+		{name: "resource name", kinds: []string{"Deployments"}, expected: true},
+		{name: "short name", kinds: []string{"service", "deploy"}, expected: true},
+		{name: "no match", kinds: []string{"service"}, expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchesKinds(r, tc.kinds))
+		})
+	}
+}
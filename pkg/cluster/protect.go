@@ -0,0 +1,60 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cluster
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/ksonnet/ksonnet/pkg/util/prompt"
+)
+
+// protectedKinds are protected from deletion unless they opt out with the
+// protected annotation. Deleting them usually loses data.
+var protectedKinds = map[string]bool{
+	"Namespace":             true,
+	"PersistentVolume":      true,
+	"PersistentVolumeClaim": true,
+}
+
+// isProtected returns true if an object of kind with annotations is protected
+// from deletion. Objects opt in or out with the protected annotation. An
+// annotation value which isn't a boolean protects the object.
+func isProtected(kind string, annotations map[string]string) bool {
+	if v, ok := annotations[metadata.AnnotationProtected]; ok {
+		protected, err := strconv.ParseBool(v)
+		if err != nil {
+			return true
+		}
+		return protected
+	}
+
+	return protectedKinds[kind]
+}
+
+// confirmDelete lists the objects which will be deleted from an environment
+// and asks for confirmation.
+func confirmDelete(in io.Reader, out io.Writer, envName string, descs []string) (bool, error) {
+	fmt.Fprintf(out, "Environment %q is protected. The following objects will be deleted:\n", envName)
+	for _, desc := range descs {
+		fmt.Fprintf(out, "  %s\n", desc)
+	}
+
+	return prompt.Confirm(bufio.NewReader(in), out, "Delete these objects?")
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -32,7 +33,10 @@ type ShowConfig struct {
 	ComponentNames []string
 	EnvName        string
 	Format         string
-	Out            io.Writer
+	NoCache        bool
+	// Explain annotates objects with where they were rendered from.
+	Explain bool
+	Out     io.Writer
 }
 
 // ShowOpts is an option for configuring Show.
@@ -48,9 +52,14 @@ type Show struct {
 
 // RunShow shows objects for a given configuration.
 func RunShow(config ShowConfig, opts ...ShowOpts) error {
+	var pipelineOpts []pipeline.Opt
+	if config.Explain {
+		pipelineOpts = append(pipelineOpts, pipeline.WithProvenance())
+	}
+
 	s := &Show{
 		ShowConfig:    config,
-		findObjectsFn: findObjects,
+		findObjectsFn: selectFindObjects(config.NoCache, pipelineOpts...),
 	}
 
 	for _, opt := range opts {
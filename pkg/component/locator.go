@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/jsonnettest"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
@@ -116,9 +117,14 @@ func (cpl *componentPathLocator) allNamespaces() ([]string, error) {
 	return paths, nil
 }
 
-// isComponent reports if a file is a component. Components have a `jsonnet` extension.
-func isComponent(path string) bool {
-	for _, s := range []string{".jsonnet", ".yaml", "json"} {
+// IsComponentFile reports if a file is a component. Components have a `jsonnet`,
+// `yaml`, or `json` extension. Jsonnet test files are not components.
+func IsComponentFile(path string) bool {
+	if strings.HasSuffix(path, jsonnettest.FileSuffix) {
+		return false
+	}
+
+	for _, s := range []string{".jsonnet", ".yaml", ".json"} {
 		if s == filepath.Ext(path) {
 			return true
 		}
@@ -148,6 +148,10 @@ func extractPathParts(ksApp app.App, path string) (string, string, error) {
 
 	exts := []string{".yaml", ".jsonnet", ".json"}
 	for _, ext := range exts {
+		if !IsComponentFile(base + ext) {
+			continue
+		}
+
 		exists, err := afero.Exists(ksApp.Fs(), base+ext)
 		if err != nil {
 			return "", "", errors.Wrap(err, "check for component")
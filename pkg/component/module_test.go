@@ -101,6 +101,10 @@ func TestModule_Components(t *testing.T) {
 		test.StageFile(t, fs, "params-with-entry.libsonnet", "/app/components/module1/params.libsonnet")
 		test.StageFile(t, fs, "params-no-entry.libsonnet", "/app/components/params.libsonnet")
 
+		// Jsonnet tests live alongside components, but are not components.
+		require.NoError(t, afero.WriteFile(fs, "/app/components/module1/certificate-crd_test.jsonnet", []byte("{}"), 0644))
+		require.NoError(t, afero.WriteFile(fs, "/app/components/service_test.jsonnet", []byte("{}"), 0644))
+
 		cases := []struct {
 			name   string
 			module string
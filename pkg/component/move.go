@@ -0,0 +1,419 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package component
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	utilio "github.com/ksonnet/ksonnet/pkg/util/io"
+	strutil "github.com/ksonnet/ksonnet/pkg/util/strings"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// Move renames the component oldName to newName. A module in newName, e.g.
+// `web.frontend`, moves the component to that module, which is created if it
+// doesn't exist. The component's params, its parameter schema, and references
+// to its params are renamed in its module and in every environment. Like
+// Delete, write operations happen at the end, and either every file is
+// updated or none are.
+func Move(a app.App, oldName, newName string) error {
+	log.Debugf("moving component %s to %s", oldName, newName)
+
+	oldModuleName, oldComponentName, err := extractPathParts(a, oldName)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(newName, "/") {
+		return errors.New("component can't contain a /")
+	}
+
+	newModule, newComponentName := extractModuleComponent(a, strings.Replace(newName, ".", string(filepath.Separator), -1))
+	if !isValidName(newComponentName) {
+		return errors.Errorf("Component name '%s' is not valid; must not contain punctuation, spaces, or begin or end with a slash", newComponentName)
+	}
+
+	oldModule := NewModule(a, oldModuleName)
+	source, err := componentSource(a, oldModule, oldComponentName)
+	if err != nil {
+		return err
+	}
+
+	existing, err := componentSource(a, newModule, newComponentName)
+	if err != nil {
+		return err
+	}
+
+	if existing != "" {
+		return errors.Errorf("component %q already exists", newName)
+	}
+
+	cm := &componentMove{
+		app:      a,
+		mover:    params.NewComponentMover(),
+		files:    make(map[string]string),
+		oldName:  oldComponentName,
+		newName:  newComponentName,
+		from:     oldModule,
+		to:       newModule,
+		sameDirs: oldModule.Dir() == newModule.Dir(),
+	}
+
+	// Build the new module params.libsonnet files.
+	if err = cm.moveModuleParams(); err != nil {
+		return err
+	}
+
+	// Build the new environment/<env>/params.libsonnet files.
+	if err = cm.moveEnvParams(); err != nil {
+		return err
+	}
+
+	// Build the new params.schema.libsonnet files.
+	if err = cm.moveSchema(); err != nil {
+		return err
+	}
+
+	data, err := afero.ReadFile(a.Fs(), source)
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(source)
+	if ext == ".jsonnet" {
+		data = renameParamsReference(data, oldComponentName, newComponentName)
+	}
+
+	//
+	// Move the component and its params.
+	//
+	log.Infof("Moving component %q to %q", oldName, newName)
+
+	if err = a.Fs().MkdirAll(newModule.Dir(), defaultFolderPermissions); err != nil {
+		return errors.Wrapf(err, "create component dir %s", newModule.Dir())
+	}
+
+	ft := utilio.NewFileTransaction(a.Fs())
+	for path, src := range cm.files {
+		log.Debugf("... updating references in %s", path)
+		ft.Write(path, []byte(src))
+	}
+	ft.Write(filepath.Join(newModule.Dir(), newComponentName+ext), data)
+	ft.Remove(source)
+
+	if err = ft.Commit(); err != nil {
+		return err
+	}
+
+	log.Infof("Successfully moved component '%s' to '%s'", oldName, newName)
+	return nil
+}
+
+// componentMove builds the params files for a component which is being moved.
+type componentMove struct {
+	app   app.App
+	mover *params.ComponentMover
+
+	// files are the updated params files, keyed by path.
+	files map[string]string
+
+	oldName  string
+	newName  string
+	from     Module
+	to       Module
+	sameDirs bool
+}
+
+func (cm *componentMove) read(path string) (string, error) {
+	if src, ok := cm.files[path]; ok {
+		return src, nil
+	}
+
+	data, err := afero.ReadFile(cm.app.Fs(), path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// moveModuleParams renames the component's params in its module, or moves
+// them to its new module.
+func (cm *componentMove) moveModuleParams() error {
+	path := cm.from.ParamsPath()
+	src, err := cm.read(path)
+	if err != nil {
+		return err
+	}
+
+	all := func(string) bool { return true }
+
+	if cm.sameDirs {
+		updated, err := cm.mover.Rename(cm.oldName, cm.newName, src)
+		if err != nil {
+			return errors.Wrapf(err, "renaming params in %s", path)
+		}
+
+		if updated, err = cm.mover.RenameRefs(cm.oldName, cm.newName, updated, all); err != nil {
+			return errors.Wrapf(err, "renaming references in %s", path)
+		}
+
+		cm.files[path] = updated
+		return nil
+	}
+
+	if err := cm.checkRefs(src, cm.oldName, all); err != nil {
+		return err
+	}
+
+	updated, componentParams, err := cm.mover.Extract(cm.oldName, src)
+	if err != nil {
+		return errors.Wrapf(err, "removing params from %s", path)
+	}
+	cm.files[path] = updated
+
+	newPath := cm.to.ParamsPath()
+	inserted, err := cm.read(newPath)
+	if os.IsNotExist(err) {
+		inserted, err = string(GenParamsContent()), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if componentParams != nil {
+		inserted, err = cm.mover.Insert(cm.newName, inserted, componentParams)
+		if err != nil {
+			return errors.Wrapf(err, "adding params to %s", newPath)
+		}
+
+		// references to the component's own params.
+		inserted, err = cm.mover.RenameRefs(cm.oldName, cm.newName, inserted, onlyComponent(cm.newName))
+		if err != nil {
+			return errors.Wrapf(err, "renaming references in %s", newPath)
+		}
+	}
+
+	cm.files[newPath] = inserted
+	return nil
+}
+
+// moveEnvParams renames the component's params, and references to them, in
+// every environment.
+func (cm *componentMove) moveEnvParams() error {
+	oldQualified := qualifiedName(cm.from.Name(), cm.oldName)
+	newQualified := qualifiedName(cm.to.Name(), cm.newName)
+
+	envs, err := cm.app.Environments()
+	if err != nil {
+		return err
+	}
+
+	for envName := range envs {
+		path := filepath.Join(cm.app.Root(), "environments", envName, "params.libsonnet")
+		src, err := cm.read(path)
+		if err != nil {
+			return err
+		}
+
+		if !cm.sameDirs {
+			if err := cm.checkRefs(src, oldQualified, inModule(cm.from.Name())); err != nil {
+				return errors.Wrapf(err, "environment %q", envName)
+			}
+		}
+
+		updated, err := cm.mover.Rename(oldQualified, newQualified, src)
+		if err != nil {
+			return errors.Wrapf(err, "renaming params for environment %q", envName)
+		}
+
+		include := inModule(cm.to.Name())
+		if !cm.sameDirs {
+			include = onlyComponent(newQualified)
+		}
+
+		updated, err = cm.mover.RenameRefs(cm.oldName, cm.newName, updated, include)
+		if err != nil {
+			return errors.Wrapf(err, "renaming references for environment %q", envName)
+		}
+
+		cm.files[path] = updated
+	}
+
+	return nil
+}
+
+// moveSchema renames the component's parameter schema in its module, or moves
+// it to the schema of its new module.
+func (cm *componentMove) moveSchema() error {
+	path := params.SchemaPath(cm.from.ParamsPath())
+	src, err := cm.read(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cm.sameDirs {
+		updated, err := cm.mover.Rename(cm.oldName, cm.newName, src)
+		if err != nil {
+			return errors.Wrapf(err, "renaming schema in %s", path)
+		}
+
+		cm.files[path] = updated
+		return nil
+	}
+
+	updated, schema, err := cm.mover.Extract(cm.oldName, src)
+	if err != nil {
+		return errors.Wrapf(err, "removing schema from %s", path)
+	}
+
+	if schema == nil {
+		return nil
+	}
+	cm.files[path] = updated
+
+	newPath := params.SchemaPath(cm.to.ParamsPath())
+	inserted, err := cm.read(newPath)
+	if os.IsNotExist(err) {
+		inserted, err = emptySchema, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cm.files[newPath], err = cm.mover.Insert(cm.newName, inserted, schema); err != nil {
+		return errors.Wrapf(err, "adding schema to %s", newPath)
+	}
+
+	return nil
+}
+
+// checkRefs checks a component which is moving to another module isn't
+// referenced by the other components of its module, and doesn't reference
+// them. References are resolved within a module, so they can't be moved.
+func (cm *componentMove) checkRefs(src, componentName string, include func(string) bool) error {
+	refs, err := cm.mover.Refs(src, include)
+	if err != nil {
+		return err
+	}
+
+	for name, targets := range refs {
+		for _, target := range targets {
+			targetName, _, err := params.ParseRef(target)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case name == componentName && targetName != cm.oldName:
+				return errors.Errorf("component %q references %q, which can't be moved to another module", cm.oldName, target)
+			case name != componentName && targetName == cm.oldName:
+				return errors.Errorf("component %q is referenced by %q, which can't be moved to another module", cm.oldName, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// emptySchema is the schema file created when a component's schema is moved
+// to a module which doesn't have one.
+const emptySchema = `{
+  components: {
+  },
+}
+`
+
+// onlyComponent returns a predicate which matches a single component.
+func onlyComponent(componentName string) func(string) bool {
+	return func(name string) bool {
+		return name == componentName
+	}
+}
+
+// inModule returns a predicate which matches the qualified names of the
+// components in a module, as used in environment params.
+func inModule(module string) func(string) bool {
+	return func(name string) bool {
+		i := strings.LastIndex(name, ".")
+		if module == "/" || module == "" {
+			return i < 0
+		}
+
+		return i >= 0 && name[:i] == module
+	}
+}
+
+// componentSource returns the path of a component in a module, or an empty
+// string if the module doesn't contain the component.
+func componentSource(a app.App, m Module, name string) (string, error) {
+	base := filepath.Join(m.Dir(), name)
+
+	for _, ext := range []string{".yaml", ".jsonnet", ".json"} {
+		if !IsComponentFile(base + ext) {
+			continue
+		}
+
+		exists, err := afero.Exists(a.Fs(), base+ext)
+		if err != nil {
+			return "", errors.Wrap(err, "check for component")
+		}
+
+		if exists {
+			return base + ext, nil
+		}
+	}
+
+	return "", nil
+}
+
+// qualifiedName returns the name of a component prefixed with its module, as
+// used in environment params.
+func qualifiedName(module, name string) string {
+	if module == "/" || module == "" {
+		return name
+	}
+
+	return strings.Join([]string{module, name}, ".")
+}
+
+// renameParamsReference rewrites the lookup of a Jsonnet component's params,
+// e.g. `std.extVar("__ksonnet/params").components["guestbook"]` or
+// `.components.guestbook`, to use its new name.
+func renameParamsReference(data []byte, oldName, newName string) []byte {
+	replacement := fmt.Sprintf(`components[%q]`, newName)
+
+	re := regexp.MustCompile(`components\s*\[\s*["']` + regexp.QuoteMeta(oldName) + `["']\s*\]`)
+	data = re.ReplaceAllLiteral(data, []byte(replacement))
+
+	if !strutil.IsASCIIIdentifier(oldName) {
+		return data
+	}
+
+	re = regexp.MustCompile(`components\s*\.\s*` + regexp.QuoteMeta(oldName) + `([^A-Za-z0-9_]|$)`)
+	return re.ReplaceAll(data, []byte(replacement+"${1}"))
+}
@@ -0,0 +1,230 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package component
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/util/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMove(t *testing.T) {
+	cases := []struct {
+		name     string
+		oldName  string
+		newName  string
+		oldPath  string
+		newPath  string
+		expected map[string]string
+		isErr    bool
+	}{
+		{
+			name:    "rename",
+			oldName: "guestbook-ui",
+			newName: "guestbook",
+			oldPath: "/app/components/guestbook-ui.jsonnet",
+			newPath: "/app/components/guestbook.jsonnet",
+			expected: map[string]string{
+				"/app/components/guestbook.jsonnet":           "move/rename/guestbook.jsonnet",
+				"/app/components/params.libsonnet":            "move/rename/params.libsonnet",
+				"/app/environments/default/params.libsonnet":  "move/rename/env-params.libsonnet",
+				"/app/components/nested/guestbook-ui.jsonnet": "delete/components/nested/guestbook-ui.jsonnet",
+				"/app/components/nested/params.libsonnet":     "delete/components/nested/params.libsonnet",
+			},
+		},
+		{
+			name:    "move to a new module",
+			oldName: "nested.guestbook-ui",
+			newName: "web.guestbook",
+			oldPath: "/app/components/nested/guestbook-ui.jsonnet",
+			newPath: "/app/components/web/guestbook.jsonnet",
+			expected: map[string]string{
+				"/app/components/web/guestbook.jsonnet":      "move/module/guestbook.jsonnet",
+				"/app/components/web/params.libsonnet":       "move/module/params.libsonnet",
+				"/app/components/nested/params.libsonnet":    "move/module/nested-params.libsonnet",
+				"/app/environments/default/params.libsonnet": "move/module/env-params.libsonnet",
+			},
+		},
+		{
+			name:    "new name exists",
+			oldName: "guestbook-ui",
+			newName: "nested.guestbook-ui",
+			isErr:   true,
+		},
+		{
+			name:    "missing component",
+			oldName: "missing",
+			newName: "guestbook",
+			isErr:   true,
+		},
+		{
+			name:    "invalid new name",
+			oldName: "guestbook-ui",
+			newName: "guestbook!",
+			isErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			test.WithApp(t, "/app", func(a *mocks.App, fs afero.Fs) {
+				test.StageDir(t, fs, "delete", "/app")
+
+				envs := app.EnvironmentConfigs{
+					"default": &app.EnvironmentConfig{},
+				}
+				a.On("Environments").Return(envs, nil)
+
+				err := Move(a, tc.oldName, tc.newName)
+				if tc.isErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+
+				test.AssertNotExists(t, fs, tc.oldPath)
+				test.AssertExists(t, fs, tc.newPath)
+
+				for path, expected := range tc.expected {
+					test.AssertContents(t, fs, expected, filepath.Clean(path))
+				}
+			})
+		})
+	}
+}
+
+func TestMove_references(t *testing.T) {
+	cases := []struct {
+		name     string
+		oldName  string
+		newName  string
+		newPath  string
+		expected map[string]string
+		isErr    bool
+	}{
+		{
+			name:    "rename",
+			oldName: "db",
+			newName: "database",
+			newPath: "/app/components/database.jsonnet",
+			expected: map[string]string{
+				"/app/components/database.jsonnet":           "move/refs-rename/database.jsonnet",
+				"/app/components/params.libsonnet":           "move/refs-rename/params.libsonnet",
+				"/app/components/params.schema.libsonnet":    "move/refs-rename/params.schema.libsonnet",
+				"/app/environments/default/params.libsonnet": "move/refs-rename/env-params.libsonnet",
+			},
+		},
+		{
+			name:    "referenced component moved to another module",
+			oldName: "db",
+			newName: "nested.db",
+			isErr:   true,
+		},
+		{
+			name:    "component with references moved to another module",
+			oldName: "web",
+			newName: "nested.web",
+			isErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			test.WithApp(t, "/app", func(a *mocks.App, fs afero.Fs) {
+				test.StageDir(t, fs, "move/refs", "/app")
+
+				envs := app.EnvironmentConfigs{
+					"default": &app.EnvironmentConfig{},
+				}
+				a.On("Environments").Return(envs, nil)
+
+				err := Move(a, tc.oldName, tc.newName)
+				if tc.isErr {
+					require.Error(t, err)
+
+					// nothing is written.
+					test.AssertContents(t, fs, "move/refs/components/params.libsonnet", "/app/components/params.libsonnet")
+					test.AssertContents(t, fs, "move/refs/environments/default/params.libsonnet", "/app/environments/default/params.libsonnet")
+					return
+				}
+				require.NoError(t, err)
+
+				test.AssertExists(t, fs, tc.newPath)
+
+				for path, expected := range tc.expected {
+					test.AssertContents(t, fs, expected, path)
+				}
+			})
+		})
+	}
+}
+
+func Test_renameParamsReference(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "index",
+			src:      `std.extVar("__ksonnet/params").components["db"];`,
+			expected: `std.extVar("__ksonnet/params").components["database"];`,
+		},
+		{
+			name:     "field",
+			src:      `std.extVar("__ksonnet/params").components.db;`,
+			expected: `std.extVar("__ksonnet/params").components["database"];`,
+		},
+		{
+			name:     "other component",
+			src:      `std.extVar("__ksonnet/params").components.dbs;`,
+			expected: `std.extVar("__ksonnet/params").components.dbs;`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := renameParamsReference([]byte(tc.src), "db", "database")
+			require.Equal(t, tc.expected, string(got))
+		})
+	}
+}
+
+func TestMove_schema(t *testing.T) {
+	test.WithApp(t, "/app", func(a *mocks.App, fs afero.Fs) {
+		test.StageDir(t, fs, "delete", "/app")
+
+		schema := `{ components: { "guestbook-ui": { type: "object" }, other: { type: "object" } } }`
+		require.NoError(t, afero.WriteFile(fs, "/app/components/nested/params.schema.libsonnet", []byte(schema), 0644))
+
+		a.On("Environments").Return(app.EnvironmentConfigs{"default": &app.EnvironmentConfig{}}, nil)
+
+		require.NoError(t, Move(a, "nested.guestbook-ui", "web.guestbook"))
+
+		got, err := afero.ReadFile(fs, "/app/components/nested/params.schema.libsonnet")
+		require.NoError(t, err)
+		require.Equal(t, `{ components: { other: { type: 'object' } } }`, string(got))
+
+		got, err = afero.ReadFile(fs, "/app/components/web/params.schema.libsonnet")
+		require.NoError(t, err)
+		require.Equal(t, "{\n  components: {\n    guestbook: { type: 'object' },\n  },\n}", string(got))
+	})
+}
@@ -0,0 +1,4 @@
+{
+  global: {},
+  components: {},
+}
\ No newline at end of file
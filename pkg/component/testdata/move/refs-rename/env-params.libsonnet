@@ -0,0 +1,22 @@
+local params = std.extVar('__ksonnet/params');
+local globals = import 'globals.libsonnet';
+local envParams = params + {
+  components+: {
+    database+: {
+      port: 5433,
+    },
+    web+: {
+      dbHost: { "__ksonnet/ref": 'database.host' },
+    },
+    "nested.web"+: {
+      dbPort: { "__ksonnet/ref": 'db.port' },
+    },
+  },
+};
+
+{
+  components: {
+    [x]: envParams.components[x] + globals
+    for x in std.objectFields(envParams.components)
+  },
+}
\ No newline at end of file
@@ -0,0 +1,11 @@
+{
+  global: {},
+  components: {
+    database: {
+      port: 5432,
+    },
+    web: {
+      dbPort: { "__ksonnet/ref": 'database.port' },
+    },
+  },
+}
\ No newline at end of file
@@ -0,0 +1,10 @@
+{
+  components: {
+    database: {
+      type: 'object',
+      properties: {
+        port: { type: 'integer', minimum: 1024 },
+      },
+    },
+  },
+}
\ No newline at end of file
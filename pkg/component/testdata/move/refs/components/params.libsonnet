@@ -0,0 +1,11 @@
+{
+  global: {},
+  components: {
+    db: {
+      port: 5432,
+    },
+    web: {
+      dbPort: { "__ksonnet/ref": "db.port" },
+    },
+  },
+}
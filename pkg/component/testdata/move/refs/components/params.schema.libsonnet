@@ -0,0 +1,10 @@
+{
+  components: {
+    db: {
+      type: "object",
+      properties: {
+        port: { type: "integer", minimum: 1024 },
+      },
+    },
+  },
+}
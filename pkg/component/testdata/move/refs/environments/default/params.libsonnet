@@ -0,0 +1,21 @@
+local params = std.extVar("__ksonnet/params");
+local globals = import "globals.libsonnet";
+local envParams = params + {
+  components +: {
+    db +: {
+      port: 5433,
+    },
+    web +: {
+      dbHost: { "__ksonnet/ref": "db.host" },
+    },
+    "nested.web" +: {
+      dbPort: { "__ksonnet/ref": "db.port" },
+    },
+  },
+};
+
+{
+  components: {
+    [x]: envParams.components[x] + globals, for x in std.objectFields(envParams.components)
+  },
+}
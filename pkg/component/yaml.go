@@ -291,9 +291,13 @@ func (y *YAML) Summarize() (Summary, error) {
 		return Summary{}, err
 	}
 
-	name, err := props.Name()
-	if err != nil {
-		return Summary{}, err
+	// Lists, e.g. from `ks import --single`, have no name of their own.
+	var name string
+	if ts.RawKind != "List" {
+		name, err = props.Name()
+		if err != nil {
+			return Summary{}, err
+		}
 	}
 
 	return Summary{
@@ -225,6 +225,28 @@ func TestYAML_Summarize_json(t *testing.T) {
 	})
 }
 
+func TestYAML_Summarize_list(t *testing.T) {
+	test.WithApp(t, "/app", func(a *mocks.App, fs afero.Fs) {
+
+		test.StageFile(t, fs, "list.yaml", "/components/list.yaml")
+		test.StageFile(t, fs, "params-no-entry.libsonnet", "/components/params.libsonnet")
+
+		y := NewYAML(a, "", "/components/list.yaml", "/components/params.libsonnet")
+
+		list, err := y.Summarize()
+		require.NoError(t, err)
+
+		expected := Summary{
+			ComponentName: "list",
+			Type:          "yaml",
+			APIVersion:    "v1",
+			Kind:          "List",
+		}
+
+		require.Equal(t, expected, list)
+	})
+}
+
 func Test_mapToPaths(t *testing.T) {
 	m := map[string]interface{}{
 		"metadata": map[string]interface{}{
@@ -0,0 +1,155 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// Clone creates an environment from an existing environment. The new
+// environment has the source environment's params, globals, targets and
+// libraries, and points to the provided destination.
+func Clone(a app.App, from, to string, d Destination, isOverride bool) error {
+	c, err := newCloner(a, from, to, d, isOverride)
+	if err != nil {
+		return err
+	}
+	return c.Clone()
+}
+
+type cloner struct {
+	app        app.App
+	from       string
+	to         string
+	d          Destination
+	isOverride bool
+}
+
+func newCloner(a app.App, from, to string, d Destination, isOverride bool) (*cloner, error) {
+	return &cloner{
+		app:        a,
+		from:       from,
+		to:         to,
+		d:          d,
+		isOverride: isOverride,
+	}, nil
+}
+
+func (c *cloner) Clone() error {
+	if !isValidName(c.to) {
+		return fmt.Errorf("environment name %q is not valid; must not contain punctuation, spaces, or begin or end with a slash", c.to)
+	}
+
+	src, err := c.app.Environment(c.from)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.app.Environment(c.to); err == nil {
+		return errors.Errorf("environment %q already exists", c.to)
+	}
+
+	srcPath, err := envRoot(c.app, c.from)
+	if err != nil {
+		return err
+	}
+
+	dstPath := filepath.Join(c.app.Root(), envRootName, c.to)
+	exists, err := afero.Exists(c.app.Fs(), dstPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("environment directory %q already exists", dstPath)
+	}
+
+	log.Infof("Cloning environment %q to %q with namespace %q, pointing to cluster at address %q",
+		c.from, c.to, c.d.Namespace(), c.d.Server())
+
+	if err = copyEnvDir(c.app.Fs(), srcPath, dstPath); err != nil {
+		return errors.Wrapf(err, "copying environment %q", c.from)
+	}
+
+	var targets []string
+	targets = append(targets, src.Targets...)
+
+	var libraries app.LibraryConfigs
+	if len(src.Libraries) > 0 {
+		libraries = app.LibraryConfigs{}
+		for k, v := range src.Libraries {
+			lib := *v
+			libraries[k] = &lib
+		}
+	}
+
+	// The environment's ksonnet-lib is shared with the source environment, so
+	// it doesn't need to be generated again.
+	return c.app.AddEnvironment(&app.EnvironmentConfig{
+		Name:              c.to,
+		Path:              c.to,
+		KubernetesVersion: src.KubernetesVersion,
+		Destination: &app.EnvironmentDestinationSpec{
+			Server:    c.d.Server(),
+			Namespace: c.d.Namespace(),
+		},
+		Targets:   targets,
+		Libraries: libraries,
+	}, "", c.isOverride)
+}
+
+// copyEnvDir copies the files in an environment directory. Nested
+// environments are not copied.
+func copyEnvDir(fs afero.Fs, src, dst string) error {
+	return afero.Walk(fs, src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if fi.IsDir() {
+			if path != src {
+				nested, err := afero.Exists(fs, filepath.Join(path, envFileName))
+				if err != nil {
+					return err
+				}
+				if nested {
+					return filepath.SkipDir
+				}
+			}
+
+			return fs.MkdirAll(target, app.DefaultFolderPermissions)
+		}
+
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+
+		return afero.WriteFile(fs, target, data, app.DefaultFilePermissions)
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package env
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/app/mocks"
+)
+
+func TestClone(t *testing.T) {
+	withEnv(t, func(appMock *mocks.App, fs afero.Fs) {
+		envSpec := &app.EnvironmentConfig{
+			Name:              "env1",
+			Path:              "env1",
+			KubernetesVersion: "v1.8.7",
+			Destination: &app.EnvironmentDestinationSpec{
+				Server:    "http://example.com",
+				Namespace: "staging",
+			},
+			Targets: []string{"web"},
+			Libraries: app.LibraryConfigs{
+				"incubator/nginx": {Name: "nginx", Registry: "incubator", Version: "1.0"},
+			},
+		}
+
+		appMock = &mocks.App{}
+		appMock.On("Fs").Return(fs)
+		appMock.On("Root").Return("/")
+		appMock.On("Environment", "env1").Return(envSpec, nil)
+		appMock.On("Environment", "env4").Return(nil, errors.New("not found"))
+
+		expected := &app.EnvironmentConfig{
+			Name:              "env4",
+			Path:              "env4",
+			KubernetesVersion: "v1.8.7",
+			Destination: &app.EnvironmentDestinationSpec{
+				Server:    "http://prod.example.com",
+				Namespace: "prod",
+			},
+			Targets: []string{"web"},
+			Libraries: app.LibraryConfigs{
+				"incubator/nginx": {Name: "nginx", Registry: "incubator", Version: "1.0"},
+			},
+		}
+		appMock.On("AddEnvironment", expected, "", false).Return(nil)
+
+		d := NewDestination("http://prod.example.com", "prod")
+		err := Clone(appMock, "env1", "env4", d, false)
+		require.NoError(t, err)
+
+		for _, name := range []string{envFileName, paramsFileName, globalsFileName} {
+			compareOutput(t, fs, name, filepath.Join("/", envRootName, "env4", name))
+		}
+
+		appMock.AssertExpectations(t)
+	})
+}
+
+func TestClone_invalid(t *testing.T) {
+	cases := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{
+			name: "invalid name",
+			from: "env1",
+			to:   "env!",
+		},
+		{
+			name: "existing environment",
+			from: "env1",
+			to:   "env2",
+		},
+		{
+			name: "missing source",
+			from: "missing",
+			to:   "env4",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withEnv(t, func(appMock *mocks.App, fs afero.Fs) {
+				appMock.On("Environment", "env2").Return(&app.EnvironmentConfig{Path: "env2"}, nil)
+				appMock.On("Environment", "env4").Return(nil, errors.New("not found"))
+				appMock.On("Environment", "missing").Return(nil, errors.New("not found"))
+
+				d := NewDestination("http://example.com", "default")
+				err := Clone(appMock, tc.from, tc.to, d, false)
+				require.Error(t, err)
+			})
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/ksonnet/ksonnet/pkg/helm"
 	utilio "github.com/ksonnet/ksonnet/pkg/util/io"
@@ -42,7 +43,11 @@ const (
 	envRootName = "environments"
 )
 
+// The package level Jsonnet settings are set from the command line. They are
+// used by Evaluate and NewVM. Library users should pass EvaluateSettings to
+// EvaluateWithSettings and NewVMWithSettings instead.
 var (
+	settingsMu       sync.RWMutex
 	componentJPaths  = make([]string, 0)
 	componentExtVars = make(map[string]string)
 	componentTlaVars = make(map[string]string)
@@ -50,11 +55,17 @@ var (
 
 // AddJPaths adds paths to JPath for a component evaluation.
 func AddJPaths(paths ...string) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
 	componentJPaths = append(componentJPaths, paths...)
 }
 
 // AddExtVar adds an ext var to a component evaluation.
 func AddExtVar(key, value string) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
 	componentExtVars[key] = value
 }
 
@@ -65,12 +76,15 @@ func AddExtVarFile(fs afero.Fs, key, filePath string) error {
 		return err
 	}
 
-	componentExtVars[key] = string(data)
+	AddExtVar(key, string(data))
 	return nil
 }
 
 // AddTlaVar adds a tla var to a component evaluation.
 func AddTlaVar(key, value string) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
 	componentTlaVars[key] = value
 }
 
@@ -81,10 +95,49 @@ func AddTlaVarFile(fs afero.Fs, key, filePath string) error {
 		return err
 	}
 
-	componentTlaVars[key] = string(data)
+	AddTlaVar(key, string(data))
 	return nil
 }
 
+// EvaluateSettings are the Jsonnet settings which are applied to component
+// evaluation in addition to the application's own paths.
+type EvaluateSettings struct {
+	JPaths  []string
+	ExtVars map[string]string
+	TlaVars map[string]string
+
+	// PackageManager resolves the versioned packages used by the environment.
+	// If it is nil, a package manager for the application is created.
+	PackageManager registry.PackageManager
+
+	// VendorCache keeps the environment's revendored packages between
+	// evaluations. If it is nil, packages are copied for every evaluation.
+	VendorCache *VendorCache
+}
+
+// CurrentEvaluateSettings returns a copy of the Jsonnet settings for component
+// evaluation which were set with AddJPaths, AddExtVar, and AddTlaVar.
+func CurrentEvaluateSettings() EvaluateSettings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+
+	settings := EvaluateSettings{
+		JPaths:  append([]string{}, componentJPaths...),
+		ExtVars: make(map[string]string),
+		TlaVars: make(map[string]string),
+	}
+
+	for k, v := range componentExtVars {
+		settings.ExtVars[k] = v
+	}
+
+	for k, v := range componentTlaVars {
+		settings.TlaVars[k] = v
+	}
+
+	return settings
+}
+
 // MainFile returns the contents of the environment's main source.
 func MainFile(a app.App, envName string) (string, error) {
 	path, err := Path(a, envName, envFileName)
@@ -100,15 +153,19 @@ func MainFile(a app.App, envName string) (string, error) {
 	return string(snippet), nil
 }
 
-// Evaluate evaluates an environment.
+// Evaluate evaluates an environment using the package level Jsonnet settings.
 func Evaluate(a app.App, envName, components, paramsStr string, opts ...jsonnet.VMOpt) (string, error) {
+	return EvaluateWithSettings(a, envName, components, paramsStr, CurrentEvaluateSettings(), opts...)
+}
 
+// EvaluateWithSettings evaluates an environment using settings.
+func EvaluateWithSettings(a app.App, envName, components, paramsStr string, settings EvaluateSettings, opts ...jsonnet.VMOpt) (string, error) {
 	snippet, err := MainFile(a, envName)
 	if err != nil {
 		return "", err
 	}
 
-	evaluated, err := evaluateMain(a, envName, snippet, components, paramsStr, opts...)
+	evaluated, err := evaluateMain(a, envName, snippet, components, paramsStr, settings, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -116,20 +173,45 @@ func Evaluate(a app.App, envName, components, paramsStr string, opts ...jsonnet.
 	return upgradeArray(evaluated)
 }
 
-func evaluateMain(a app.App, envName, snippet, components, paramsStr string, opts ...jsonnet.VMOpt) (string, error) {
-	libPath, err := a.LibPath(envName)
+func evaluateMain(a app.App, envName, snippet, components, paramsStr string, settings EvaluateSettings, opts ...jsonnet.VMOpt) (string, error) {
+	vm, cleanup, err := NewVMWithSettings(a, envName, paramsStr, settings, opts...)
 	if err != nil {
 		return "", err
 	}
+	defer cleanup()
+
+	vm.ExtCode(ComponentsExtCodeKey, components)
+
+	return vm.EvaluateSnippet(envFileName, snippet)
+}
+
+// NewVM creates a Jsonnet VM configured to evaluate sources in an environment.
+// It has the same jpaths, native functions, ext vars, and tla vars as the VM used to
+// evaluate the environment's components, and `paramsStr` is available as
+// `std.extVar("__ksonnet/params")`. The caller is responsible for calling the
+// returned cleanup function once it is done with the VM.
+func NewVM(a app.App, envName, paramsStr string, opts ...jsonnet.VMOpt) (*jsonnet.VM, func() error, error) {
+	return NewVMWithSettings(a, envName, paramsStr, CurrentEvaluateSettings(), opts...)
+}
+
+// NewVMWithSettings creates a Jsonnet VM like NewVM, using settings instead of
+// the package level Jsonnet settings.
+func NewVMWithSettings(a app.App, envName, paramsStr string, settings EvaluateSettings, opts ...jsonnet.VMOpt) (*jsonnet.VM, func() error, error) {
+	noop := func() error { return nil }
+
+	libPath, err := a.LibPath(envName)
+	if err != nil {
+		return nil, noop, err
+	}
 
 	appEnv, err := a.Environment(envName)
 	if err != nil {
-		return "", err
+		return nil, noop, err
 	}
 
 	vm := jsonnet.NewVM(opts...)
 
-	vm.AddJPath(componentJPaths...)
+	vm.AddJPath(settings.JPaths...)
 	vm.AddJPath(
 		filepath.Join(a.Root(), envRootName),
 		filepath.Join(a.Root(), envRootName, appEnv.Path),
@@ -142,13 +224,20 @@ func evaluateMain(a app.App, envName, snippet, components, paramsStr string, opt
 	vm.AddFunctions(helmRenderer.JsonnetNativeFunc())
 
 	// Re-vendor versioned packages, such that import paths will remain path-agnostic.
-	// TODO Where should packagemanager come from?
-	pm := registry.NewPackageManager(a)
-	revendoredPath, cleanup, err := revendorPackages(a, pm, appEnv)
+	pm := settings.PackageManager
+	if pm == nil {
+		pm = registry.NewPackageManager(a)
+	}
+	var revendoredPath string
+	cleanup := noop
+	if settings.VendorCache != nil {
+		revendoredPath, err = settings.VendorCache.Path(a, pm, appEnv)
+	} else {
+		revendoredPath, cleanup, err = revendorPackages(a, pm, appEnv)
+	}
 	if err != nil {
-		return "", errors.Wrapf(err, "revendoring packages for environment: %v", envName)
+		return nil, noop, errors.Wrapf(err, "revendoring packages for environment: %v", envName)
 	}
-	defer cleanup()
 	vm.AddJPath(revendoredPath) // TODO does precedence matter?
 	// end re-vendor
 
@@ -163,22 +252,22 @@ func evaluateMain(a app.App, envName, snippet, components, paramsStr string, opt
 
 	envCode, err := params.JsonnetEnvObject(a, envName)
 	if err != nil {
-		return "", err
+		cleanup()
+		return nil, noop, err
 	}
 
-	for k, v := range componentExtVars {
+	for k, v := range settings.ExtVars {
 		vm.ExtVar(k, v)
 	}
 
-	for k, v := range componentTlaVars {
+	for k, v := range settings.TlaVars {
 		vm.TLAVar(k, v)
 	}
 
 	vm.ExtCode("__ksonnet/environments", envCode)
-	vm.ExtCode(ComponentsExtCodeKey, components)
 	vm.ExtCode("__ksonnet/params", paramsStr)
 
-	return vm.EvaluateSnippet(envFileName, snippet)
+	return vm, cleanup, nil
 }
 
 // upgradeArray wraps component lists in Kubernetes lists.
@@ -279,8 +368,6 @@ func buildPackagePaths(pm registry.PackageManager, e *app.EnvironmentConfig) (ma
 // The caller is responsible for calling the returned cleanup function to release
 // and temporary resources.
 func revendorPackages(a app.App, pm registry.PackageManager, e *app.EnvironmentConfig) (path string, cleanup func() error, err error) {
-	log := log.WithField("action", "env.revendorPackages")
-
 	noop := func() error { return nil }
 
 	if a == nil {
@@ -303,6 +390,17 @@ func revendorPackages(a app.App, pm registry.PackageManager, e *app.EnvironmentC
 		return "", noop, err
 	}
 
+	return copyPackages(fs, pathByPkg)
+}
+
+// copyPackages copies packages to a temporary directory, keyed by their
+// version-agnostic import paths. The caller is responsible for calling the
+// returned cleanup function.
+func copyPackages(fs afero.Fs, pathByPkg map[string]string) (path string, cleanup func() error, err error) {
+	log := log.WithField("action", "env.revendorPackages")
+
+	noop := func() error { return nil }
+
 	// Build our temporary space
 	tmpDir, err := afero.TempDir(fs, "", "ksvendor")
 	if err != nil {
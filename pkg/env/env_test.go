@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/ksonnet/ksonnet/pkg/app"
@@ -243,6 +244,55 @@ func TestEvaluate(t *testing.T) {
 	})
 }
 
+func TestEvaluateWithSettings(t *testing.T) {
+	test.WithApp(t, "/app", func(a *mocks.App, fs afero.Fs) {
+		envSpec := &app.EnvironmentConfig{
+			Path: "default",
+			Destination: &app.EnvironmentDestinationSpec{
+				Server:    "http://example.com",
+				Namespace: "default",
+			},
+		}
+		a.On("Environment", "default").Return(envSpec, nil)
+		a.On("Libraries").Return(app.LibraryConfigs{}, nil)
+		a.On("Registries").Return(app.RegistryConfigs{}, nil)
+
+		main := `{ greeting: { value: std.extVar("greeting") } }`
+		require.NoError(t, afero.WriteFile(fs, "/app/environments/default/main.jsonnet", []byte(main), 0644))
+
+		// renders with different settings can run concurrently, and don't
+		// change the package level settings.
+		current := CurrentEvaluateSettings()
+
+		var wg sync.WaitGroup
+		results := make([]string, 10)
+		errs := make([]error, 10)
+
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				settings := EvaluateSettings{
+					ExtVars: map[string]string{"greeting": fmt.Sprintf("hello %d", i)},
+				}
+
+				results[i], errs[i] = EvaluateWithSettings(a, "default", "{}", "{}", settings)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := range results {
+			require.NoError(t, errs[i])
+			expected := fmt.Sprintf(`{"greeting": {"value": "hello %d"}}`, i)
+			assert.JSONEq(t, expected, results[i])
+		}
+
+		assert.Equal(t, current, CurrentEvaluateSettings())
+	})
+}
+
 func TestEvaluate_versionedPackages(t *testing.T) {
 	require.Empty(t, componentJPaths)
 
@@ -428,3 +478,42 @@ func Test_revendorPackages(t *testing.T) {
 
 	})
 }
+
+func TestVendorCache(t *testing.T) {
+	r := "incubator"
+	e := &app.EnvironmentConfig{Name: "default"}
+
+	pmFor := func(version string) registry.PackageManager {
+		pm := new(rmocks.PackageManager)
+		pm.On("PackagesForEnv", e).Return([]pkg.Package{makePackage(r, "nginx", version, true)}, nil)
+		return pm
+	}
+
+	test.WithApp(t, "/", func(a *mocks.App, fs afero.Fs) {
+		test.StageDir(t, fs, filepath.Join("packages", "nginx"), "/app/vendor/incubator/nginx@1.2.3")
+		test.StageDir(t, fs, filepath.Join("packages", "nginx"), "/app/vendor/incubator/nginx@1.2.4")
+
+		vc := NewVendorCache()
+
+		path, err := vc.Path(a, pmFor("1.2.3"), e)
+		require.NoError(t, err)
+
+		cached, err := vc.Path(a, pmFor("1.2.3"), e)
+		require.NoError(t, err)
+		assert.Equal(t, path, cached, "packages were revendored without a version change")
+
+		upgraded, err := vc.Path(a, pmFor("1.2.4"), e)
+		require.NoError(t, err)
+		assert.NotEqual(t, path, upgraded, "packages were not revendored after a version change")
+
+		exists, err := afero.DirExists(fs, path)
+		require.NoError(t, err)
+		assert.False(t, exists, "outdated packages were not removed: %v", path)
+
+		require.NoError(t, vc.Clear())
+
+		exists, err = afero.DirExists(fs, upgraded)
+		require.NoError(t, err)
+		assert.False(t, exists, "cached packages were not removed: %v", upgraded)
+	})
+}
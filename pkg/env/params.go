@@ -16,10 +16,14 @@
 package env
 
 import (
+	"os"
+	"path/filepath"
+
 	param "github.com/ksonnet/ksonnet/metadata/params"
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/component"
 	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
@@ -67,6 +71,32 @@ func SetGlobalParams(a app.App, envName string, p param.Params) error {
 	return nil
 }
 
+// EvaluateGlobals evaluates an environment's globals.libsonnet. It returns an
+// empty object if the environment has no globals.
+func EvaluateGlobals(a app.App, envName string) (string, error) {
+	path, err := Path(a, envName, globalsFileName)
+	if err != nil {
+		return "", err
+	}
+
+	src, err := afero.ReadFile(a.Fs(), path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "{}", nil
+		}
+		return "", err
+	}
+
+	vm := jsonnet.NewVM()
+	vm.AddJPath(
+		filepath.Dir(path),
+		filepath.Join(a.Root(), "lib"),
+		filepath.Join(a.Root(), "vendor"),
+	)
+
+	return vm.EvaluateSnippet(path, string(src))
+}
+
 // UnsetGlobalParams un-sets global param for an environment.
 func UnsetGlobalParams(a app.App, envName, paramName string) error {
 	if err := ensureEnvExists(a, envName); err != nil {
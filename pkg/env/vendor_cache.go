@@ -0,0 +1,113 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// VendorCache keeps the revendored packages of environments between
+// evaluations. Each environment's packages are copied once, and copied again
+// when the versions of its packages change. It is safe for concurrent use.
+type VendorCache struct {
+	mu   sync.Mutex
+	fs   afero.Fs
+	dirs map[string]vendorDir
+}
+
+type vendorDir struct {
+	key  string
+	path string
+}
+
+// NewVendorCache creates an instance of VendorCache.
+func NewVendorCache() *VendorCache {
+	return &VendorCache{
+		dirs: make(map[string]vendorDir),
+	}
+}
+
+// Path returns the directory containing the revendored packages for an
+// environment. The directory is owned by the cache, and is removed by Clear.
+func (vc *VendorCache) Path(a app.App, pm registry.PackageManager, e *app.EnvironmentConfig) (string, error) {
+	if a == nil {
+		return "", errors.Errorf("nil app")
+	}
+	if e == nil {
+		return "", errors.Errorf("nil environment")
+	}
+
+	pathByPkg, err := buildPackagePaths(pm, e)
+	if err != nil {
+		return "", err
+	}
+
+	// Vendor paths include the package version, e.g. `vendor/incubator/nginx@1.2.3`.
+	var keys []string
+	for k, path := range pathByPkg {
+		keys = append(keys, fmt.Sprintf("%s=%s", k, path))
+	}
+	sort.Strings(keys)
+	key := strings.Join(keys, ",")
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	cached, ok := vc.dirs[e.Name]
+	if ok && cached.key == key {
+		return cached.path, nil
+	}
+
+	path, _, err := copyPackages(a.Fs(), pathByPkg)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		if err := vc.fs.RemoveAll(cached.path); err != nil {
+			return "", errors.Wrapf(err, "removing outdated packages for environment %q", e.Name)
+		}
+	}
+
+	vc.fs = a.Fs()
+	vc.dirs[e.Name] = vendorDir{key: key, path: path}
+
+	return path, nil
+}
+
+// Clear removes the revendored packages of every environment.
+func (vc *VendorCache) Clear() error {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	for name, dir := range vc.dirs {
+		if err := vc.fs.RemoveAll(dir.path); err != nil {
+			return errors.Wrapf(err, "removing packages for environment %q", name)
+		}
+
+		delete(vc.dirs, name)
+	}
+
+	return nil
+}
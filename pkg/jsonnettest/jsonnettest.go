@@ -0,0 +1,255 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+// Package jsonnettest runs Jsonnet unit tests.
+//
+// A test file is a Jsonnet file with a `_test.jsonnet` suffix which evaluates
+// to an object. Each field of the object is a test. A test passes if it
+// evaluates to `true`, or to an object with `actual` and `expected` fields
+// which are equal. A test fails if it evaluates to `false`, if `actual` and
+// `expected` differ, or if its evaluation fails (e.g. with `std.assertEqual`).
+package jsonnettest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// FileSuffix is the suffix of Jsonnet test files.
+	FileSuffix = "_test.jsonnet"
+)
+
+// Evaluator evaluates Jsonnet snippets.
+type Evaluator interface {
+	EvaluateSnippet(name, snippet string) (string, error)
+}
+
+// Result is the result of a single test.
+type Result struct {
+	Name    string
+	Failure string
+	Elapsed time.Duration
+}
+
+// Passed returns true if the test passed.
+func (r *Result) Passed() bool {
+	return r.Failure == ""
+}
+
+// FileResult is the result of the tests in a test file.
+type FileResult struct {
+	// Name is the name of the test file used in reports.
+	Name    string
+	Results []Result
+	Elapsed time.Duration
+	// Err is set if the test file could not be evaluated.
+	Err error
+}
+
+// Passed returns true if the file could be evaluated and all its tests passed.
+func (fr *FileResult) Passed() bool {
+	if fr.Err != nil {
+		return false
+	}
+
+	for _, r := range fr.Results {
+		if !r.Passed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Failures returns the number of failed tests.
+func (fr *FileResult) Failures() int {
+	count := 0
+	for _, r := range fr.Results {
+		if !r.Passed() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Passed returns true if all test files passed.
+func Passed(results []FileResult) bool {
+	for _, fr := range results {
+		if !fr.Passed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Discover finds test files in directories. Missing directories are ignored.
+func Discover(fs afero.Fs, dirs ...string) ([]string, error) {
+	var paths []string
+
+	for _, dir := range dirs {
+		exists, err := afero.DirExists(fs, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking %q", dir)
+		}
+
+		if !exists {
+			continue
+		}
+
+		err = afero.Walk(fs, dir, func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !fi.IsDir() && strings.HasSuffix(path, FileSuffix) {
+				paths = append(paths, path)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding tests in %q", dir)
+		}
+	}
+
+	return paths, nil
+}
+
+// RunFile runs the tests in the test file at path.
+func RunFile(vm Evaluator, name, path string) FileResult {
+	start := time.Now()
+
+	fr := FileResult{Name: name}
+
+	names, err := testNames(vm, path)
+	if err != nil {
+		fr.Err = err
+		fr.Elapsed = time.Since(start)
+		return fr
+	}
+
+	for _, testName := range names {
+		fr.Results = append(fr.Results, runTest(vm, path, testName))
+	}
+
+	fr.Elapsed = time.Since(start)
+	return fr
+}
+
+func testNames(vm Evaluator, path string) ([]string, error) {
+	snippet := fmt.Sprintf(`
+local tests = import %s;
+if std.type(tests) == "object" then std.objectFields(tests)
+else error "test file should evaluate to an object, got " + std.type(tests)
+`, quote(path))
+
+	out, err := vm.EvaluateSnippet(path, snippet)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(out), &names); err != nil {
+		return nil, errors.Wrap(err, "decoding test names")
+	}
+
+	return names, nil
+}
+
+func runTest(vm Evaluator, path, name string) Result {
+	start := time.Now()
+
+	r := Result{Name: name}
+
+	snippet := fmt.Sprintf("(import %s)[%s]", quote(path), quote(name))
+	out, err := vm.EvaluateSnippet(path, snippet)
+	if err != nil {
+		r.Failure = err.Error()
+	} else {
+		r.Failure = checkResult(out)
+	}
+
+	r.Elapsed = time.Since(start)
+	return r
+}
+
+// checkResult checks an evaluated test. It returns a failure message if the
+// test did not pass.
+func checkResult(out string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		return fmt.Sprintf("decoding test result: %v", err)
+	}
+
+	switch t := v.(type) {
+	case bool:
+		if !t {
+			return "test evaluated to false"
+		}
+		return ""
+	case map[string]interface{}:
+		actual, hasActual := t["actual"]
+		expected, hasExpected := t["expected"]
+		if !hasActual || !hasExpected {
+			break
+		}
+
+		if reflect.DeepEqual(actual, expected) {
+			return ""
+		}
+
+		return fmt.Sprintf("actual and expected values differ\nactual:   %s\nexpected: %s",
+			encode(actual), encode(expected))
+	}
+
+	return `test should evaluate to a boolean or to an object with "actual" and "expected" fields`
+}
+
+// quote quotes a string for use in a Jsonnet snippet.
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(data)
+}
+
+// RelativeName returns the name of a test file relative to root.
+func RelativeName(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package jsonnettest
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	files := []string{
+		"/app/lib/helpers.libsonnet",
+		"/app/lib/helpers_test.jsonnet",
+		"/app/components/deployment.jsonnet",
+		"/app/components/nested/service_test.jsonnet",
+		"/app/environments/default/env_test.jsonnet",
+	}
+
+	for _, path := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte("{}"), 0644))
+	}
+
+	got, err := Discover(fs, "/app/lib", "/app/components", "/app/missing")
+	require.NoError(t, err)
+
+	expected := []string{
+		"/app/lib/helpers_test.jsonnet",
+		"/app/components/nested/service_test.jsonnet",
+	}
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRunFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	helpers := `{ double(x):: x * 2 }`
+	tests := `
+local helpers = import "helpers.libsonnet";
+{
+  double: helpers.double(2) == 4,
+  double_equal: { actual: helpers.double(3), expected: 6 },
+  double_false: helpers.double(2) == 5,
+  double_differ: { actual: helpers.double(3), expected: 7 },
+  assert_equal: std.assertEqual(helpers.double(1), 3),
+  invalid: "yes",
+}
+`
+
+	require.NoError(t, afero.WriteFile(fs, "/app/lib/helpers.libsonnet", []byte(helpers), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/app/lib/helpers_test.jsonnet", []byte(tests), 0644))
+
+	vm := jsonnet.NewVM(jsonnet.AferoImporterOpt(fs))
+
+	fr := RunFile(vm, "lib/helpers_test.jsonnet", "/app/lib/helpers_test.jsonnet")
+	require.NoError(t, fr.Err)
+
+	assert.Equal(t, "lib/helpers_test.jsonnet", fr.Name)
+	assert.False(t, fr.Passed())
+	assert.Equal(t, 4, fr.Failures())
+
+	failures := make(map[string]string)
+	for _, r := range fr.Results {
+		failures[r.Name] = r.Failure
+	}
+
+	expected := map[string]string{
+		"assert_equal":  "",
+		"double":        "",
+		"double_differ": "actual and expected values differ\nactual:   6\nexpected: 7",
+		"double_equal":  "",
+		"double_false":  "test evaluated to false",
+		"invalid":       `test should evaluate to a boolean or to an object with "actual" and "expected" fields`,
+	}
+
+	for name, failure := range expected {
+		got, ok := failures[name]
+		require.True(t, ok, "expected result for %s", name)
+
+		if name == "assert_equal" {
+			assert.Contains(t, got, "Assertion failed")
+			continue
+		}
+
+		assert.Equal(t, failure, got, name)
+	}
+}
+
+func TestRunFile_invalid(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/app/lib/bad_test.jsonnet", []byte("[]"), 0644))
+
+	vm := jsonnet.NewVM(jsonnet.AferoImporterOpt(fs))
+
+	fr := RunFile(vm, "lib/bad_test.jsonnet", "/app/lib/bad_test.jsonnet")
+	require.Error(t, fr.Err)
+	assert.Contains(t, fr.Err.Error(), "test file should evaluate to an object")
+	assert.False(t, fr.Passed())
+	assert.False(t, Passed([]FileResult{fr}))
+}
@@ -0,0 +1,167 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package jsonnettest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Fprint prints test results in a format similar to `go test -v`.
+func Fprint(w io.Writer, results []FileResult) error {
+	for _, fr := range results {
+		if fr.Err != nil {
+			fmt.Fprintf(w, "--- FAIL: %s (%s)\n", fr.Name, seconds(fr.Elapsed))
+			fmt.Fprint(w, indent(fr.Err.Error()))
+		}
+
+		for _, r := range fr.Results {
+			status := "PASS"
+			if !r.Passed() {
+				status = "FAIL"
+			}
+
+			fmt.Fprintf(w, "--- %s: %s (%s)\n", status, r.Name, seconds(r.Elapsed))
+			if !r.Passed() {
+				fmt.Fprint(w, indent(r.Failure))
+			}
+		}
+
+		status := "ok  "
+		if !fr.Passed() {
+			status = "FAIL"
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", status, fr.Name, seconds(fr.Elapsed)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func indent(s string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		sb.WriteString("        ")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// WriteJUnit writes test results as JUnit XML. Each test file is a test suite.
+// A test file which could not be evaluated is reported as an error.
+func WriteJUnit(w io.Writer, results []FileResult) error {
+	suites := junitTestSuites{}
+
+	for _, fr := range results {
+		suite := junitTestSuite{
+			Name:     fr.Name,
+			Tests:    len(fr.Results),
+			Failures: fr.Failures(),
+			Time:     junitTime(fr.Elapsed),
+		}
+
+		if fr.Err != nil {
+			suite.Tests++
+			suite.Errors = 1
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      fr.Name,
+				ClassName: fr.Name,
+				Time:      junitTime(fr.Elapsed),
+				Error:     newJUnitMessage(fr.Err.Error()),
+			})
+		}
+
+		for _, r := range fr.Results {
+			tc := junitTestCase{
+				Name:      r.Name,
+				ClassName: fr.Name,
+				Time:      junitTime(r.Elapsed),
+			}
+
+			if !r.Passed() {
+				tc.Failure = newJUnitMessage(r.Failure)
+			}
+
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// newJUnitMessage uses the first line of a failure as its message, and the
+// full failure as its contents.
+func newJUnitMessage(failure string) *junitMessage {
+	return &junitMessage{
+		Message:  strings.SplitN(failure, "\n", 2)[0],
+		Contents: failure,
+	}
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
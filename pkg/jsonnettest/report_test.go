@@ -0,0 +1,73 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package jsonnettest
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportResults() []FileResult {
+	return []FileResult{
+		{
+			Name:    "lib/helpers_test.jsonnet",
+			Elapsed: 20 * time.Millisecond,
+			Results: []Result{
+				{Name: "double", Elapsed: 10 * time.Millisecond},
+				{Name: "triple", Failure: "actual and expected values differ\nactual:   6\nexpected: 9", Elapsed: 10 * time.Millisecond},
+			},
+		},
+		{
+			Name:    "components/service_test.jsonnet",
+			Elapsed: 10 * time.Millisecond,
+			Results: []Result{
+				{Name: "port", Elapsed: 10 * time.Millisecond},
+			},
+		},
+		{
+			Name:    "components/bad_test.jsonnet",
+			Elapsed: 5 * time.Millisecond,
+			Err:     errors.New("test file should evaluate to an object, got array"),
+		},
+	}
+}
+
+func TestFprint(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Fprint(&buf, reportResults()))
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "report.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, reportResults()))
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "junit.xml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), buf.String())
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package ksonnet
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/cluster"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderOpt is an option for a single render.
+type RenderOpt func(*renderConfig)
+
+type renderConfig struct {
+	componentNames []string
+	jPaths         []string
+	extVars        map[string]string
+	tlaVars        map[string]string
+	httpClient     *http.Client
+	packageManager registry.PackageManager
+	cache          pipeline.Cache
+	vendorCache    *env.VendorCache
+}
+
+// WithComponents limits a render to the named components.
+func WithComponents(names ...string) RenderOpt {
+	return func(c *renderConfig) {
+		c.componentNames = append(c.componentNames, names...)
+	}
+}
+
+// WithJPaths adds Jsonnet library paths to a render.
+func WithJPaths(paths ...string) RenderOpt {
+	return func(c *renderConfig) {
+		c.jPaths = append(c.jPaths, paths...)
+	}
+}
+
+// WithExtVar sets a Jsonnet ext var for a render.
+func WithExtVar(key, value string) RenderOpt {
+	return func(c *renderConfig) {
+		c.extVars[key] = value
+	}
+}
+
+// WithTlaVar sets a Jsonnet top level argument for a render.
+func WithTlaVar(key, value string) RenderOpt {
+	return func(c *renderConfig) {
+		c.tlaVars[key] = value
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to resolve registries. It is
+// ignored if a package manager is set with WithPackageManager.
+func WithHTTPClient(client *http.Client) RenderOpt {
+	return func(c *renderConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithPackageManager sets the package manager used to resolve the environment's
+// versioned packages.
+func WithPackageManager(pm registry.PackageManager) RenderOpt {
+	return func(c *renderConfig) {
+		c.packageManager = pm
+	}
+}
+
+// WithRenderCache caches rendered modules in c.
+func WithRenderCache(cache pipeline.Cache) RenderOpt {
+	return func(c *renderConfig) {
+		c.cache = cache
+	}
+}
+
+// WithVendorCache reuses the revendored packages of environments kept in vc,
+// instead of copying them for every render.
+func WithVendorCache(vc *env.VendorCache) RenderOpt {
+	return func(c *renderConfig) {
+		c.vendorCache = vc
+	}
+}
+
+// Renderer renders the environments of a ksonnet application.
+//
+// Unlike the `ks` command line, a Renderer does not use package level state:
+// Jsonnet settings, the HTTP client, and the package manager are options of
+// each render. A Renderer can be used by multiple goroutines, and renderers for
+// different applications can be used concurrently.
+type Renderer struct {
+	app app.App
+}
+
+// NewRenderer creates an instance of Renderer for an application.
+func NewRenderer(a app.App) *Renderer {
+	return &Renderer{app: a}
+}
+
+// Objects renders the objects for an environment. Objects are returned in
+// module order.
+func (r *Renderer) Objects(envName string, opts ...RenderOpt) ([]*unstructured.Unstructured, error) {
+	if r.app == nil {
+		return nil, errors.New("renderer requires an application")
+	}
+
+	config := &renderConfig{
+		extVars: make(map[string]string),
+		tlaVars: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	p := pipeline.New(r.app, envName, r.pipelineOpts(config)...)
+	return p.Objects(config.componentNames)
+}
+
+// YAML renders the objects for an environment as YAML. Objects are written in
+// the same format and order as `ks show`.
+func (r *Renderer) YAML(w io.Writer, envName string, opts ...RenderOpt) error {
+	objects, err := r.Objects(envName, opts...)
+	if err != nil {
+		return err
+	}
+
+	cluster.UnstructuredSlice(objects).Sort()
+	return cluster.ShowYAML(w, objects)
+}
+
+func (r *Renderer) pipelineOpts(config *renderConfig) []pipeline.Opt {
+	pm := config.packageManager
+	if pm == nil {
+		pm = registry.NewPackageManager(r.app, registry.HTTPClientOpt(config.httpClient))
+	}
+
+	settings := env.EvaluateSettings{
+		JPaths:         config.jPaths,
+		ExtVars:        config.extVars,
+		TlaVars:        config.tlaVars,
+		PackageManager: pm,
+		VendorCache:    config.vendorCache,
+	}
+
+	opts := []pipeline.Opt{pipeline.WithEvaluateSettings(settings)}
+	if config.cache != nil {
+		opts = append(opts, pipeline.WithCache(config.cache))
+	}
+
+	return opts
+}
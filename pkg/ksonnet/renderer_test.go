@@ -0,0 +1,163 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package ksonnet
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rendererAppYAML = `apiVersion: 0.3.0
+environments:
+  default:
+    destination:
+      namespace: default
+      server: http://example.com
+    k8sVersion: v1.10.0
+    path: default
+kind: ksonnet.io/app
+name: renderer
+version: 0.0.1
+`
+
+// withRendererApp stages an application with a single `config` component. The
+// component is evaluated with the OS filesystem, so the app is staged in a
+// temporary directory.
+func withRendererApp(t *testing.T, fn func(a app.App)) {
+	root, err := ioutil.TempDir("", "renderer")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"app.yaml":                               rendererAppYAML,
+		"components/params.libsonnet":            `{ global: {}, components: { config: { name: "config" } } }`,
+		"components/config.jsonnet":              configComponent,
+		"environments/base.libsonnet":            string(env.DefaultBaseData),
+		"environments/default/main.jsonnet":      string(env.DefaultOverrideData),
+		"environments/default/params.libsonnet":  string(env.DefaultParamsData),
+		"environments/default/globals.libsonnet": string(env.DefaultGlobalsData),
+		"lib/greeting.libsonnet":                 `{ greeting(name):: "hello " + name }`,
+		"lib/ksonnet-lib/v1.10.0/k.libsonnet":    `{}`,
+		"lib/ksonnet-lib/v1.10.0/k8s.libsonnet":  `{}`,
+	}
+
+	for path, content := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	a, err := app.Load(afero.NewOsFs(), nil, root)
+	require.NoError(t, err)
+
+	fn(a)
+}
+
+const configComponent = `
+local params = std.extVar("__ksonnet/params").components.config;
+local greeting = import "greeting.libsonnet";
+
+{
+  apiVersion: "v1",
+  kind: "ConfigMap",
+  metadata: { name: params.name },
+  data: { greeting: greeting.greeting(std.extVar("name")) },
+}
+`
+
+func TestRenderer_Objects(t *testing.T) {
+	withRendererApp(t, func(a app.App) {
+		r := NewRenderer(a)
+		current := env.CurrentEvaluateSettings()
+
+		// renders with different ext vars run concurrently.
+		var wg sync.WaitGroup
+		greetings := make([]string, 5)
+		errs := make([]error, 5)
+
+		for i := range greetings {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				objects, err := r.Objects("default", WithExtVar("name", fmt.Sprintf("user-%d", i)))
+				if err != nil {
+					errs[i] = err
+					return
+				}
+
+				if len(objects) != 1 {
+					errs[i] = fmt.Errorf("expected 1 object, got %d", len(objects))
+					return
+				}
+
+				data := objects[0].Object["data"].(map[string]interface{})
+				greetings[i] = data["greeting"].(string)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := range greetings {
+			require.NoError(t, errs[i])
+			assert.Equal(t, fmt.Sprintf("hello user-%d", i), greetings[i])
+		}
+
+		assert.Equal(t, current, env.CurrentEvaluateSettings())
+	})
+}
+
+func TestRenderer_YAML(t *testing.T) {
+	withRendererApp(t, func(a app.App) {
+		r := NewRenderer(a)
+
+		var buf bytes.Buffer
+		err := r.YAML(&buf, "default", WithExtVar("name", "world"), WithComponents("config"))
+		require.NoError(t, err)
+
+		expected := `---
+apiVersion: v1
+data:
+  greeting: hello world
+kind: ConfigMap
+metadata:
+  labels:
+    ksonnet.io/component: config
+  name: config
+`
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestRenderer_Objects_missing_ext_var(t *testing.T) {
+	withRendererApp(t, func(a app.App) {
+		r := NewRenderer(a)
+
+		_, err := r.Objects("default")
+		require.Error(t, err)
+	})
+}
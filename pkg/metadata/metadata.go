@@ -29,6 +29,16 @@ const (
 	// AnnotationManaged annotation holds the pristine object.
 	AnnotationManaged = "ksonnet.io/managed"
 
+	// AnnotationProtected annotation protects an object from deletion. Protected
+	// objects are skipped by `ks delete` and garbage collection unless they are
+	// forced. Namespaces, persistent volumes and persistent volume claims are
+	// protected unless the annotation is "false".
+	AnnotationProtected = "ksonnet.io/protected"
+
+	// AnnotationProvenance annotation holds where an object was rendered from.
+	// It is only set when requested, e.g. by `ks show --explain`.
+	AnnotationProvenance = "ksonnet.io/provenance"
+
 	// LabelDeployManager label signifies an object is deployed with ksonnet.
 	LabelDeployManager = "app.kubernetes.io/deploy-manager"
 
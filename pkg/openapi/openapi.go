@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/go-openapi/spec"
 	"github.com/go-openapi/strfmt"
@@ -40,6 +41,65 @@ func ValidateAgainstSchema(a app.App, obj *unstructured.Unstructured, envName st
 	return v.run(a, obj, envName)
 }
 
+// Validator validates documents against the schema. Unlike ValidateAgainstSchema,
+// it caches the schemas it loads, so it should be reused when validating many
+// documents. It is safe for concurrent use.
+type Validator struct {
+	v            *validateAgainstSchema
+	loadSchemaFn func(app.App, string, string) (*spec.Schema, error)
+
+	mu      sync.Mutex
+	schemas map[string]*spec.Schema
+}
+
+// NewValidator creates an instance of Validator.
+func NewValidator() *Validator {
+	v := &Validator{
+		v:            newValidateAgainstSchema(),
+		loadSchemaFn: loadSchema,
+		schemas:      make(map[string]*spec.Schema),
+	}
+
+	v.v.loadSchema = v.cachedSchema
+	return v
+}
+
+// Validate validates a document against the schema.
+func (v *Validator) Validate(a app.App, obj *unstructured.Unstructured, envName string) []error {
+	return v.v.run(a, obj, envName)
+}
+
+// cachedSchema loads a schema definition. Definitions are cached by the
+// environment's lib path, so environments with the same Kubernetes version
+// share them.
+func (v *Validator) cachedSchema(a app.App, name, envName string) (*spec.Schema, error) {
+	libPath, err := a.LibPath(envName)
+	if err != nil {
+		return nil, err
+	}
+
+	key := libPath + "#" + name
+
+	v.mu.Lock()
+	schema, ok := v.schemas[key]
+	v.mu.Unlock()
+
+	if ok {
+		return schema, nil
+	}
+
+	schema, err = v.loadSchemaFn(a, name, envName)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.schemas[key] = schema
+	v.mu.Unlock()
+
+	return schema, nil
+}
+
 type validateAgainstSchema struct {
 	definitionName func(*unstructured.Unstructured) (string, error)
 	loadSchema     func(app.App, string, string) (*spec.Schema, error)
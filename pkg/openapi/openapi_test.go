@@ -65,6 +65,38 @@ func TestValidateAgainstSchema(t *testing.T) {
 	})
 }
 
+func TestValidator(t *testing.T) {
+	test.WithApp(t, "/", func(a *mocks.App, fs afero.Fs) {
+		a.On("LibPath", "default").Return("/lib/v1.10.0", nil)
+		a.On("LibPath", "prod").Return("/lib/v1.10.0", nil)
+
+		obj := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+			},
+		}
+
+		schema := &spec.Schema{}
+		loads := 0
+
+		v := NewValidator()
+		v.loadSchemaFn = func(_ app.App, name, _ string) (*spec.Schema, error) {
+			require.Equal(t, "io.k8s.api.core.v1.Service", name)
+			loads++
+			return schema, nil
+		}
+		v.v.validate = func(s *spec.Schema, data interface{}, formats strfmt.Registry) error {
+			require.Equal(t, schema, s)
+			return nil
+		}
+
+		require.Nil(t, v.Validate(a, obj, "default"))
+		require.Nil(t, v.Validate(a, obj, "prod"))
+		require.Equal(t, 1, loads, "expected schema to be loaded once")
+	})
+}
+
 func Test_definitionName(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -0,0 +1,278 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/pkg/errors"
+)
+
+// ComponentMover renames and moves param configuration for components in
+// module and env params libsonnet files.
+type ComponentMover struct {
+}
+
+// NewComponentMover creates an instance of ComponentMover.
+func NewComponentMover() *ComponentMover {
+	cm := &ComponentMover{}
+
+	return cm
+}
+
+// Rename renames the params for a component from oldName to newName in the
+// jsonnet snippet. The snippet is returned unchanged if it doesn't have params
+// for oldName.
+func (cm *ComponentMover) Rename(oldName, newName, snippet string) (string, error) {
+	n, componentsObj, err := cm.componentsObject(snippet)
+	if err != nil {
+		return "", err
+	}
+
+	i, err := componentFieldIndex(componentsObj, oldName)
+	if err != nil {
+		return "", err
+	}
+
+	if i < 0 {
+		return snippet, nil
+	}
+
+	j, err := componentFieldIndex(componentsObj, newName)
+	if err != nil {
+		return "", err
+	}
+
+	if j >= 0 {
+		return "", errors.Errorf("params for component %q already exist", newName)
+	}
+
+	renamed, err := astext.CreateField(newName)
+	if err != nil {
+		return "", err
+	}
+
+	field := &componentsObj.Fields[i]
+	field.Kind = renamed.Kind
+	field.Id = renamed.Id
+	field.Expr1 = renamed.Expr1
+
+	return printSnippet(n)
+}
+
+// Extract removes the params for a component from the jsonnet snippet. It
+// returns the updated snippet and the removed params, which are nil if the
+// snippet doesn't have params for the component.
+func (cm *ComponentMover) Extract(componentName, snippet string) (string, ast.Node, error) {
+	n, componentsObj, err := cm.componentsObject(snippet)
+	if err != nil {
+		return "", nil, err
+	}
+
+	i, err := componentFieldIndex(componentsObj, componentName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if i < 0 {
+		return snippet, nil, nil
+	}
+
+	params := componentsObj.Fields[i].Expr2
+	componentsObj.Fields = append(componentsObj.Fields[:i], componentsObj.Fields[i+1:]...)
+
+	updated, err := printSnippet(n)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return updated, params, nil
+}
+
+// Insert adds params for a component to the jsonnet snippet. It is an error
+// if the snippet already has params for the component.
+func (cm *ComponentMover) Insert(componentName, snippet string, params ast.Node) (string, error) {
+	n, componentsObj, err := cm.componentsObject(snippet)
+	if err != nil {
+		return "", err
+	}
+
+	i, err := componentFieldIndex(componentsObj, componentName)
+	if err != nil {
+		return "", err
+	}
+
+	if i >= 0 {
+		return "", errors.Errorf("params for component %q already exist", componentName)
+	}
+
+	of, err := astext.CreateField(componentName)
+	if err != nil {
+		return "", err
+	}
+	of.Expr2 = params
+	of.Hide = ast.ObjectFieldInherit
+
+	componentsObj.Fields = append(componentsObj.Fields, *of)
+
+	return printSnippet(n)
+}
+
+// RenameRefs rewrites references to the params of component oldName, e.g.
+// `{ "__ksonnet/ref": "oldName.image" }`, to point at newName. References are
+// resolved within a module, so only the params of components for which
+// include returns true are rewritten.
+func (cm *ComponentMover) RenameRefs(oldName, newName, snippet string, include func(componentName string) bool) (string, error) {
+	n, componentsObj, err := cm.componentsObject(snippet)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := oldName + "."
+	changed := false
+
+	err = walkComponentRefs(componentsObj, include, func(_ string, target *ast.LiteralString) {
+		if strings.HasPrefix(target.Value, prefix) {
+			target.Value = newName + "." + strings.TrimPrefix(target.Value, prefix)
+			changed = true
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if !changed {
+		return snippet, nil
+	}
+
+	return printSnippet(n)
+}
+
+// Refs returns the reference targets in the params of components for which
+// include returns true, keyed by component name.
+func (cm *ComponentMover) Refs(snippet string, include func(componentName string) bool) (map[string][]string, error) {
+	_, componentsObj, err := cm.componentsObject(snippet)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string][]string)
+	err = walkComponentRefs(componentsObj, include, func(componentName string, target *ast.LiteralString) {
+		refs[componentName] = append(refs[componentName], target.Value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// walkComponentRefs calls fn with the target of each reference in the params
+// of components for which include returns true.
+func walkComponentRefs(componentsObj *astext.Object, include func(string) bool, fn func(componentName string, target *ast.LiteralString)) error {
+	for i := range componentsObj.Fields {
+		id, err := jsonnet.FieldID(componentsObj.Fields[i])
+		if err != nil {
+			return err
+		}
+
+		if !include(id) {
+			continue
+		}
+
+		walkRefs(componentsObj.Fields[i].Expr2, func(target *ast.LiteralString) {
+			fn(id, target)
+		})
+	}
+
+	return nil
+}
+
+func walkRefs(n ast.Node, fn func(target *ast.LiteralString)) {
+	switch t := n.(type) {
+	case *astext.Object:
+		if len(t.Fields) == 1 {
+			if id, err := jsonnet.FieldID(t.Fields[0]); err == nil && id == RefKey {
+				if target, ok := t.Fields[0].Expr2.(*ast.LiteralString); ok {
+					fn(target)
+					return
+				}
+			}
+		}
+
+		for i := range t.Fields {
+			walkRefs(t.Fields[i].Expr2, fn)
+		}
+	case *ast.Array:
+		for _, item := range t.Elements {
+			walkRefs(item, fn)
+		}
+	}
+}
+
+func (cm *ComponentMover) componentsObject(snippet string) (ast.Node, *astext.Object, error) {
+	n, err := jsonnet.ParseNode("params.libsonnet", snippet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	obj, err := componentParams(n, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	of, err := findField(obj, "components")
+	if err != nil {
+		return nil, nil, errors.Wrap(errUnsupportedEnvParams, "unable to find components field")
+	}
+
+	componentsObj, ok := of.Expr2.(*astext.Object)
+	if !ok {
+		return nil, nil, errors.Wrap(errUnsupportedEnvParams, "components field is not an object")
+	}
+
+	return n, componentsObj, nil
+}
+
+// componentFieldIndex returns the index of a component's field in a
+// components object, or -1 if it doesn't have one.
+func componentFieldIndex(componentsObj *astext.Object, componentName string) (int, error) {
+	for i := range componentsObj.Fields {
+		id, err := jsonnet.FieldID(componentsObj.Fields[i])
+		if err != nil {
+			return -1, err
+		}
+
+		if id == componentName {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+func printSnippet(n ast.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := jsonnetPrinterFn(&buf, n); err != nil {
+		return "", errors.Wrap(err, "unable to update snippet")
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentMover_Rename(t *testing.T) {
+	cases := []struct {
+		name    string
+		oldName string
+		input   string
+		output  string
+		isErr   bool
+	}{
+		{
+			name:    "no globals",
+			oldName: "guestbook",
+			input:   filepath.Join("env", "no-globals", "remove-component", "in.libsonnet"),
+			output:  filepath.Join("env", "no-globals", "rename-component", "out.libsonnet"),
+		},
+		{
+			name:    "globals",
+			oldName: "guestbook",
+			input:   filepath.Join("env", "globals", "remove-component", "in.libsonnet"),
+			output:  filepath.Join("env", "globals", "rename-component", "out.libsonnet"),
+		},
+		{
+			name:    "missing component",
+			oldName: "missing",
+			input:   filepath.Join("env", "globals", "remove-component", "in.libsonnet"),
+			output:  filepath.Join("env", "globals", "remove-component", "in.libsonnet"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			snippet := test.ReadTestData(t, tc.input)
+
+			cm := NewComponentMover()
+
+			got, err := cm.Rename(tc.oldName, "web.guestbook", snippet)
+			require.NoError(t, err)
+
+			expected := test.ReadTestData(t, tc.output)
+			require.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestComponentMover_Rename_exists(t *testing.T) {
+	snippet := `{ components: { a: { x: 1 }, b: { x: 2 } } }`
+
+	cm := NewComponentMover()
+
+	_, err := cm.Rename("a", "b", snippet)
+	require.Error(t, err)
+}
+
+func TestComponentMover_Extract_Insert(t *testing.T) {
+	src := `{ global: {}, components: { a: { x: 1 }, b: { x: 2 } } }`
+	dest := `{ global: {}, components: { c: { x: 3 } } }`
+
+	cm := NewComponentMover()
+
+	updated, params, err := cm.Extract("a", src)
+	require.NoError(t, err)
+	require.NotNil(t, params)
+
+	assert.Equal(t, `{ global: {}, components: { b: { x: 2 } } }`, updated)
+
+	inserted, err := cm.Insert("d", dest, params)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{ global: {}, components: { c: { x: 3 }, d: { x: 1 } } }`, inserted)
+
+	_, err = cm.Insert("c", dest, params)
+	require.Error(t, err)
+
+	unchanged, params, err := cm.Extract("missing", src)
+	require.NoError(t, err)
+	assert.Nil(t, params)
+	assert.Equal(t, src, unchanged)
+}
+
+func TestComponentMover_RenameRefs(t *testing.T) {
+	snippet := `{ components: { a: { x: 1 }, b: { x: { "__ksonnet/ref": "a.x" }, y: [{ "__ksonnet/ref": "a.x.y" }], z: { "__ksonnet/ref": "ab.x" } }, c: { x: { "__ksonnet/ref": "a.x" } } } }`
+
+	cm := NewComponentMover()
+
+	got, err := cm.RenameRefs("a", "d", snippet, func(name string) bool { return name != "c" })
+	require.NoError(t, err)
+
+	expected := `{ components: { a: { x: 1 }, b: { x: { "__ksonnet/ref": 'd.x' }, y: [{ "__ksonnet/ref": 'd.x.y' }], z: { "__ksonnet/ref": 'ab.x' } }, c: { x: { "__ksonnet/ref": 'a.x' } } } }`
+	assert.Equal(t, expected, got)
+
+	unchanged, err := cm.RenameRefs("missing", "d", snippet, func(string) bool { return true })
+	require.NoError(t, err)
+	assert.Equal(t, snippet, unchanged)
+}
+
+func TestComponentMover_Refs(t *testing.T) {
+	snippet := `{ components: { a: { x: 1 }, b: { x: { "__ksonnet/ref": "a.x" }, y: [{ "__ksonnet/ref": "c.y" }] }, c: { x: { "__ksonnet/ref": "a.x" } } } }`
+
+	cm := NewComponentMover()
+
+	got, err := cm.Refs(snippet, func(name string) bool { return name != "c" })
+	require.NoError(t, err)
+
+	expected := map[string][]string{
+		"b": {"a.x", "c.y"},
+	}
+	assert.Equal(t, expected, got)
+}
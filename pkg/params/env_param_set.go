@@ -17,6 +17,7 @@ package params
 
 import (
 	"bytes"
+	"sort"
 
 	"github.com/google/go-jsonnet/ast"
 	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
@@ -37,8 +38,29 @@ func NewEnvParamSet() *EnvParamSet {
 	return epa
 }
 
-// Set sets params in environment parameter files.
+// Set sets params in environment parameter files. Param values are
+// decoded with jsonnet.DecodeValue.
 func (epa *EnvParamSet) Set(componentName, snippet string, p params.Params) (string, error) {
+	values := make(map[string]interface{})
+	for key := range p {
+		s, err := p.StringValue(key)
+		if err != nil {
+			return "", err
+		}
+
+		decoded, err := jsonnet.DecodeValue(s)
+		if err != nil {
+			return "", err
+		}
+
+		values[key] = decoded
+	}
+
+	return epa.SetValues(componentName, snippet, values)
+}
+
+// SetValues sets decoded param values in environment parameter files.
+func (epa *EnvParamSet) SetValues(componentName, snippet string, values map[string]interface{}) (string, error) {
 	if componentName == "" {
 		return "", errors.New("component name was blank")
 	}
@@ -56,7 +78,7 @@ func (epa *EnvParamSet) Set(componentName, snippet string, p params.Params) (str
 		return "", err
 	}
 
-	if err = epa.setParams(obj, componentName, p); err != nil {
+	if err = epa.setParams(obj, componentName, values); err != nil {
 		return "", errors.Wrap(err, "set params")
 	}
 
@@ -69,7 +91,7 @@ func (epa *EnvParamSet) Set(componentName, snippet string, p params.Params) (str
 	return buf.String(), nil
 }
 
-func (epa *EnvParamSet) setParams(obj *astext.Object, componentName string, p params.Params) error {
+func (epa *EnvParamSet) setParams(obj *astext.Object, componentName string, values map[string]interface{}) error {
 	of, err := findField(obj, "components")
 	if err != nil {
 		return errors.Wrap(errUnsupportedEnvParams, "unable to find components field")
@@ -100,18 +122,14 @@ func (epa *EnvParamSet) setParams(obj *astext.Object, componentName string, p pa
 		componentsObj.Fields = append(componentsObj.Fields, *of)
 	}
 
-	for key := range p {
-		s, err := p.StringValue(key)
-		if err != nil {
-			return err
-		}
-
-		decoded, err := jsonnet.DecodeValue(s)
-		if err != nil {
-			return err
-		}
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		value, err := nm.ValueToNoder(decoded)
+	for _, key := range keys {
+		value, err := nm.ValueToNoder(values[key])
 		if err != nil {
 			return err
 		}
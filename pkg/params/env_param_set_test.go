@@ -75,3 +75,21 @@ func TestEnvParamSet(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvParamSet_SetValues(t *testing.T) {
+	snippet := test.ReadTestData(t, filepath.Join("env", "no-globals", "set", "in.libsonnet"))
+
+	epa := NewEnvParamSet()
+
+	values := map[string]interface{}{
+		"containerPort": "8080",
+		"labels":        map[string]interface{}{"app": "guestbook"},
+		"replicas":      3.0,
+	}
+
+	got, err := epa.SetValues("guestbook", snippet, values)
+	require.NoError(t, err)
+
+	expected := test.ReadTestData(t, filepath.Join("env", "no-globals", "set", "out-values.libsonnet"))
+	require.Equal(t, expected, got)
+}
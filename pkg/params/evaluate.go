@@ -24,7 +24,8 @@ import (
 	"github.com/spf13/afero"
 )
 
-// EvaluateEnv evaluates environment parameters.
+// EvaluateEnv evaluates environment parameters. Parameter references are resolved
+// after the environment overrides have been applied.
 func EvaluateEnv(a app.App, sourcePath, paramsStr, envName, moduleName string) (string, error) {
 	snippet, err := afero.ReadFile(a.Fs(), sourcePath)
 	if err != nil {
@@ -48,6 +49,11 @@ func EvaluateEnv(a app.App, sourcePath, paramsStr, envName, moduleName string) (
 		return "", errors.Wrapf(err, "evaluating parameters for module %q in environment %q", moduleName, envName)
 	}
 
+	envParams, err = resolveEnvRefs(envParams)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving parameter references for module %q in environment %q", moduleName, envName)
+	}
+
 	return envParams, nil
 }
 
@@ -0,0 +1,168 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
+	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/printer"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/pkg/errors"
+)
+
+// FieldSource is the literal source of a field in a Jsonnet document.
+type FieldSource struct {
+	// Line is the line the field's value starts on.
+	Line int
+	// Source is the field's value as Jsonnet.
+	Source string
+}
+
+// FindFieldSources finds the fields at path in a Jsonnet document. If
+// anywhere is true, path can start in any object in the document, e.g. in
+// an object which is bound to a local. Otherwise path starts at the root object.
+// Fields are returned in the order they appear.
+func FindFieldSources(filename, src string, path []string, anywhere bool) ([]FieldSource, error) {
+	if len(path) == 0 {
+		return nil, errors.New("field path is empty")
+	}
+
+	node, err := jsonnet.ParseNode(filename, src)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &fieldFinder{path: path}
+
+	if anywhere {
+		f.walk(node)
+	} else {
+		f.match(node, path)
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.sources, nil
+}
+
+type fieldFinder struct {
+	path    []string
+	sources []FieldSource
+	err     error
+}
+
+// walk matches the path in every object in node.
+func (f *fieldFinder) walk(node ast.Node) {
+	if node == nil || f.err != nil {
+		return
+	}
+
+	if _, ok := node.(*astext.Object); ok {
+		f.match(node, f.path)
+	}
+
+	for _, child := range children(node) {
+		f.walk(child)
+	}
+}
+
+// match matches path starting at node.
+func (f *fieldFinder) match(node ast.Node, path []string) {
+	if f.err != nil {
+		return
+	}
+
+	switch t := node.(type) {
+	case *astext.Object:
+		for _, field := range t.Fields {
+			id, err := jsonnet.FieldID(field)
+			if err != nil || id != path[0] {
+				continue
+			}
+
+			if len(path) > 1 {
+				f.match(field.Expr2, path[1:])
+				continue
+			}
+
+			line := field.Expr2.Loc().Begin.Line
+
+			// print values on a single line like Lister does.
+			switch v := field.Expr2.(type) {
+			case *astext.Object:
+				v.Oneline = true
+			case *ast.Array:
+				loc := v.NodeBase.Loc()
+				loc.Begin.Line = loc.End.Line
+			}
+
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, field.Expr2); err != nil {
+				f.err = errors.Wrapf(err, "printing %s", strings.Join(f.path, "."))
+				return
+			}
+
+			f.sources = append(f.sources, FieldSource{
+				Line:   line,
+				Source: strings.TrimSpace(buf.String()),
+			})
+		}
+	case *ast.Binary:
+		// objects which are merged with `+` both contribute fields.
+		f.match(t.Left, path)
+		f.match(t.Right, path)
+	case *ast.ApplyBrace:
+		f.match(t.Left, path)
+		f.match(t.Right, path)
+	case *ast.Local:
+		f.match(t.Body, path)
+	case *ast.Parens:
+		f.match(t.Inner, path)
+	}
+}
+
+// children returns the nodes which can contain objects.
+func children(node ast.Node) []ast.Node {
+	switch t := node.(type) {
+	case *astext.Object:
+		var nodes []ast.Node
+		for _, field := range t.Fields {
+			nodes = append(nodes, field.Expr2)
+		}
+		return nodes
+	case *ast.Binary:
+		return []ast.Node{t.Left, t.Right}
+	case *ast.ApplyBrace:
+		return []ast.Node{t.Left, t.Right}
+	case *ast.Local:
+		nodes := []ast.Node{t.Body}
+		for _, bind := range t.Binds {
+			nodes = append(nodes, bind.Body)
+		}
+		return nodes
+	case *ast.Parens:
+		return []ast.Node{t.Inner}
+	case *ast.Conditional:
+		return []ast.Node{t.BranchTrue, t.BranchFalse}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const explainEnvParams = `local params = std.extVar("__ksonnet/params");
+local globals = import "globals.libsonnet";
+local envParams = params + {
+  components+: {
+    web+: {
+      replicas: 3,
+      labels: { app: "web" },
+    },
+  },
+};
+
+{
+  components: {
+    [x]: envParams.components[x] + globals
+    for x in std.objectFields(envParams.components)
+  },
+}
+`
+
+const explainModuleParams = `{
+  global: {
+    replicas: 2,
+  },
+  components: {
+    web: {
+      replicas: 1,
+      nested: { replicas: 5 },
+    },
+  },
+}
+`
+
+func TestFindFieldSources(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		path     []string
+		anywhere bool
+		expected []FieldSource
+		isErr    bool
+	}{
+		{
+			name:     "env params",
+			src:      explainEnvParams,
+			path:     []string{"components", "web", "replicas"},
+			anywhere: true,
+			expected: []FieldSource{{Line: 6, Source: "3"}},
+		},
+		{
+			name:     "object value",
+			src:      explainEnvParams,
+			path:     []string{"components", "web", "labels"},
+			anywhere: true,
+			expected: []FieldSource{{Line: 7, Source: "{ app: 'web' }"}},
+		},
+		{
+			name:     "module params",
+			src:      explainModuleParams,
+			path:     []string{"components", "web", "replicas"},
+			expected: []FieldSource{{Line: 7, Source: "1"}},
+		},
+		{
+			name:     "module globals",
+			src:      explainModuleParams,
+			path:     []string{"global", "replicas"},
+			expected: []FieldSource{{Line: 3, Source: "2"}},
+		},
+		{
+			name: "root only",
+			src:  explainModuleParams,
+			path: []string{"replicas"},
+		},
+		{
+			name: "missing",
+			src:  explainModuleParams,
+			path: []string{"components", "db", "replicas"},
+		},
+		{
+			name:  "invalid source",
+			src:   "{",
+			path:  []string{"components"},
+			isErr: true,
+		},
+		{
+			name:  "empty path",
+			src:   explainModuleParams,
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FindFieldSources("params.libsonnet", tc.src, tc.path, tc.anywhere)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/google/go-jsonnet/ast"
 	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
+	nm "github.com/ksonnet/ksonnet-lib/ksonnet-gen/nodemaker"
 	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/printer"
 	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
@@ -38,8 +39,12 @@ type Entry struct {
 	ComponentName string
 	// ParamName is the name of the parameter.
 	ParamName string
-	// Value is the value of the parameter.
+	// Value is the value of the parameter. If the parameter is a reference,
+	// this is the resolved value.
 	Value string
+	// Reference is the target of the parameter if it references another
+	// component's parameter.
+	Reference string
 }
 
 // Lister lists parameters.
@@ -73,7 +78,12 @@ func (l *Lister) List(r io.Reader, componentName string) ([]Entry, error) {
 
 	source := string(data)
 
-	object, err := l.buildObject(source)
+	output, err := l.evaluate(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluating parameters")
+	}
+
+	object, err := l.buildObject(output)
 	if err != nil {
 		return nil, errors.Wrap(err, "building params object")
 	}
@@ -112,6 +122,10 @@ func (l *Lister) List(r io.Reader, componentName string) ([]Entry, error) {
 		entries = append(entries, paramEntries...)
 	}
 
+	if err := l.resolveReferences(output, entries); err != nil {
+		return nil, errors.Wrap(err, "resolving parameter references")
+	}
+
 	sort.Slice(entries, func(i, j int) bool {
 		if entries[i].ComponentName < entries[j].ComponentName {
 			return true
@@ -150,12 +164,12 @@ func (l *Lister) componentsObject(object *astext.Object) (*astext.Object, error)
 	return nil, errors.Errorf("unable to find components object")
 }
 
-// buildObject converts params.libsonnet source into a Jsonnet object.
-func (l *Lister) buildObject(source string) (*astext.Object, error) {
+// evaluate evaluates params.libsonnet source.
+func (l *Lister) evaluate(source string) (string, error) {
 	// TODO: this code is repeated in module.Resolveparams, and should be centralized.
 	envCode, err := l.destinationObject()
 	if err != nil {
-		return nil, errors.Wrap(err, "building environment object")
+		return "", errors.Wrap(err, "building environment object")
 	}
 
 	vm := jsonnet.NewVM()
@@ -169,9 +183,14 @@ func (l *Lister) buildObject(source string) (*astext.Object, error) {
 
 	output, err := vm.EvaluateSnippet("params.libsonnet", source)
 	if err != nil {
-		return nil, errors.Wrap(err, "evaluating params.libsonnet")
+		return "", errors.Wrap(err, "evaluating params.libsonnet")
 	}
 
+	return output, nil
+}
+
+// buildObject converts evaluated params.libsonnet into a Jsonnet object.
+func (l *Lister) buildObject(output string) (*astext.Object, error) {
 	n, err := jsonnet.ParseNode("params.libsonnet", output)
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing parameters")
@@ -185,6 +204,47 @@ func (l *Lister) buildObject(source string) (*astext.Object, error) {
 	return object, nil
 }
 
+// resolveReferences updates entries which reference other component parameters
+// with their target and resolved value.
+func (l *Lister) resolveReferences(output string, entries []Entry) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &m); err != nil {
+		return errors.Wrap(err, "decoding parameters")
+	}
+
+	components, ok := m["components"].(map[string]interface{})
+	if !ok || !HasRefs(components) {
+		return nil
+	}
+
+	for i := range entries {
+		componentParams, ok := components[entries[i].ComponentName].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		target, ok := RefTarget(componentParams[entries[i].ParamName])
+		if !ok {
+			continue
+		}
+
+		resolved, err := ResolveRef(components, target)
+		if err != nil {
+			return errors.Wrapf(err, "resolving %s.%s", entries[i].ComponentName, entries[i].ParamName)
+		}
+
+		val, err := valueAsString(resolved)
+		if err != nil {
+			return err
+		}
+
+		entries[i].Reference = target
+		entries[i].Value = val
+	}
+
+	return nil
+}
+
 func (l *Lister) destinationObject() (string, error) {
 	dest := map[string]string{
 		"server":    l.Destination.Server,
@@ -239,6 +299,36 @@ func objectValueAsString(source, key string) (string, error) {
 	return "", errors.Errorf("object did not contain key %q", key)
 }
 
+// FormatValue formats a decoded parameter value the same way Lister formats
+// parameter values.
+func FormatValue(v interface{}) (string, error) {
+	return valueAsString(v)
+}
+
+// valueAsString converts a value to a string using the same format as
+// objectValueAsString.
+func valueAsString(v interface{}) (string, error) {
+	noder, err := nm.ValueToNoder(v)
+	if err != nil {
+		return "", errors.Wrap(err, "converting value to node")
+	}
+
+	object := &astext.Object{}
+	f, err := astext.CreateField("value")
+	if err != nil {
+		return "", err
+	}
+	f.Expr2 = noder.Node()
+	object.Fields = append(object.Fields, *f)
+
+	var buf bytes.Buffer
+	if err = printer.Fprint(&buf, object); err != nil {
+		return "", errors.Wrap(err, "converting node to text")
+	}
+
+	return objectValueAsString(buf.String(), "value")
+}
+
 // entryCreator creates Entry from a param object.
 type entryCreator struct {
 	idField func(astext.ObjectField) (string, error)
@@ -102,6 +102,37 @@ func TestLister_List(t *testing.T) {
 	}
 }
 
+func TestLister_List_references(t *testing.T) {
+	dest := app.EnvironmentDestinationSpec{
+		Server:    "https://example.com",
+		Namespace: "default",
+	}
+
+	l := NewLister("/app", dest)
+
+	source := test.ReadTestData(t, "lister-params-refs.libsonnet")
+	r := strings.NewReader(source)
+
+	got, err := l.List(r, "")
+	require.NoError(t, err)
+
+	expected := []Entry{
+		{
+			ComponentName: "db",
+			ParamName:     "service_name",
+			Value:         `'db-svc'`,
+		},
+		{
+			ComponentName: "web",
+			ParamName:     "db_host",
+			Value:         `'db-svc'`,
+			Reference:     "db.service_name",
+		},
+	}
+
+	assert.Equal(t, expected, got)
+}
+
 func Test_objectValueAsString(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -167,7 +167,10 @@ func ToMap(componentName, src, root string) (map[string]interface{}, error) {
 func mergeMaps(m1 map[string]interface{}, m2 map[string]interface{}, path []string) error {
 	for k := range m2 {
 		_, ok := m1[k]
-		if ok {
+		if ok && (isRef(m1[k]) || isRef(m2[k])) {
+			// references replace values rather than being merged into them.
+			m1[k] = m2[k]
+		} else if ok {
 			v1, isMap1 := m1[k].(map[string]interface{})
 			v2, isMap2 := m2[k].(map[string]interface{})
 			if isMap1 && isMap2 {
@@ -189,6 +192,11 @@ func mergeMaps(m1 map[string]interface{}, m2 map[string]interface{}, path []stri
 	return nil
 }
 
+func isRef(v interface{}) bool {
+	_, ok := RefTarget(v)
+	return ok
+}
+
 func componentParams(node ast.Node, componentName string) (*astext.Object, error) {
 	switch t := node.(type) {
 	default:
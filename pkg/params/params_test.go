@@ -507,3 +507,25 @@ func Test_mergeMaps_simple(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, m1)
 }
+
+func Test_mergeMaps_reference(t *testing.T) {
+	m1 := map[string]interface{}{
+		"a": "value",
+		"b": map[string]interface{}{RefKey: "other.b"},
+	}
+
+	m2 := map[string]interface{}{
+		"a": map[string]interface{}{RefKey: "other.a"},
+		"b": 4,
+	}
+
+	expected := map[string]interface{}{
+		"a": map[string]interface{}{RefKey: "other.a"},
+		"b": 4,
+	}
+
+	err := mergeMaps(m1, m2, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, m1)
+}
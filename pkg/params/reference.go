@@ -0,0 +1,235 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RefKey is the key of the object which marks a parameter value as a
+	// reference to another component's parameter. A reference is stored in
+	// params.libsonnet as `{ "__ksonnet/ref": "component.param" }`.
+	RefKey = "__ksonnet/ref"
+)
+
+// NewRef creates a reference parameter value pointing at `target`. The target
+// is in the form `component.param[.nested]`.
+func NewRef(target string) (map[string]interface{}, error) {
+	if _, _, err := ParseRef(target); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{RefKey: target}, nil
+}
+
+// RefTarget returns the target of a reference parameter value. If the value
+// is not a reference, it returns false.
+func RefTarget(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return "", false
+	}
+
+	target, ok := m[RefKey].(string)
+	return target, ok
+}
+
+// ParseRef splits a reference target into a component name and a parameter path.
+func ParseRef(target string) (string, []string, error) {
+	parts := strings.Split(target, ".")
+	if len(parts) < 2 {
+		return "", nil, errors.Errorf("reference %q should be in the form component.param", target)
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return "", nil, errors.Errorf("reference %q contains an empty segment", target)
+		}
+	}
+
+	return parts[0], parts[1:], nil
+}
+
+// ResolveRefs resolves parameter references in a components object. The
+// components object is keyed by component name and is updated in place.
+// References can point at other references; cycles are reported as errors.
+func ResolveRefs(components map[string]interface{}) error {
+	r := &refResolver{
+		components: components,
+		resolved:   make(map[string]interface{}),
+	}
+
+	for name, v := range components {
+		resolved, err := r.resolveValue(v, nil)
+		if err != nil {
+			return errors.Wrapf(err, "resolving parameters for component %q", name)
+		}
+
+		components[name] = resolved
+	}
+
+	return nil
+}
+
+// ResolveRef resolves a single reference target against a components object.
+func ResolveRef(components map[string]interface{}, target string) (interface{}, error) {
+	r := &refResolver{
+		components: components,
+		resolved:   make(map[string]interface{}),
+	}
+
+	return r.resolveTarget(target, nil)
+}
+
+// HasRefs returns true if a value contains a reference.
+func HasRefs(v interface{}) bool {
+	if _, ok := RefTarget(v); ok {
+		return true
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, item := range t {
+			if HasRefs(item) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range t {
+			if HasRefs(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolveEnvRefs resolves references in evaluated environment parameters. If
+// the parameters do not contain references, they are returned unchanged.
+func resolveEnvRefs(envParams string) (string, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(envParams), &m); err != nil {
+		return "", errors.Wrap(err, "decoding environment parameters")
+	}
+
+	components, ok := m["components"].(map[string]interface{})
+	if !ok || !HasRefs(components) {
+		return envParams, nil
+	}
+
+	if err := ResolveRefs(components); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", errors.Wrap(err, "encoding environment parameters")
+	}
+
+	return string(data), nil
+}
+
+type refResolver struct {
+	components map[string]interface{}
+	resolved   map[string]interface{}
+}
+
+func (r *refResolver) resolveValue(v interface{}, chain []string) (interface{}, error) {
+	if target, ok := RefTarget(v); ok {
+		return r.resolveTarget(target, chain)
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			resolved, err := r.resolveValue(item, chain)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			resolved, err := r.resolveValue(item, chain)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func (r *refResolver) resolveTarget(target string, chain []string) (interface{}, error) {
+	if v, ok := r.resolved[target]; ok {
+		return v, nil
+	}
+
+	for _, seen := range chain {
+		if seen == target {
+			return nil, errors.Errorf("reference cycle detected: %s",
+				strings.Join(append(chain, target), " -> "))
+		}
+	}
+
+	componentName, path, err := ParseRef(target)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, ok := r.components[componentName]
+	if !ok {
+		return nil, errors.Errorf("reference %q points at unknown component %q", target, componentName)
+	}
+
+	for _, k := range path {
+		// a reference may point through another reference.
+		if nested, isRef := RefTarget(cur); isRef {
+			cur, err = r.resolveTarget(nested, append(chain, target))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("reference %q does not point at a parameter", target)
+		}
+
+		cur, ok = m[k]
+		if !ok {
+			return nil, errors.Errorf("reference %q points at unknown parameter", target)
+		}
+	}
+
+	v, err := r.resolveValue(cur, append(chain, target))
+	if err != nil {
+		return nil, err
+	}
+
+	r.resolved[target] = v
+	return v, nil
+}
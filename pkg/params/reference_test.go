@@ -0,0 +1,161 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ref(target string) map[string]interface{} {
+	return map[string]interface{}{RefKey: target}
+}
+
+func TestNewRef(t *testing.T) {
+	got, err := NewRef("db.service_name")
+	require.NoError(t, err)
+	assert.Equal(t, ref("db.service_name"), got)
+
+	_, err = NewRef("db")
+	require.Error(t, err)
+
+	_, err = NewRef("db..name")
+	require.Error(t, err)
+}
+
+func TestResolveRefs(t *testing.T) {
+	cases := []struct {
+		name       string
+		components map[string]interface{}
+		expected   map[string]interface{}
+		isErr      bool
+	}{
+		{
+			name: "no references",
+			components: map[string]interface{}{
+				"db": map[string]interface{}{"name": "db"},
+			},
+			expected: map[string]interface{}{
+				"db": map[string]interface{}{"name": "db"},
+			},
+		},
+		{
+			name: "reference",
+			components: map[string]interface{}{
+				"db":  map[string]interface{}{"service_name": "db-svc"},
+				"web": map[string]interface{}{"db_host": ref("db.service_name")},
+			},
+			expected: map[string]interface{}{
+				"db":  map[string]interface{}{"service_name": "db-svc"},
+				"web": map[string]interface{}{"db_host": "db-svc"},
+			},
+		},
+		{
+			name: "nested path",
+			components: map[string]interface{}{
+				"db":  map[string]interface{}{"service": map[string]interface{}{"port": 5432.0}},
+				"web": map[string]interface{}{"db_port": ref("db.service.port")},
+			},
+			expected: map[string]interface{}{
+				"db":  map[string]interface{}{"service": map[string]interface{}{"port": 5432.0}},
+				"web": map[string]interface{}{"db_port": 5432.0},
+			},
+		},
+		{
+			name: "chained references",
+			components: map[string]interface{}{
+				"a": map[string]interface{}{"x": ref("b.y")},
+				"b": map[string]interface{}{"y": ref("c.z")},
+				"c": map[string]interface{}{"z": "value"},
+			},
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{"x": "value"},
+				"b": map[string]interface{}{"y": "value"},
+				"c": map[string]interface{}{"z": "value"},
+			},
+		},
+		{
+			name: "reference in array",
+			components: map[string]interface{}{
+				"db":  map[string]interface{}{"name": "db"},
+				"web": map[string]interface{}{"hosts": []interface{}{ref("db.name"), "cache"}},
+			},
+			expected: map[string]interface{}{
+				"db":  map[string]interface{}{"name": "db"},
+				"web": map[string]interface{}{"hosts": []interface{}{"db", "cache"}},
+			},
+		},
+		{
+			name: "cycle",
+			components: map[string]interface{}{
+				"a": map[string]interface{}{"x": ref("b.y")},
+				"b": map[string]interface{}{"y": ref("a.x")},
+			},
+			isErr: true,
+		},
+		{
+			name: "self reference",
+			components: map[string]interface{}{
+				"a": map[string]interface{}{"x": ref("a.x")},
+			},
+			isErr: true,
+		},
+		{
+			name: "unknown component",
+			components: map[string]interface{}{
+				"a": map[string]interface{}{"x": ref("b.y")},
+			},
+			isErr: true,
+		},
+		{
+			name: "unknown param",
+			components: map[string]interface{}{
+				"a": map[string]interface{}{"x": ref("b.y")},
+				"b": map[string]interface{}{},
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ResolveRefs(tc.components)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.components)
+		})
+	}
+}
+
+func Test_resolveEnvRefs(t *testing.T) {
+	in := `{"components":{"db":{"name":"db"},"web":{"host":{"__ksonnet/ref":"db.name"}}}}`
+
+	got, err := resolveEnvRefs(in)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"components":{"db":{"name":"db"},"web":{"host":"db"}}}`, got)
+
+	unchanged := `{"components": {"db": {"name": "db"}}}`
+	got, err = resolveEnvRefs(unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, unchanged, got)
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// SchemaFile is the name of a module's parameter schema file. It is stored
+	// alongside the module's params.libsonnet, and evaluates to an object with
+	// a JSON Schema for each component's parameters:
+	//
+	//   {
+	//     components: {
+	//       web: {
+	//         type: "object",
+	//         required: ["image"],
+	//         properties: {
+	//           replicas: { type: "integer", minimum: 1 },
+	//         },
+	//       },
+	//     },
+	//   }
+	SchemaFile = "params.schema.libsonnet"
+)
+
+// Schema contains JSON Schemas for component parameters.
+type Schema struct {
+	components map[string]*spec.Schema
+}
+
+// SchemaPath returns the path of the schema file for a module's params file.
+func SchemaPath(paramsPath string) string {
+	return filepath.Join(filepath.Dir(paramsPath), SchemaFile)
+}
+
+// LoadSchema loads the schema for a module's params file. It returns nil if
+// the module doesn't have a schema.
+func LoadSchema(fs afero.Fs, paramsPath string) (*Schema, error) {
+	path := SchemaPath(paramsPath)
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, pkgerrors.Wrapf(err, "reading %s", path)
+	}
+
+	return ParseSchema(path, string(data))
+}
+
+// ParseSchema parses a parameter schema from Jsonnet source.
+func ParseSchema(filename, src string) (*Schema, error) {
+	vm := jsonnet.NewVM()
+	vm.AddJPath(filepath.Dir(filename))
+
+	output, err := vm.EvaluateSnippet(filename, src)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "evaluating %s", filename)
+	}
+
+	var doc struct {
+		Components map[string]*spec.Schema `json:"components"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		return nil, pkgerrors.Wrapf(err, "decoding %s", filename)
+	}
+
+	return &Schema{components: doc.Components}, nil
+}
+
+// Violation is a parameter which doesn't match its schema.
+type Violation struct {
+	// Path is the parameter's path, starting with the component name.
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ValidationError is returned when parameters don't match their schema.
+type ValidationError struct {
+	Violations []Violation
+}
+
+var _ error = (*ValidationError)(nil)
+
+func (e *ValidationError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString("parameters do not match their schema:")
+	for _, v := range e.Violations {
+		fmt.Fprintf(&buf, "\n  %s", v)
+	}
+
+	return buf.String()
+}
+
+// Validate validates component parameters keyed by component name. Components
+// without a schema are not validated. It returns a *ValidationError which
+// lists every violation.
+func (s *Schema) Validate(components map[string]interface{}) error {
+	if s == nil {
+		return nil
+	}
+
+	var names []string
+	for name := range components {
+		if _, ok := s.components[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var violations []Violation
+	for _, name := range names {
+		v := validate.NewSchemaValidator(s.components[name], nil, name, strfmt.Default)
+		result := v.Validate(components[name])
+
+		for _, err := range result.Errors {
+			violations = append(violations, newViolation(name, err))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+
+	return &ValidationError{Violations: violations}
+}
+
+// ValidateComponent validates a single component's parameters.
+func (s *Schema) ValidateComponent(componentName string, values map[string]interface{}) error {
+	return s.Validate(map[string]interface{}{componentName: values})
+}
+
+func newViolation(componentName string, err error) Violation {
+	ve, ok := err.(*errors.Validation)
+	if !ok || ve.Name == "" {
+		return Violation{Path: componentName, Message: err.Error()}
+	}
+
+	// go-openapi messages start with "<name> in <location>".
+	message := strings.TrimPrefix(ve.Error(), ve.Name)
+	message = strings.TrimPrefix(message, " in "+ve.In)
+	message = strings.TrimSpace(message)
+
+	return Violation{Path: ve.Name, Message: message}
+}
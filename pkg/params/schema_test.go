@@ -0,0 +1,135 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package params
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `
+local port = { type: "integer", minimum: 1, maximum: 65535 };
+
+{
+  components: {
+    web: {
+      type: "object",
+      required: ["image"],
+      properties: {
+        replicas: { type: "integer", minimum: 1, maximum: 10 },
+        mode: { enum: ["blue", "green"] },
+        port: port,
+        labels: {
+          type: "object",
+          properties: { app: { type: "string" } },
+        },
+      },
+    },
+  },
+}
+`
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := ParseSchema("/components/params.schema.libsonnet", testSchema)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name       string
+		components map[string]interface{}
+		expected   []Violation
+	}{
+		{
+			name: "valid",
+			components: map[string]interface{}{
+				"web": map[string]interface{}{"image": "nginx", "replicas": 3, "mode": "blue"},
+			},
+		},
+		{
+			name: "no schema",
+			components: map[string]interface{}{
+				"db": map[string]interface{}{"replicas": "abc"},
+			},
+		},
+		{
+			name: "invalid",
+			components: map[string]interface{}{
+				"web": map[string]interface{}{
+					"replicas": "abc",
+					"mode":     "red",
+					"port":     70000,
+					"labels":   map[string]interface{}{"app": 1},
+				},
+			},
+			expected: []Violation{
+				{Path: "web.image", Message: "is required"},
+				{Path: "web.labels.app", Message: `must be of type string: "integer"`},
+				{Path: "web.mode", Message: "should be one of [blue green]"},
+				{Path: "web.port", Message: "should be less than or equal to 65535"},
+				{Path: "web.replicas", Message: `must be of type integer: "string"`},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.Validate(tc.components)
+			if tc.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.IsType(t, &ValidationError{}, err)
+			assert.Equal(t, tc.expected, err.(*ValidationError).Violations)
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{
+		Violations: []Violation{
+			{Path: "web.image", Message: "is required"},
+			{Path: "web.replicas", Message: "should be less than or equal to 10"},
+		},
+	}
+
+	expected := "parameters do not match their schema:\n" +
+		"  web.image: is required\n" +
+		"  web.replicas: should be less than or equal to 10"
+	assert.Equal(t, expected, err.Error())
+}
+
+func TestLoadSchema(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	schema, err := LoadSchema(fs, "/components/params.libsonnet")
+	require.NoError(t, err)
+	assert.Nil(t, schema)
+	assert.NoError(t, schema.Validate(map[string]interface{}{"web": "anything"}))
+
+	require.NoError(t, afero.WriteFile(fs, "/components/params.schema.libsonnet", []byte(testSchema), 0644))
+
+	schema, err = LoadSchema(fs, "/components/params.libsonnet")
+	require.NoError(t, err)
+	require.NotNil(t, schema)
+	assert.Error(t, schema.ValidateComponent("web", map[string]interface{}{}))
+
+	require.NoError(t, afero.WriteFile(fs, "/components/params.schema.libsonnet", []byte("{"), 0644))
+	_, err = LoadSchema(fs, "/components/params.libsonnet")
+	assert.Error(t, err)
+}
@@ -0,0 +1,15 @@
+local params = import '../../components/params.libsonnet';
+
+params + {
+  components+: {
+    // Insert component parameter overrides here. Ex:
+    // guestbook +: {
+    // name: "guestbook-dev",
+    // replicas: params.global.replicas,
+    // },
+    "web.guestbook"+: {
+      name: 'guestbook-dev',
+      replicas: params.global.replicas,
+    },
+  },
+}
\ No newline at end of file
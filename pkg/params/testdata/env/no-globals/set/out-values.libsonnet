@@ -0,0 +1,14 @@
+local params = import '../../components/params.libsonnet';
+
+params + {
+  components+: {
+    guestbook+: {
+      name: 'guestbook-dev',
+      replicas: 3,
+      containerPort: '8080',
+      labels: {
+        app: 'guestbook',
+      },
+    },
+  },
+}
\ No newline at end of file
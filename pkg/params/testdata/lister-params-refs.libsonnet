@@ -0,0 +1,11 @@
+{
+  global: {},
+  components: {
+    db: {
+      service_name: "db-svc",
+    },
+    web: {
+      db_host: { "__ksonnet/ref": "db.service_name" },
+    },
+  },
+}
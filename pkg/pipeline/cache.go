@@ -0,0 +1,410 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	gostrings "strings"
+	"sync"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/jsonnettest"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// cacheVersion is mixed into every cache key. Bump it when the format of
+	// cached objects or the rendering pipeline changes.
+	cacheVersion = "1"
+)
+
+// Cache stores rendered module objects. Each entry is identified by an id and
+// is only valid for the key it was stored with.
+type Cache interface {
+	// Load loads objects for id. It returns false if there is no entry for
+	// id or if the entry was stored with a different key.
+	Load(id, key string) ([]*unstructured.Unstructured, bool, error)
+	// Store stores objects for id and key.
+	Store(id, key string, objects []*unstructured.Unstructured) error
+}
+
+// WithCache configures the pipeline to cache rendered modules.
+func WithCache(c Cache) Opt {
+	return func(p *Pipeline) {
+		p.cache = c
+	}
+}
+
+// FsCache is a Cache which stores entries in the application's .ksonnet directory.
+// It keeps a single entry per id, so it does not grow as sources change.
+type FsCache struct {
+	fs  afero.Fs
+	dir string
+}
+
+var _ Cache = (*FsCache)(nil)
+
+// NewFsCache creates an instance of FsCache for an application.
+func NewFsCache(a app.App) *FsCache {
+	return &FsCache{
+		fs:  a.Fs(),
+		dir: filepath.Join(a.Root(), ".ksonnet", "cache", "render"),
+	}
+}
+
+type cacheEntry struct {
+	Key     string            `json:"key"`
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// Load loads objects for id.
+func (c *FsCache) Load(id, key string) ([]*unstructured.Unstructured, bool, error) {
+	data, err := afero.ReadFile(c.fs, c.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "reading cache entry %q", id)
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, errors.Wrapf(err, "decoding cache entry %q", id)
+	}
+
+	if entry.Key != key {
+		return nil, false, nil
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, len(entry.Objects))
+	for _, data := range entry.Objects {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return nil, false, errors.Wrapf(err, "decoding cached object in %q", id)
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, true, nil
+}
+
+// Store stores objects for id.
+func (c *FsCache) Store(id, key string, objects []*unstructured.Unstructured) error {
+	entry := cacheEntry{
+		Key:     key,
+		Objects: make([]json.RawMessage, 0, len(objects)),
+	}
+
+	for _, obj := range objects {
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			return errors.Wrapf(err, "encoding cached object in %q", id)
+		}
+		entry.Objects = append(entry.Objects, data)
+	}
+
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return errors.Wrapf(err, "encoding cache entry %q", id)
+	}
+
+	path := c.path(id)
+	if err := c.fs.MkdirAll(filepath.Dir(path), app.DefaultFolderPermissions); err != nil {
+		return errors.Wrap(err, "creating cache directory")
+	}
+
+	return afero.WriteFile(c.fs, path, data, app.DefaultFilePermissions)
+}
+
+func (c *FsCache) path(id string) string {
+	return filepath.Join(c.dir, id+".json")
+}
+
+// MemoryCache is a Cache which keeps entries in memory. It is safe for
+// concurrent use, and is meant for long running processes which render the
+// same application many times.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+type memoryCacheEntry struct {
+	key     string
+	objects []*unstructured.Unstructured
+}
+
+// NewMemoryCache creates an instance of MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryCacheEntry),
+	}
+}
+
+// Load loads objects for id. The returned objects are copies, so callers can
+// modify them.
+func (c *MemoryCache) Load(id, key string) ([]*unstructured.Unstructured, bool, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[id]
+	c.mu.Unlock()
+
+	if !ok || entry.key != key {
+		return nil, false, nil
+	}
+
+	return copyObjects(entry.objects), true, nil
+}
+
+// Store stores objects for id.
+func (c *MemoryCache) Store(id, key string, objects []*unstructured.Unstructured) error {
+	entry := memoryCacheEntry{
+		key:     key,
+		objects: copyObjects(objects),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[id] = entry
+	return nil
+}
+
+func copyObjects(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	out := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, obj := range objects {
+		out = append(out, obj.DeepCopy())
+	}
+
+	return out
+}
+
+// moduleCacheID is the cache id for a module in an environment. Renders which
+// are filtered to some components have their own entries, so they don't
+// replace the entry for the full render.
+func moduleCacheID(envName, moduleName string, filter []string) string {
+	if moduleName == "" || moduleName == "/" {
+		moduleName = "root"
+	}
+
+	id := filepath.Join(envName, moduleName)
+	if len(filter) == 0 {
+		return id
+	}
+
+	sum := sha256.Sum256([]byte(sortedFilter(filter)))
+	return id + "@" + hex.EncodeToString(sum[:8])
+}
+
+func sortedFilter(filter []string) string {
+	sorted := append([]string{}, filter...)
+	sort.Strings(sorted)
+	return gostrings.Join(sorted, ",")
+}
+
+// appCacheKey computes the part of the cache key which every module shares. It
+// hashes the environment configuration, app.yaml, lib/, vendor/, the
+// environment sources, and the extra Jsonnet settings from the command line.
+// These can be large, so the key is computed once for each render and reused
+// for every module.
+func (p *Pipeline) appCacheKey() (string, error) {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "version:%s\nenv:%s\n", cacheVersion, p.envName)
+
+	envConfig, err := p.app.Environment(p.envName)
+	if err != nil {
+		return "", errors.Wrapf(err, "load environment %s", p.envName)
+	}
+
+	envData, err := json.Marshal(envConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "encoding environment")
+	}
+	fmt.Fprintf(h, "environment:%s\n", envData)
+
+	settings := p.evaluateSettingsFn()
+	fmt.Fprintf(h, "jpaths:%s\n", gostrings.Join(settings.JPaths, ","))
+	hashStringMap(h, "ext-var", settings.ExtVars)
+	hashStringMap(h, "tla-var", settings.TlaVars)
+
+	fs := p.app.Fs()
+	root := p.app.Root()
+
+	if err := hashFile(h, fs, root, filepath.Join(root, "app.yaml")); err != nil {
+		return "", err
+	}
+
+	dirs := []string{
+		filepath.Join(root, "lib"),
+		filepath.Join(root, "vendor"),
+		filepath.Join(root, "environments"),
+	}
+	dirs = append(dirs, settings.JPaths...)
+
+	for _, dir := range dirs {
+		if err := hashDir(h, fs, root, dir, nil); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// moduleCacheKey computes a key for a module's rendered objects. It combines
+// the application's key from appCacheKey with the module's component sources,
+// and the params and other Jsonnet sources in components/.
+// Component sources which belong to other modules are not part of the key, so
+// changing them doesn't invalidate this module. Jsonnet tests are never rendered,
+// so they are not part of the key either.
+func (p *Pipeline) moduleCacheKey(appKey string, module component.Module, filter []string) (string, error) {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "app:%s\nmodule:%s\n", appKey, module.Name())
+	fmt.Fprintf(h, "filter:%s\n", sortedFilter(filter))
+	fmt.Fprintf(h, "provenance:%t\n", p.provenance)
+
+	fs := p.app.Fs()
+	root := p.app.Root()
+
+	moduleDir := module.Dir()
+	skipOtherComponents := func(path string) bool {
+		if gostrings.HasSuffix(path, jsonnettest.FileSuffix) {
+			return true
+		}
+
+		if filepath.Dir(path) == moduleDir {
+			return false
+		}
+
+		return component.IsComponentFile(path)
+	}
+
+	if err := hashDir(h, fs, root, filepath.Join(root, "components"), skipOtherComponents); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashStringMap(h hash.Hash, name string, m map[string]string) {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s:%s=%d:%s\n", name, k, len(m[k]), m[k])
+	}
+}
+
+// hashDir hashes the names and contents of all files in a directory. Files
+// for which skip returns true are ignored. Missing directories are ignored.
+func hashDir(h hash.Hash, fs afero.Fs, root, dir string, skip func(string) bool) error {
+	exists, err := afero.DirExists(fs, dir)
+	if err != nil {
+		return errors.Wrapf(err, "checking %q", dir)
+	}
+
+	if !exists {
+		return nil
+	}
+
+	return afero.Walk(fs, dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if fi.IsDir() || (skip != nil && skip(path)) {
+			return nil
+		}
+
+		return hashFile(h, fs, root, path)
+	})
+}
+
+// hashFile hashes the name and contents of a file. Missing files are ignored.
+func hashFile(h hash.Hash, fs afero.Fs, root, path string) error {
+	f, err := fs.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "opening %q", path)
+	}
+	defer f.Close()
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+
+	fmt.Fprintf(h, "file:%s\n", filepath.ToSlash(rel))
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Wrapf(err, "reading %q", path)
+	}
+	fmt.Fprintln(h)
+
+	return nil
+}
+
+// cachedModuleObjects returns the objects for a module, using the cache if
+// the module's sources haven't changed since they were last rendered.
+func (p *Pipeline) cachedModuleObjects(appKey string, module component.Module, filter []string) ([]*unstructured.Unstructured, error) {
+	logger := log.WithFields(log.Fields{
+		"action":      "pipeline",
+		"module-name": module.Name(),
+	})
+
+	key, err := p.moduleCacheKey(appKey, module, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "computing cache key")
+	}
+
+	id := moduleCacheID(p.envName, module.Name(), filter)
+
+	objects, ok, err := p.cache.Load(id, key)
+	if err != nil {
+		logger.WithError(err).Debug("ignoring unreadable cache entry")
+	} else if ok {
+		logger.Debug("using cached objects")
+		return objects, nil
+	}
+
+	objects, err = p.moduleObjects(module, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cache.Store(id, key, objects); err != nil {
+		logger.WithError(err).Warn("unable to cache rendered objects")
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,386 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package pipeline
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	appmocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFsCache(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	a := &appmocks.App{}
+	a.On("Fs").Return(fs)
+	a.On("Root").Return("/app")
+
+	c := NewFsCache(a)
+
+	_, ok, err := c.Load("default/root", "key")
+	require.NoError(t, err)
+	assert.False(t, ok, "expected empty cache to miss")
+
+	objects := []*unstructured.Unstructured{
+		{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name": "my-service",
+				},
+				"spec": map[string]interface{}{
+					"port": int64(1000000),
+				},
+			},
+		},
+	}
+
+	require.NoError(t, c.Store("default/root", "key", objects))
+
+	got, ok, err := c.Load("default/root", "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, objects, got)
+
+	_, ok, err = c.Load("default/root", "other-key")
+	require.NoError(t, err)
+	assert.False(t, ok, "expected stale key to miss")
+
+	exists, err := afero.Exists(fs, "/app/.ksonnet/cache/render/default/root.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache()
+
+	_, ok, err := c.Load("default/root", "key")
+	require.NoError(t, err)
+	assert.False(t, ok, "expected empty cache to miss")
+
+	objects := []*unstructured.Unstructured{
+		{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name": "my-service",
+				},
+			},
+		},
+	}
+
+	require.NoError(t, c.Store("default/root", "key", objects))
+
+	got, ok, err := c.Load("default/root", "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, objects, got)
+
+	// loaded objects are copies.
+	got[0].SetName("changed")
+	got, _, err = c.Load("default/root", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "my-service", got[0].GetName())
+
+	_, ok, err = c.Load("default/root", "other-key")
+	require.NoError(t, err)
+	assert.False(t, ok, "expected stale key to miss")
+}
+
+// stageCacheApp stages an application with two modules, `/` and `nested`.
+func stageCacheApp(t *testing.T, fs afero.Fs) {
+	files := map[string]string{
+		"/app/app.yaml":                                 "apiVersion: 0.3.0\n",
+		"/app/components/params.libsonnet":              "{components: {a: {}}}",
+		"/app/components/a.jsonnet":                     "{}",
+		"/app/components/helper.libsonnet":              "{}",
+		"/app/components/nested/params.libsonnet":       "{components: {b: {}}}",
+		"/app/components/nested/b.jsonnet":              "{}",
+		"/app/environments/base.libsonnet":              "{}",
+		"/app/environments/default/main.jsonnet":        "{}",
+		"/app/environments/default/params.libsonnet":    "{}",
+		"/app/environments/default/globals.libsonnet":   "{}",
+		"/app/lib/helpers.libsonnet":                    "{}",
+		"/app/vendor/incubator/redis/redis.libsonnet":   "{}",
+		"/app/.ksonnet/registries/incubator/registry.x": "ignored",
+	}
+
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+}
+
+func TestPipeline_moduleCacheKey(t *testing.T) {
+	cases := []struct {
+		name      string
+		change    func(t *testing.T, fs afero.Fs, p *Pipeline)
+		isChanged bool
+	}{
+		{
+			name:   "unchanged",
+			change: func(*testing.T, afero.Fs, *Pipeline) {},
+		},
+		{
+			name: "module component",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/components/a.jsonnet", "{a: 1}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "new module component",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/components/c.yaml", "kind: Service")
+			},
+			isChanged: true,
+		},
+		{
+			name: "module params",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/components/params.libsonnet", "{components: {a: {replicas: 2}}}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "shared jsonnet source",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/components/helper.libsonnet", "{x: 1}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "component in another module",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/components/nested/b.jsonnet", "{b: 1}")
+			},
+		},
+		{
+			name: "environment params",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/environments/default/params.libsonnet", "{x: 1}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "environment globals",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/environments/default/globals.libsonnet", "{x: 1}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "lib",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/lib/helpers.libsonnet", "{x: 1}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "vendored package",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/vendor/incubator/redis/redis.libsonnet", "{x: 1}")
+			},
+			isChanged: true,
+		},
+		{
+			name: "app.yaml",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/app.yaml", "apiVersion: 0.3.0\nname: app\n")
+			},
+			isChanged: true,
+		},
+		{
+			name: "ksonnet directory",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/app/.ksonnet/registries/incubator/registry.x", "changed")
+			},
+		},
+		{
+			name: "ext vars",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				p.evaluateSettingsFn = func() env.EvaluateSettings {
+					return env.EvaluateSettings{JPaths: []string{"/jpath"}, ExtVars: map[string]string{"key": "value"}}
+				}
+			},
+			isChanged: true,
+		},
+		{
+			name: "tla vars",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				p.evaluateSettingsFn = func() env.EvaluateSettings {
+					return env.EvaluateSettings{JPaths: []string{"/jpath"}, TlaVars: map[string]string{"key": "value"}}
+				}
+			},
+			isChanged: true,
+		},
+		{
+			name: "jpath contents",
+			change: func(t *testing.T, fs afero.Fs, p *Pipeline) {
+				writeFile(t, fs, "/jpath/lib.libsonnet", "{x: 1}")
+			},
+			isChanged: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withCachePipeline(t, func(p *Pipeline, fs afero.Fs, module component.Module) {
+				before := cacheKey(t, p, module, nil)
+
+				tc.change(t, fs, p)
+
+				after := cacheKey(t, p, module, nil)
+
+				if tc.isChanged {
+					assert.NotEqual(t, before, after)
+					return
+				}
+
+				assert.Equal(t, before, after)
+			})
+		})
+	}
+}
+
+func TestPipeline_moduleCacheKey_filter(t *testing.T) {
+	withCachePipeline(t, func(p *Pipeline, fs afero.Fs, module component.Module) {
+		all := cacheKey(t, p, module, nil)
+		filtered := cacheKey(t, p, module, []string{"a"})
+
+		assert.NotEqual(t, all, filtered)
+	})
+}
+
+func Test_moduleCacheID(t *testing.T) {
+	assert.Equal(t, "default/root", moduleCacheID("default", "/", nil))
+	assert.Equal(t, "default/nested", moduleCacheID("default", "nested", nil))
+
+	filtered := moduleCacheID("default", "/", []string{"b", "a"})
+	assert.NotEqual(t, "default/root", filtered)
+	assert.Equal(t, filtered, moduleCacheID("default", "/", []string{"a", "b"}))
+	assert.NotEqual(t, filtered, moduleCacheID("default", "/", []string{"a"}))
+}
+
+func TestPipeline_Objects_cached(t *testing.T) {
+	withCachePipeline(t, func(p *Pipeline, fs afero.Fs, _ component.Module) {
+		module := &cmocks.Module{}
+		module.On("Name").Return("/")
+		module.On("Dir").Return("/app/components")
+		module.On("ParamsPath").Return("/app/components/params.libsonnet")
+		module.On("Render", "default").Return(&astext.Object{}, map[string]string{"service": "yaml"}, nil)
+		module.On("Render", "default", "service").Return(&astext.Object{}, map[string]string{"service": "yaml"}, nil)
+		module.On("ResolvedParams", "default").Return("", nil)
+
+		m := p.cm.(*cmocks.Manager)
+		m.On("Modules", p.app, "default").Return([]component.Module{module}, nil)
+
+		serviceJSON, err := ioutil.ReadFile(filepath.Join("testdata", "components.json"))
+		require.NoError(t, err)
+
+		evaluations := 0
+		p.evaluateEnvFn = func(_ app.App, envName, input, params string, opts ...jsonnet.VMOpt) (string, error) {
+			evaluations++
+			return string(serviceJSON), nil
+		}
+
+		p.evaluateEnvParamsFn = func(_ app.App, paramsPath, paramData, envName, moduleName string) (string, error) {
+			return `{"components": {}}`, nil
+		}
+
+		first, err := p.Objects(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, evaluations)
+
+		second, err := p.Objects(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, evaluations, "expected cached module to skip evaluation")
+		assert.Equal(t, first, second)
+
+		_, err = p.Objects([]string{"service"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, evaluations, "expected filtered render to be cached separately")
+
+		_, err = p.Objects(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, evaluations, "expected filtered render to keep the full render cached")
+
+		writeFile(t, fs, "/app/environments/default/params.libsonnet", "{x: 1}")
+
+		third, err := p.Objects(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 3, evaluations, "expected changed environment to invalidate cache")
+		assert.Equal(t, first, third)
+	})
+}
+
+func withCachePipeline(t *testing.T, fn func(p *Pipeline, fs afero.Fs, module component.Module)) {
+	fs := afero.NewMemMapFs()
+	stageCacheApp(t, fs)
+	require.NoError(t, fs.MkdirAll("/jpath", 0755))
+
+	a := &appmocks.App{}
+	a.On("Fs").Return(fs)
+	a.On("Root").Return("/app")
+	a.On("EnvironmentParams", "default").Return("{}", nil)
+
+	envConfig := &app.EnvironmentConfig{
+		Path: "default",
+		Destination: &app.EnvironmentDestinationSpec{
+			Namespace: "default",
+			Server:    "http://example.com",
+		},
+	}
+	a.On("Environment", "default").Return(envConfig, nil)
+
+	manager := &cmocks.Manager{}
+
+	p := New(a, "default", OverrideManager(manager), WithCache(NewFsCache(a)))
+	p.evaluateSettingsFn = func() env.EvaluateSettings {
+		return env.EvaluateSettings{JPaths: []string{"/jpath"}}
+	}
+
+	module := component.NewModule(a, "")
+
+	fn(p, fs, module)
+}
+
+// cacheKey computes a module's cache key the way a render does.
+func cacheKey(t *testing.T, p *Pipeline, module component.Module, filter []string) string {
+	appKey, err := p.appCacheKey()
+	require.NoError(t, err)
+
+	key, err := p.moduleCacheKey(appKey, module, filter)
+	require.NoError(t, err)
+
+	return key
+}
+
+func writeFile(t *testing.T, fs afero.Fs, path, content string) {
+	require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+}
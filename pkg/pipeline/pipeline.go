@@ -18,10 +18,14 @@ package pipeline
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	gostrings "strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
@@ -37,7 +41,8 @@ import (
 	"github.com/ksonnet/ksonnet/pkg/util/strings"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // OverrideManager overrides the component manager interface for a pipeline.
@@ -47,6 +52,28 @@ func OverrideManager(c component.Manager) Opt {
 	}
 }
 
+// Concurrency sets the maximum number of modules which are evaluated at the
+// same time.
+func Concurrency(n int) Opt {
+	return func(p *Pipeline) {
+		if n < 1 {
+			n = 1
+		}
+		p.concurrency = n
+	}
+}
+
+// WithEvaluateSettings sets the Jsonnet settings used to evaluate components.
+// Without this option, the pipeline uses the package level settings from the
+// env package.
+func WithEvaluateSettings(settings env.EvaluateSettings) Opt {
+	return func(p *Pipeline) {
+		p.evaluateSettingsFn = func() env.EvaluateSettings {
+			return settings
+		}
+	}
+}
+
 // Opt is an option for configuring Pipeline.
 type Opt func(p *Pipeline)
 
@@ -58,7 +85,13 @@ type Pipeline struct {
 	buildObjectsFn      func(*Pipeline, []string) ([]*unstructured.Unstructured, error)
 	evaluateEnvFn       func(a app.App, envName, components, paramsStr string, opts ...jsonnet.VMOpt) (string, error)
 	evaluateEnvParamsFn func(a app.App, sourcePath, paramsStr, envName, moduleName string) (string, error)
+	envGlobalsFn        func(a app.App, envName string) (string, error)
 	stubModuleFn        func(m component.Module) (string, error)
+	validateParamsFn    func(m component.Module, envParamData string, filter []string) error
+	evaluateSettingsFn  func() env.EvaluateSettings
+	cache               Cache
+	concurrency         int
+	provenance          bool
 }
 
 // New creates an instance of Pipeline.
@@ -69,11 +102,16 @@ func New(ksApp app.App, envName string, opts ...Opt) *Pipeline {
 		envName:             envName,
 		cm:                  component.DefaultManager,
 		buildObjectsFn:      buildObjects,
-		evaluateEnvFn:       env.Evaluate,
 		evaluateEnvParamsFn: params.EvaluateEnv,
+		envGlobalsFn:        env.EvaluateGlobals,
 		stubModuleFn:        stubModule,
+		evaluateSettingsFn:  env.CurrentEvaluateSettings,
+		concurrency:         runtime.NumCPU(),
 	}
 
+	p.evaluateEnvFn = p.evaluateEnv
+	p.validateParamsFn = p.validateParams
+
 	for _, opt := range opts {
 		opt(p)
 	}
@@ -81,6 +119,10 @@ func New(ksApp app.App, envName string, opts ...Opt) *Pipeline {
 	return p
 }
 
+func (p *Pipeline) evaluateEnv(a app.App, envName, components, paramsStr string, opts ...jsonnet.VMOpt) (string, error) {
+	return env.EvaluateWithSettings(a, envName, components, paramsStr, p.evaluateSettingsFn(), opts...)
+}
+
 // Modules returns the modules that belong to this pipeline.
 func (p *Pipeline) Modules() ([]component.Module, error) {
 	return p.cm.Modules(p.app, p.envName)
@@ -184,6 +226,22 @@ func (p *Pipeline) Objects(filter []string) ([]*unstructured.Unstructured, error
 	return p.buildObjectsFn(p, filter)
 }
 
+// ModuleParameters returns a module's parameters with the environment's
+// parameters applied.
+func (p *Pipeline) ModuleParameters(module component.Module) (string, error) {
+	moduleParamData, err := module.ResolvedParams(p.envName)
+	if err != nil {
+		return "", err
+	}
+
+	envParamsPath, err := env.Path(p.app, p.envName, "params.libsonnet")
+	if err != nil {
+		return "", err
+	}
+
+	return p.evaluateEnvParamsFn(p.app, envParamsPath, moduleParamData, p.envName, module.Name())
+}
+
 func (p *Pipeline) moduleObjects(module component.Module, filter []string) ([]*unstructured.Unstructured, error) {
 	doc := &astext.Object{}
 
@@ -194,20 +252,21 @@ func (p *Pipeline) moduleObjects(module component.Module, filter []string) ([]*u
 
 	doc.Fields = append(doc.Fields, object.Fields...)
 
-	// apply environment parameters
-	moduleParamData, err := module.ResolvedParams(p.envName)
+	envParamData, err := p.ModuleParameters(module)
 	if err != nil {
 		return nil, err
 	}
 
-	envParamsPath, err := env.Path(p.app, p.envName, "params.libsonnet")
-	if err != nil {
+	if err = p.validateParamsFn(module, envParamData, filter); err != nil {
 		return nil, err
 	}
 
-	envParamData, err := p.evaluateEnvParamsFn(p.app, envParamsPath, moduleParamData, p.envName, module.Name())
-	if err != nil {
-		return nil, err
+	var mp *moduleProvenance
+	if p.provenance {
+		mp, err = p.newModuleProvenance(module, envParamData)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding provenance")
+		}
 	}
 
 	var buf bytes.Buffer
@@ -227,9 +286,17 @@ func (p *Pipeline) moduleObjects(module component.Module, filter []string) ([]*u
 		return nil, err
 	}
 
-	ret := make([]runtime.Object, 0, len(m))
+	ret := make([]kruntime.Object, 0, len(m))
 
-	for componentName, v := range m {
+	// iterate in component name order so module output is deterministic.
+	componentNames := make([]string, 0, len(m))
+	for componentName := range m {
+		componentNames = append(componentNames, componentName)
+	}
+	sort.Strings(componentNames)
+
+	for _, componentName := range componentNames {
+		v := m[componentName]
 		if len(filter) != 0 && !strings.InSlice(componentName, filter) {
 			continue
 		}
@@ -241,6 +308,12 @@ func (p *Pipeline) moduleObjects(module component.Module, filter []string) ([]*u
 
 		labelComponents(componentObject, componentName)
 
+		if mp != nil {
+			if err := annotateComponents(componentObject, mp.provenance(componentName)); err != nil {
+				return nil, err
+			}
+		}
+
 		data, err := json.Marshal(componentObject)
 		if err != nil {
 			return nil, err
@@ -274,6 +347,42 @@ func (p *Pipeline) moduleObjects(module component.Module, filter []string) ([]*u
 	return k8s.FlattenToV1(ret)
 }
 
+// validateParams validates a module's environment parameters against the
+// module's parameter schema. If there is a filter, only the components in the
+// filter are validated.
+func (p *Pipeline) validateParams(module component.Module, envParamData string, filter []string) error {
+	schema, err := params.LoadSchema(p.app.Fs(), module.ParamsPath())
+	if err != nil {
+		return errors.Wrapf(err, "loading parameter schema for module %q", module.Name())
+	}
+
+	if schema == nil {
+		return nil
+	}
+
+	envValues, err := componentParams(envParamData)
+	if err != nil {
+		return errors.Wrap(err, "decoding environment parameters")
+	}
+
+	var localFilter []string
+	for _, name := range filter {
+		localFilter = append(localFilter, localComponentName(name))
+	}
+
+	components := make(map[string]interface{})
+	for name, values := range envValues {
+		name = localComponentName(name)
+		if len(localFilter) != 0 && !strings.InSlice(name, localFilter) {
+			continue
+		}
+
+		components[name] = values
+	}
+
+	return schema.Validate(components)
+}
+
 // YAML converts components into YAML.
 func (p *Pipeline) YAML(filter []string) (io.Reader, error) {
 	objects, err := p.Objects(filter)
@@ -327,25 +436,85 @@ func buildObjects(p *Pipeline, filter []string) ([]*unstructured.Unstructured, e
 		return nil, errors.Wrap(err, "get modules")
 	}
 
-	var ret []*unstructured.Unstructured
+	var appKey string
+	if p.cache != nil {
+		if appKey, err = p.appCacheKey(); err != nil {
+			return nil, errors.Wrap(err, "computing cache key")
+		}
+	}
 
-	for _, m := range modules {
-		log.WithFields(log.Fields{
-			"action":      "pipeline",
-			"module-name": m.Name(),
-		}).Debug("building objects")
+	// modules are independent, so they are evaluated concurrently. Results are
+	// collected by module index to keep the output in module order.
+	results := make([][]*unstructured.Unstructured, len(modules))
+	errs := make([]error, len(modules))
 
-		objects, err := p.moduleObjects(m, filter)
-		if err != nil {
-			return nil, err
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := p.concurrency
+	if workers > len(modules) {
+		workers = len(modules)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i], errs[i] = p.buildModuleObjects(appKey, modules[i], filter)
+			}
+		}()
+	}
+
+	for i := range modules {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	var ret []*unstructured.Unstructured
+	var moduleErrs []error
+
+	for i := range modules {
+		if errs[i] != nil {
+			moduleErrs = append(moduleErrs, errors.Wrap(errs[i], moduleErrorMsg("build objects for %s", modules[i].Name())))
+			continue
 		}
 
-		ret = append(ret, objects...)
+		ret = append(ret, results[i]...)
+	}
+
+	if len(moduleErrs) > 0 {
+		return nil, utilerrors.NewAggregate(moduleErrs)
 	}
 
 	return ret, nil
 }
 
+func (p *Pipeline) buildModuleObjects(appKey string, m component.Module, filter []string) ([]*unstructured.Unstructured, error) {
+	log.WithFields(log.Fields{
+		"action":      "pipeline",
+		"module-name": m.Name(),
+	}).Debug("building objects")
+
+	if p.cache != nil {
+		return p.cachedModuleObjects(appKey, m, filter)
+	}
+
+	return p.moduleObjects(m, filter)
+}
+
+func moduleErrorMsg(format, module string) string {
+	s := fmt.Sprintf("module %q", module)
+	if module == "" || module == "/" {
+		s = "root module"
+	}
+
+	return fmt.Sprintf(format, s)
+}
+
 func labelComponents(m map[string]interface{}, name string) {
 	if m["apiVersion"] == "v1" && m["kind"] == "List" {
 		list, ok := m["items"].([]interface{})
@@ -16,8 +16,10 @@
 package pipeline
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	gostrings "strings"
 	"testing"
 
 	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
@@ -195,6 +197,84 @@ func TestPipeline_Objects(t *testing.T) {
 	})
 }
 
+func TestPipeline_Objects_concurrent(t *testing.T) {
+	withPipeline(t, func(p *Pipeline, m *cmocks.Manager, a *appmocks.App) {
+		names := []string{"/", "a", "b", "c", "d", "e"}
+
+		var modules []component.Module
+		for _, name := range names {
+			module := &cmocks.Module{}
+			module.On("Name").Return(name)
+			module.On("Render", "default").Return(&astext.Object{}, map[string]string{}, nil)
+			module.On("ResolvedParams", "default").Return(name, nil)
+			modules = append(modules, module)
+		}
+
+		m.On("Modules", p.app, "default").Return(modules, nil)
+
+		env := &app.EnvironmentConfig{Path: "default"}
+		a.On("Environment", "default").Return(env, nil)
+
+		p.concurrency = 3
+
+		// env params carry the module name through to evaluation.
+		p.evaluateEnvParamsFn = func(_ app.App, paramsPath, paramData, envName, moduleName string) (string, error) {
+			return paramData, nil
+		}
+
+		p.evaluateEnvFn = func(_ app.App, envName, input, params string, opts ...jsonnet.VMOpt) (string, error) {
+			return fmt.Sprintf(`{"%s-cm": {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "%s"}}}`,
+				gostrings.TrimPrefix(params, "/"), params), nil
+		}
+
+		got, err := p.Objects(nil)
+		require.NoError(t, err)
+
+		var gotNames []string
+		for _, obj := range got {
+			gotNames = append(gotNames, obj.GetName())
+		}
+
+		require.Equal(t, names, gotNames)
+	})
+}
+
+func TestPipeline_Objects_aggregates_errors(t *testing.T) {
+	withPipeline(t, func(p *Pipeline, m *cmocks.Manager, a *appmocks.App) {
+		good := &cmocks.Module{}
+		good.On("Name").Return("good")
+		good.On("Render", "default").Return(&astext.Object{}, map[string]string{}, nil)
+		good.On("ResolvedParams", "default").Return("", nil)
+
+		broken1 := &cmocks.Module{}
+		broken1.On("Name").Return("broken1")
+		broken1.On("Render", "default").Return(nil, nil, errors.New("first failure"))
+
+		broken2 := &cmocks.Module{}
+		broken2.On("Name").Return("broken2")
+		broken2.On("Render", "default").Return(nil, nil, errors.New("second failure"))
+
+		m.On("Modules", p.app, "default").Return([]component.Module{broken1, good, broken2}, nil)
+
+		env := &app.EnvironmentConfig{Path: "default"}
+		a.On("Environment", "default").Return(env, nil)
+
+		p.evaluateEnvParamsFn = func(_ app.App, paramsPath, paramData, envName, moduleName string) (string, error) {
+			return `{"components": {}}`, nil
+		}
+
+		p.evaluateEnvFn = func(_ app.App, envName, input, params string, opts ...jsonnet.VMOpt) (string, error) {
+			return "{}", nil
+		}
+
+		_, err := p.Objects(nil)
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), `module "broken1": first failure`)
+		assert.Contains(t, err.Error(), `module "broken2": second failure`)
+	})
+}
+
 func TestPipeline_YAML(t *testing.T) {
 	withPipeline(t, func(p *Pipeline, m *cmocks.Manager, a *appmocks.App) {
 		p.buildObjectsFn = func(_ *Pipeline, filter []string) ([]*unstructured.Unstructured, error) {
@@ -301,6 +381,9 @@ func withPipeline(t *testing.T, fn func(p *Pipeline, m *cmocks.Manager, a *appmo
 	manager := &cmocks.Manager{}
 
 	p := New(a, envName, OverrideManager(manager))
+	p.validateParamsFn = func(component.Module, string, []string) error {
+		return nil
+	}
 
 	fn(p, manager, a)
 }
@@ -0,0 +1,327 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	gostrings "strings"
+
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	clustermetadata "github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Provenance describes where a rendered object came from. Paths are relative
+// to the application root.
+type Provenance struct {
+	// Module is the component's module.
+	Module string `json:"module"`
+	// Component is the component name.
+	Component string `json:"component"`
+	// Source is the path of the component's source file.
+	Source string `json:"source,omitempty"`
+	// Params is the path of the module's params file.
+	Params string `json:"params"`
+	// EnvParams is the path of the environment's params file.
+	EnvParams string `json:"envParams"`
+	// EnvGlobals is the path of the environment's globals file, if it
+	// overrides parameters.
+	EnvGlobals string `json:"envGlobals,omitempty"`
+	// Overrides are the component parameters which the environment params
+	// file sets or changes. Nested parameters are separated by dots.
+	Overrides []string `json:"overrides,omitempty"`
+	// GlobalOverrides are the component parameters which the environment
+	// globals file sets or changes.
+	GlobalOverrides []string `json:"globalOverrides,omitempty"`
+}
+
+// String describes the provenance in a single line.
+func (p *Provenance) String() string {
+	source := p.Source
+	if source == "" {
+		source = fmt.Sprintf("component %q", p.Component)
+	}
+
+	var overrides []string
+	if len(p.Overrides) > 0 {
+		overrides = append(overrides, fmt.Sprintf("%s overridden by %s",
+			gostrings.Join(p.Overrides, ", "), p.EnvParams))
+	}
+	if len(p.GlobalOverrides) > 0 {
+		overrides = append(overrides, fmt.Sprintf("%s overridden by %s",
+			gostrings.Join(p.GlobalOverrides, ", "), p.EnvGlobals))
+	}
+
+	if len(overrides) == 0 {
+		return source
+	}
+
+	return fmt.Sprintf("%s, with %s", source, gostrings.Join(overrides, ", and "))
+}
+
+// WithProvenance configures the pipeline to annotate rendered objects with
+// their provenance. See ObjectProvenance.
+func WithProvenance() Opt {
+	return func(p *Pipeline) {
+		p.provenance = true
+	}
+}
+
+// ObjectProvenance returns the provenance of an object rendered with
+// WithProvenance. It returns false if the object has no provenance.
+func ObjectProvenance(obj *unstructured.Unstructured) (*Provenance, bool, error) {
+	value, ok := obj.GetAnnotations()[clustermetadata.AnnotationProvenance]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var p Provenance
+	if err := json.Unmarshal([]byte(value), &p); err != nil {
+		return nil, false, errors.Wrapf(err, "decoding provenance of %s", obj.GetName())
+	}
+
+	return &p, true, nil
+}
+
+// moduleProvenance creates the provenance for components in a module.
+type moduleProvenance struct {
+	module       component.Module
+	root         string
+	sources      map[string]string
+	envParams    string
+	envGlobals   string
+	moduleParams map[string]interface{}
+	envValues    map[string]interface{}
+	globals      map[string]interface{}
+}
+
+func (p *Pipeline) newModuleProvenance(module component.Module, envParamData string) (*moduleProvenance, error) {
+	moduleParamData, err := module.ResolvedParams(p.envName)
+	if err != nil {
+		return nil, err
+	}
+
+	envParamsPath, err := env.Path(p.app, p.envName, "params.libsonnet")
+	if err != nil {
+		return nil, err
+	}
+
+	envGlobalsPath, err := env.Path(p.app, p.envName, "globals.libsonnet")
+	if err != nil {
+		return nil, err
+	}
+
+	envGlobalsData, err := p.envGlobalsFn(p.app, p.envName)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluating environment globals")
+	}
+
+	mp := &moduleProvenance{
+		module:     module,
+		root:       p.app.Root(),
+		sources:    make(map[string]string),
+		envParams:  envParamsPath,
+		envGlobals: envGlobalsPath,
+	}
+
+	if mp.moduleParams, err = componentParams(moduleParamData); err != nil {
+		return nil, errors.Wrap(err, "decoding module parameters")
+	}
+
+	if mp.envValues, err = componentParams(envParamData); err != nil {
+		return nil, errors.Wrap(err, "decoding environment parameters")
+	}
+
+	if err = json.Unmarshal([]byte(envGlobalsData), &mp.globals); err != nil {
+		return nil, errors.Wrap(err, "decoding environment globals")
+	}
+
+	// environment parameters have their references resolved, so module
+	// parameters are resolved before they are compared.
+	if err = params.ResolveRefs(mp.moduleParams); err != nil {
+		return nil, err
+	}
+
+	if err = params.ResolveRefs(mp.envValues); err != nil {
+		return nil, err
+	}
+
+	fis, err := afero.ReadDir(p.app.Fs(), module.Dir())
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading module %q", module.Name())
+	}
+
+	for _, fi := range fis {
+		if fi.IsDir() || !component.IsComponentFile(fi.Name()) {
+			continue
+		}
+
+		name := gostrings.TrimSuffix(fi.Name(), filepath.Ext(fi.Name()))
+		mp.sources[name] = filepath.Join(module.Dir(), fi.Name())
+	}
+
+	return mp, nil
+}
+
+func (mp *moduleProvenance) provenance(componentName string) *Provenance {
+	name := localComponentName(componentName)
+
+	p := &Provenance{
+		Module:    mp.module.Name(),
+		Component: componentName,
+		Source:    mp.rel(mp.sources[name]),
+		Params:    mp.rel(mp.module.ParamsPath()),
+		EnvParams: mp.rel(mp.envParams),
+	}
+
+	moduleValues, _ := mp.moduleParams[name].(map[string]interface{})
+	envValues, _ := mp.envValues[name].(map[string]interface{})
+
+	// environment globals are merged into every component after the
+	// environment params, so they replace whatever the params set.
+	envParamValues := make(map[string]interface{})
+	for key, value := range envValues {
+		if _, ok := mp.globals[key]; !ok {
+			envParamValues[key] = value
+		}
+	}
+
+	p.Overrides = overriddenParams("", moduleValues, envParamValues)
+	p.GlobalOverrides = overriddenParams("", moduleValues, mp.globals)
+	if len(p.GlobalOverrides) > 0 {
+		p.EnvGlobals = mp.rel(mp.envGlobals)
+	}
+
+	return p
+}
+
+func (mp *moduleProvenance) rel(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	rel, err := filepath.Rel(mp.root, path)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// componentParams decodes the components object from evaluated parameters.
+func componentParams(data string) (map[string]interface{}, error) {
+	var m struct {
+		Components map[string]interface{} `json:"components"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return nil, err
+	}
+
+	return m.Components, nil
+}
+
+// localComponentName strips the module from a component name.
+func localComponentName(name string) string {
+	name = gostrings.TrimPrefix(name, "/")
+	if i := gostrings.LastIndexAny(name, "./"); i >= 0 {
+		return name[i+1:]
+	}
+
+	return name
+}
+
+// overriddenParams returns the paths of the values in updated which are not
+// in original, or which are different.
+func overriddenParams(prefix string, original, updated map[string]interface{}) []string {
+	var paths []string
+
+	for key, value := range updated {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		originalValue, ok := original[key]
+		if ok && reflect.DeepEqual(originalValue, value) {
+			continue
+		}
+
+		originalMap, isOriginalMap := originalValue.(map[string]interface{})
+		valueMap, isMap := value.(map[string]interface{})
+		if ok && isOriginalMap && isMap {
+			paths = append(paths, overriddenParams(path, originalMap, valueMap)...)
+			continue
+		}
+
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+func annotateComponents(m map[string]interface{}, p *Provenance) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "encoding provenance")
+	}
+
+	if m["apiVersion"] == "v1" && m["kind"] == "List" {
+		list, ok := m["items"].([]interface{})
+		if !ok {
+			return nil
+		}
+
+		for _, item := range list {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			annotateComponent(itemMap, string(data))
+		}
+
+		return nil
+	}
+
+	annotateComponent(m, string(data))
+	return nil
+}
+
+func annotateComponent(m map[string]interface{}, value string) {
+	metadata, ok := m["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+		m["metadata"] = metadata
+	}
+
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if !ok {
+		annotations = make(map[string]interface{})
+		metadata["annotations"] = annotations
+	}
+
+	annotations[clustermetadata.AnnotationProvenance] = value
+}
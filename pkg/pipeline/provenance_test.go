@@ -0,0 +1,209 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	appmocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPipeline_Objects_provenance(t *testing.T) {
+	withPipeline(t, func(p *Pipeline, m *cmocks.Manager, a *appmocks.App) {
+		fs := afero.NewMemMapFs()
+		writeFile(t, fs, "/components/service.jsonnet", "{}")
+		writeFile(t, fs, "/components/params.libsonnet", "{}")
+		a.On("Fs").Return(fs)
+
+		module := &cmocks.Module{}
+		module.On("Name").Return("/")
+		module.On("Dir").Return("/components")
+		module.On("ParamsPath").Return("/components/params.libsonnet")
+		module.On("Render", "default").Return(&astext.Object{}, map[string]string{"service": "jsonnet"}, nil)
+		module.On("ResolvedParams", "default").
+			Return(`{"components": {"db": {"port": 5432}, "service": {"port": 80, "replicas": 1, "dbPort": {"__ksonnet/ref": "db.port"}, "labels": {"app": "web"}}}}`, nil)
+
+		m.On("Modules", p.app, "default").Return([]component.Module{module}, nil)
+
+		env := &app.EnvironmentConfig{Path: "default"}
+		a.On("Environment", "default").Return(env, nil)
+
+		p.evaluateEnvParamsFn = func(_ app.App, paramsPath, paramData, envName, moduleName string) (string, error) {
+			return `{"components": {"db": {"port": 5432, "replicas": 2}, "service": {"port": 8080, "replicas": 2, "dbPort": 5432, "labels": {"app": "web", "tier": "front"}}}}`, nil
+		}
+
+		p.envGlobalsFn = func(_ app.App, envName string) (string, error) {
+			return `{"replicas": 2}`, nil
+		}
+
+		p.evaluateEnvFn = func(_ app.App, envName, input, params string, opts ...jsonnet.VMOpt) (string, error) {
+			return `{"service": {"apiVersion": "v1", "kind": "Service", "metadata": {"name": "web"}}}`, nil
+		}
+
+		WithProvenance()(p)
+
+		got, err := p.Objects(nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+
+		provenance, ok, err := ObjectProvenance(got[0])
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		expected := &Provenance{
+			Module:          "/",
+			Component:       "service",
+			Source:          "components/service.jsonnet",
+			Params:          "components/params.libsonnet",
+			EnvParams:       "environments/default/params.libsonnet",
+			EnvGlobals:      "environments/default/globals.libsonnet",
+			Overrides:       []string{"labels.tier", "port"},
+			GlobalOverrides: []string{"replicas"},
+		}
+		assert.Equal(t, expected, provenance)
+	})
+}
+
+func TestObjectProvenance_missing(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "web"},
+		},
+	}
+
+	_, ok, err := ObjectProvenance(obj)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestProvenance_String(t *testing.T) {
+	cases := []struct {
+		name       string
+		provenance Provenance
+		expected   string
+	}{
+		{
+			name:       "source",
+			provenance: Provenance{Component: "web", Source: "components/web.jsonnet"},
+			expected:   "components/web.jsonnet",
+		},
+		{
+			name:       "no source",
+			provenance: Provenance{Component: "web"},
+			expected:   `component "web"`,
+		},
+		{
+			name: "overrides",
+			provenance: Provenance{
+				Component: "web",
+				Source:    "components/web.jsonnet",
+				EnvParams: "environments/prod/params.libsonnet",
+				Overrides: []string{"port", "replicas"},
+			},
+			expected: "components/web.jsonnet, with port, replicas overridden by environments/prod/params.libsonnet",
+		},
+		{
+			name: "global overrides",
+			provenance: Provenance{
+				Component:       "web",
+				Source:          "components/web.jsonnet",
+				EnvParams:       "environments/prod/params.libsonnet",
+				EnvGlobals:      "environments/prod/globals.libsonnet",
+				Overrides:       []string{"port"},
+				GlobalOverrides: []string{"replicas"},
+			},
+			expected: "components/web.jsonnet, with port overridden by environments/prod/params.libsonnet, " +
+				"and replicas overridden by environments/prod/globals.libsonnet",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.provenance.String())
+		})
+	}
+}
+
+func Test_overriddenParams(t *testing.T) {
+	cases := []struct {
+		name     string
+		original map[string]interface{}
+		updated  map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			original: map[string]interface{}{"a": 1.0},
+			updated:  map[string]interface{}{"a": 1.0},
+		},
+		{
+			name:     "changed and added",
+			original: map[string]interface{}{"a": 1.0, "b": "x"},
+			updated:  map[string]interface{}{"a": 2.0, "b": "x", "c": true},
+			expected: []string{"a", "c"},
+		},
+		{
+			name: "nested",
+			original: map[string]interface{}{
+				"labels": map[string]interface{}{"app": "web"},
+			},
+			updated: map[string]interface{}{
+				"labels": map[string]interface{}{"app": "api", "tier": "front"},
+			},
+			expected: []string{"labels.app", "labels.tier"},
+		},
+		{
+			name:     "replaced with object",
+			original: map[string]interface{}{"a": 1.0},
+			updated:  map[string]interface{}{"a": map[string]interface{}{"b": 1.0}},
+			expected: []string{"a"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, overriddenParams("", tc.original, tc.updated))
+		})
+	}
+}
+
+func Test_localComponentName(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "web", expected: "web"},
+		{name: "nested.web", expected: "web"},
+		{name: "/nested/web", expected: "web"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, localComponentName(tc.name))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/ksonnet/ksonnet-lib/ksonnet-gen/astext"
+	"github.com/ksonnet/ksonnet/pkg/app"
+	appmocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	cmocks "github.com/ksonnet/ksonnet/pkg/component/mocks"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pipelineSchema = `{
+  components: {
+    web: {
+      type: "object",
+      required: ["image"],
+      properties: { replicas: { type: "integer", minimum: 1 } },
+    },
+  },
+}`
+
+func TestPipeline_validateParams(t *testing.T) {
+	cases := []struct {
+		name       string
+		schema     string
+		params     string
+		filter     []string
+		violations []params.Violation
+	}{
+		{
+			name:   "no schema",
+			params: `{"components": {"web": {"replicas": 0}}}`,
+		},
+		{
+			name:   "valid",
+			schema: pipelineSchema,
+			params: `{"components": {"web": {"image": "nginx", "replicas": 2}}}`,
+		},
+		{
+			name:   "invalid",
+			schema: pipelineSchema,
+			params: `{"components": {"web": {"replicas": 0}, "db": {}}}`,
+			violations: []params.Violation{
+				{Path: "web.image", Message: "is required"},
+				{Path: "web.replicas", Message: "should be greater than or equal to 1"},
+			},
+		},
+		{
+			name:   "filtered out",
+			schema: pipelineSchema,
+			params: `{"components": {"web": {"replicas": 0}, "db": {}}}`,
+			filter: []string{"db"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			if tc.schema != "" {
+				writeFile(t, fs, "/components/params.schema.libsonnet", tc.schema)
+			}
+
+			a := &appmocks.App{}
+			a.On("Fs").Return(fs)
+
+			module := &cmocks.Module{}
+			module.On("Name").Return("/")
+			module.On("ParamsPath").Return("/components/params.libsonnet")
+
+			p := New(a, "default")
+
+			err := p.validateParams(module, tc.params, tc.filter)
+			if tc.violations == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.IsType(t, &params.ValidationError{}, err)
+			assert.Equal(t, tc.violations, err.(*params.ValidationError).Violations)
+		})
+	}
+}
+
+func TestPipeline_Objects_invalid_params(t *testing.T) {
+	withPipeline(t, func(p *Pipeline, m *cmocks.Manager, a *appmocks.App) {
+		module := &cmocks.Module{}
+		module.On("Name").Return("/")
+		module.On("Render", "default").Return(&astext.Object{}, map[string]string{}, nil)
+		module.On("ResolvedParams", "default").Return(`{"components": {}}`, nil)
+
+		m.On("Modules", p.app, "default").Return([]component.Module{module}, nil)
+		a.On("Environment", "default").Return(&app.EnvironmentConfig{Path: "default"}, nil)
+
+		p.evaluateEnvParamsFn = func(_ app.App, paramsPath, paramData, envName, moduleName string) (string, error) {
+			return `{"components": {"web": {}}}`, nil
+		}
+
+		p.validateParamsFn = func(_ component.Module, envParamData string, filter []string) error {
+			assert.Equal(t, `{"components": {"web": {}}}`, envParamData)
+			return &params.ValidationError{
+				Violations: []params.Violation{{Path: "web.image", Message: "is required"}},
+			}
+		}
+
+		_, err := p.Objects(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "web.image: is required")
+	})
+}
@@ -31,7 +31,8 @@ import (
 )
 
 type chartConfig struct {
-	Description string `json:"description"`
+	Description string   `json:"description"`
+	Keywords    []string `json:"keywords"`
 }
 
 // Helm is a package based on a Helm chart.
@@ -142,6 +143,9 @@ func (h *Helm) Prototypes() (prototype.Prototypes, error) {
 		Kind:       prototype.DefaultKind,
 		Name:       h.prototypeName(),
 		Version:    latestVersion,
+		Package:    h.name,
+		Registry:   h.registryName,
+		Keywords:   h.config.Keywords,
 		Template: prototype.SnippetSchema{
 			Description:      shortDescription,
 			ShortDescription: shortDescription,
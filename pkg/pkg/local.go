@@ -130,6 +130,9 @@ func (l *Local) Prototypes() (prototype.Prototypes, error) {
 			return err
 		}
 		spec.Version = l.version
+		spec.Package = l.name
+		spec.Registry = l.registryName
+		spec.Keywords = l.config.Keywords
 
 		prototypes = append(prototypes, spec)
 		return nil
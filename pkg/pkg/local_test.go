@@ -196,6 +196,8 @@ func TestLocal_Prototypes(t *testing.T) {
 			protoNames := make([]string, 0, len(prototypes))
 			for _, proto := range prototypes {
 				protoNames = append(protoNames, proto.Name)
+				assert.Equalf(t, "incubator", proto.Registry, "[%v] prototype registry", tc.caseName)
+				assert.Equalf(t, tc.name, proto.Package, "[%v] prototype package", tc.caseName)
 			}
 
 			assert.Subsetf(t, protoNames, tc.expected, "[%v] comparing prototype names", tc.caseName)
@@ -46,19 +46,55 @@ func BindFlags(p *Prototype) (fs *pflag.FlagSet, err error) {
 		if fs.Lookup(param.Name) != nil {
 			return nil, &FlagDefinitionError{name: param.Name}
 		}
-		fs.String(param.Name, "", param.Description)
+		bindParamFlag(fs, param, "")
 	}
 
 	for _, param := range p.OptionalParams() {
 		if fs.Lookup(param.Name) != nil {
 			return nil, &FlagDefinitionError{name: param.Name}
 		}
-		fs.String(param.Name, *param.Default, param.Description)
+		bindParamFlag(fs, param, *param.Default)
 	}
 
 	return fs, nil
 }
 
+// bindParamFlag defines a flag for a param. Bool params can be set without a
+// value, e.g. `--enabled`.
+func bindParamFlag(fs *pflag.FlagSet, param *ParamSchema, value string) {
+	fs.Var(&paramValue{param: param, value: value}, param.Name, param.Description)
+
+	if param.Type == Bool {
+		fs.Lookup(param.Name).NoOptDefVal = "true"
+	}
+}
+
+// paramValue is a flag value for a param. Values are validated against the
+// param's type when they are set.
+type paramValue struct {
+	param *ParamSchema
+	value string
+}
+
+var _ pflag.Value = (*paramValue)(nil)
+
+func (v *paramValue) String() string {
+	return v.value
+}
+
+func (v *paramValue) Set(s string) error {
+	if _, err := v.param.Quote(s); err != nil {
+		return err
+	}
+
+	v.value = s
+	return nil
+}
+
+func (v *paramValue) Type() string {
+	return v.param.TypeString()
+}
+
 // ExtractParameters extracts prototypes parameters from flags.
 func ExtractParameters(fs afero.Fs, p *Prototype, flags *pflag.FlagSet) (map[string]string, error) {
 
@@ -93,10 +129,13 @@ func ExtractParameters(fs afero.Fs, p *Prototype, flags *pflag.FlagSet) (map[str
 
 // updateValuesFromFlag updates values from flags. It mutates the map which is passed in.
 func updateValuesFromFlag(p *Prototype, values map[string]string, param *ParamSchema, flags *pflag.FlagSet) error {
-	val, err := flags.GetString(param.Name)
-	if err != nil {
-		return err
-	} else if _, ok := values[param.Name]; ok {
+	flag := flags.Lookup(param.Name)
+	if flag == nil {
+		return errors.Errorf("flag %q does not exist", param.Name)
+	}
+
+	val := flag.Value.String()
+	if _, ok := values[param.Name]; ok {
 		return errors.Errorf("prototype %q has multiple parameters with name %q", p.Name, param.Name)
 	}
 
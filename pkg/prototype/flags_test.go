@@ -83,6 +83,76 @@ func TestBindFlags(t *testing.T) {
 	assert.Equal(t, expectedKeys, seenFlags, "did not see all expected flags")
 }
 
+func TestBindFlags_typed_params(t *testing.T) {
+	p := &Prototype{
+		Params: ParamSchemas{
+			{Name: "name", Description: "name", Type: String},
+			{Name: "enabled", Description: "enabled", Type: Bool, Default: strings.Ptr("false")},
+			{Name: "port", Description: "port", Type: Integer, Min: int64Ptr(1), Max: int64Ptr(65535), Default: strings.Ptr("80")},
+			{Name: "type", Description: "type", Type: Enum, Values: []string{"ClusterIP", "NodePort"}, Default: strings.Ptr("ClusterIP")},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		args     []string
+		expected map[string]string
+		isErr    bool
+	}{
+		{
+			name: "defaults",
+			args: []string{"--name", "web"},
+			expected: map[string]string{
+				"name":    `"web"`,
+				"enabled": "false",
+				"port":    "80",
+				"type":    `"ClusterIP"`,
+			},
+		},
+		{
+			name: "bool flag without value",
+			args: []string{"--name", "web", "--enabled", "--port", "8080", "--type", "NodePort"},
+			expected: map[string]string{
+				"name":    `"web"`,
+				"enabled": "true",
+				"port":    "8080",
+				"type":    `"NodePort"`,
+			},
+		},
+		{
+			name:  "int out of range",
+			args:  []string{"--name", "web", "--port", "70000"},
+			isErr: true,
+		},
+		{
+			name:  "invalid enum value",
+			args:  []string{"--name", "web", "--type", "Internal"},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			flags, err := BindFlags(p)
+			require.NoError(t, err)
+
+			assert.Equal(t, "int(1..65535)", flags.Lookup("port").Value.Type())
+
+			err = flags.Parse(tc.args)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			m, err := ExtractParameters(afero.NewMemMapFs(), p, flags)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, m)
+		})
+	}
+}
+
 func TestBindFlags_duplicate_required_param(t *testing.T) {
 	p := &Prototype{
 		Params: ParamSchemas{
@@ -22,6 +22,7 @@ import (
 
 type index struct {
 	prototypes map[string]*Prototype
+	search     *searchIndex
 }
 
 func (idx *index) List() (Prototypes, error) {
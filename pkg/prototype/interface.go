@@ -64,6 +64,9 @@ const (
 type Index interface {
 	List() (Prototypes, error)
 	SearchNames(query string, opts SearchOptions) (Prototypes, error)
+	// Search searches prototype names, descriptions, parameter names and
+	// package keywords. Results are ordered by score, highest first.
+	Search(query string, filter SearchFilter) ([]SearchResult, error)
 }
 
 // NewIndex constructs an index of prototype specifications from a list.
@@ -90,5 +93,6 @@ func NewIndex(prototypes []*Prototype, builder Builder) (Index, error) {
 
 	return &index{
 		prototypes: idx,
+		search:     newSearchIndex(idx),
 	}, nil
 }
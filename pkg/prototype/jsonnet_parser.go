@@ -242,18 +242,18 @@ func paramDirective(src string) func(*Prototype) error {
 			return fmt.Errorf("param fields must have '<name> <type> <description>, but got:\n%s", src)
 		}
 
-		pt, err := parseParamType(split[1])
-		if err != nil {
-			return errors.Wrap(err, "invalid param tag")
-		}
-
-		s.Params = append(s.Params, &ParamSchema{
+		ps := &ParamSchema{
 			Name:        split[0],
 			Alias:       &split[0],
 			Description: split[2],
 			Default:     nil,
-			Type:        pt,
-		})
+		}
+
+		if err := parseParamSchemaType(ps, split[1]); err != nil {
+			return errors.Wrap(err, "invalid param tag")
+		}
+
+		s.Params = append(s.Params, ps)
 
 		return nil
 	}
@@ -266,18 +266,18 @@ func optParamDirective(src string) func(*Prototype) error {
 			return fmt.Errorf("optional param fields must have '<name> <type> <default-val> <description> (<default-val> currently cannot contain spaces), but got:\n%s", src)
 		}
 
-		pt, err := parseParamType(split[1])
-		if err != nil {
-			return err
-		}
-
-		s.Params = append(s.Params, &ParamSchema{
+		ps := &ParamSchema{
 			Name:        split[0],
 			Alias:       &split[0],
 			Default:     &split[2],
 			Description: split[3],
-			Type:        pt,
-		})
+		}
+
+		if err := parseParamSchemaType(ps, split[1]); err != nil {
+			return err
+		}
+
+		s.Params = append(s.Params, ps)
 
 		return nil
 	}
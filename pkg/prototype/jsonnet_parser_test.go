@@ -174,6 +174,35 @@ func Test_optParamDirective(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "enum",
+			src:  "type enum(ClusterIP|NodePort) ClusterIP Type of the service",
+			expected: ParamSchemas{
+				{
+					Name:        "type",
+					Alias:       strings.Ptr("type"),
+					Description: "Type of the service",
+					Default:     strings.Ptr("ClusterIP"),
+					Type:        Enum,
+					Values:      []string{"ClusterIP", "NodePort"},
+				},
+			},
+		},
+		{
+			name: "int range",
+			src:  "port int(1..65535) 80 Port to expose",
+			expected: ParamSchemas{
+				{
+					Name:        "port",
+					Alias:       strings.Ptr("port"),
+					Description: "Port to expose",
+					Default:     strings.Ptr("80"),
+					Type:        Integer,
+					Min:         int64Ptr(1),
+					Max:         int64Ptr(65535),
+				},
+			},
+		},
 		{
 			name:  "invalid type",
 			src:   "name invalid Name of the service",
@@ -0,0 +1,338 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prototype
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/ksonnet/ksonnet/pkg/prototype/snippet"
+	snippetjsonnet "github.com/ksonnet/ksonnet/pkg/prototype/snippet/jsonnet"
+	"github.com/ksonnet/ksonnet/pkg/util/jsonnet"
+)
+
+// LintSeverity is the severity of a lint issue.
+type LintSeverity string
+
+const (
+	// LintError is an issue which prevents a prototype from being used.
+	LintError LintSeverity = "error"
+	// LintWarning is an issue which doesn't prevent a prototype from being
+	// used, but should be fixed.
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a problem found in a prototype.
+type LintIssue struct {
+	Severity LintSeverity
+	Message  string
+}
+
+func (li LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", li.Severity, li.Message)
+}
+
+// reservedParams are flags `prototype use` defines for every prototype.
+var reservedParams = []string{"module", "values-file", "verbose"}
+
+var (
+	reParamImport    = regexp.MustCompile(`param://([A-Za-z0-9_-]+)`)
+	reParamReference = regexp.MustCompile(`\bparams\.([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// EvaluateJsonnetFn evaluates a prototype's Jsonnet template with quoted param
+// values.
+type EvaluateJsonnetFn func(p *Prototype, values map[string]string) (string, error)
+
+// LintOpt is an option for Lint.
+type LintOpt func(*linter)
+
+// WithJsonnetEvaluator evaluates Jsonnet templates with fn. Without an
+// evaluator, Jsonnet templates are only parsed, because they usually import
+// libraries from an application.
+func WithJsonnetEvaluator(fn EvaluateJsonnetFn) LintOpt {
+	return func(l *linter) {
+		l.evaluateJsonnetFn = fn
+	}
+}
+
+// Lint checks the source of a prototype. It checks the prototype's
+// directives, the types and defaults of its parameters, and that each of its
+// templates renders with sample values.
+func Lint(src string, opts ...LintOpt) []LintIssue {
+	l := &linter{}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	p, err := JsonnetParse(src)
+	if err != nil {
+		l.errorf("parsing directives: %v", err)
+		return l.issues
+	}
+
+	l.lintDirectives(p)
+	l.lintParams(p)
+	l.lintTemplates(p)
+
+	return l.issues
+}
+
+type linter struct {
+	issues            []LintIssue
+	evaluateJsonnetFn EvaluateJsonnetFn
+}
+
+func (l *linter) errorf(format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{Severity: LintError, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) warnf(format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{Severity: LintWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) lintDirectives(p *Prototype) {
+	if p.APIVersion == "" {
+		l.warnf("missing @apiVersion directive")
+	}
+
+	switch {
+	case p.Name == "":
+		l.errorf("missing @name directive")
+	case strings.ContainsAny(p.Name, " \t"):
+		l.errorf("name %q contains whitespace", p.Name)
+	}
+
+	if p.Template.Description == "" {
+		l.warnf("missing @description directive")
+	}
+
+	if p.Template.ShortDescription == "" {
+		l.warnf("missing @shortDescription directive")
+	}
+}
+
+func (l *linter) lintParams(p *Prototype) {
+	seen := make(map[string]bool)
+
+	for _, param := range p.Params {
+		if seen[param.Name] {
+			l.errorf("param %q is declared more than once", param.Name)
+			continue
+		}
+		seen[param.Name] = true
+
+		for _, reserved := range reservedParams {
+			if param.Name == reserved {
+				l.errorf("param %q conflicts with the %q flag of `prototype use`", param.Name, reserved)
+			}
+		}
+
+		if param.Description == "" {
+			l.warnf("param %q does not have a description", param.Name)
+		}
+
+		if param.Default == nil {
+			continue
+		}
+
+		quoted, err := param.Quote(*param.Default)
+		if err != nil {
+			l.errorf("default for param %q: %v", param.Name, err)
+			continue
+		}
+
+		if err := checkParamValue(param.Type, quoted); err != nil {
+			l.errorf("default %q for param %q: %v", *param.Default, param.Name, err)
+		}
+	}
+}
+
+// checkParamValue checks a quoted param value evaluates to the param type.
+func checkParamValue(pt ParamType, quoted string) error {
+	vm := jsonnet.NewVM()
+	out, err := vm.EvaluateSnippet("value", quoted)
+	if err != nil {
+		return fmt.Errorf("value is not valid Jsonnet")
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		return err
+	}
+
+	var ok bool
+	switch v.(type) {
+	case bool:
+		ok = pt == Bool
+	case float64:
+		ok = pt == Number || pt == NumberOrString || pt == Integer
+	case string:
+		ok = pt == String || pt == NumberOrString || pt == Enum || pt == Quantity || pt == Image
+	case map[string]interface{}:
+		ok = pt == Object
+	case []interface{}:
+		ok = pt == Array
+	}
+
+	if !ok {
+		return fmt.Errorf("value is not of type %s", pt)
+	}
+
+	return nil
+}
+
+func (l *linter) lintTemplates(p *Prototype) {
+	templates := p.Template.AvailableTemplates()
+	if len(templates) == 0 {
+		l.errorf("prototype does not have a template")
+		return
+	}
+
+	declared := make(map[string]bool)
+	for _, param := range p.Params {
+		declared[param.Name] = true
+	}
+
+	values := SampleValues(p)
+	used := make(map[string]bool)
+
+	for _, t := range templates {
+		body, err := p.Template.Body(t)
+		if err != nil {
+			l.errorf("%s template: %v", t, err)
+			continue
+		}
+		text := strings.Join(body, "\n")
+
+		for _, name := range referencedParams(text) {
+			used[name] = true
+			if !declared[name] {
+				l.errorf("%s template references undeclared param %q", t, name)
+			}
+		}
+
+		if err := l.renderSample(p, t, text, values); err != nil {
+			l.errorf("%s template does not render: %v", t, err)
+		}
+	}
+
+	for _, param := range p.Params {
+		// name is used by `prototype use` for the component name.
+		if !used[param.Name] && param.Name != "name" {
+			l.warnf("param %q is not used by any template", param.Name)
+		}
+	}
+}
+
+// referencedParams returns the names of the params a template references.
+func referencedParams(text string) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, re := range []*regexp.Regexp{reParamImport, reParamReference} {
+		for _, match := range re.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// renderSample renders a template with sample values. Jsonnet templates are
+// evaluated if the linter has an evaluator, and are otherwise only parsed.
+func (l *linter) renderSample(p *Prototype, t TemplateType, text string, values map[string]string) error {
+	if t == Jsonnet {
+		if _, err := snippetjsonnet.Parse("prototype", text); err != nil {
+			return err
+		}
+
+		if l.evaluateJsonnetFn == nil {
+			return nil
+		}
+
+		_, err := l.evaluateJsonnetFn(p, values)
+		return err
+	}
+
+	rendered, err := snippet.Parse(text).Evaluate(values)
+	if err != nil {
+		return err
+	}
+
+	var v interface{}
+	return yaml.Unmarshal([]byte(rendered), &v)
+}
+
+// SampleValues returns quoted values for a prototype's params. Optional
+// params use their defaults, and required params use a placeholder value of
+// their type.
+func SampleValues(p *Prototype) map[string]string {
+	values := make(map[string]string)
+
+	for _, param := range p.Params {
+		if param.Default != nil {
+			if quoted, err := param.Quote(*param.Default); err == nil {
+				values[param.Name] = quoted
+				continue
+			}
+		}
+
+		switch param.Type {
+		case Number:
+			values[param.Name] = "1"
+		case Object:
+			values[param.Name] = "{}"
+		case Array:
+			values[param.Name] = "[]"
+		case Bool:
+			values[param.Name] = "true"
+		case Integer:
+			values[param.Name] = strconv.FormatInt(sampleInteger(param), 10)
+		case Enum:
+			values[param.Name] = fmt.Sprintf("%q", param.Values[0])
+		case Quantity:
+			values[param.Name] = `"1"`
+		case Image:
+			values[param.Name] = `"nginx"`
+		default:
+			values[param.Name] = fmt.Sprintf("%q", param.Name)
+		}
+	}
+
+	return values
+}
+
+// sampleInteger returns an integer in the range of an int param.
+func sampleInteger(param *ParamSchema) int64 {
+	i := int64(1)
+	if param.Min != nil && i < *param.Min {
+		i = *param.Min
+	}
+	if param.Max != nil && i > *param.Max {
+		i = *param.Max
+	}
+	return i
+}
@@ -0,0 +1,184 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prototype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		expected []LintIssue
+	}{
+		{
+			name: "valid",
+			src: `// @apiVersion 0.0.1
+// @name io.ksonnet.pkg.valid
+// @description A valid prototype
+// @shortDescription Valid
+// @param name string Name of the service
+// @optionalParam port number 80 Port to expose
+// @optionalParam labels object {app:'web'} Labels
+// @optionalParam type enum(ClusterIP|NodePort) ClusterIP Type of the service
+// @optionalParam debug bool false Enable debugging
+{
+  metadata: { name: import 'param://name', labels: params.labels },
+  spec: { port: import 'param://port', type: params.type, debug: params.debug },
+}`,
+		},
+		{
+			name: "invalid directive",
+			src: `// @apiVersion 0.0.1
+// @name io.ksonnet.pkg.invalid
+// @param name strings Name of the service
+{}`,
+			expected: []LintIssue{
+				{Severity: LintError, Message: "parsing directives: invalid param tag: unknown param type 'strings'"},
+			},
+		},
+		{
+			name: "missing directives",
+			src: `// @param name string Name
+{ name: params.name }`,
+			expected: []LintIssue{
+				{Severity: LintWarning, Message: "missing @apiVersion directive"},
+				{Severity: LintError, Message: "missing @name directive"},
+				{Severity: LintWarning, Message: "missing @description directive"},
+				{Severity: LintWarning, Message: "missing @shortDescription directive"},
+			},
+		},
+		{
+			name: "invalid params",
+			src: `// @apiVersion 0.0.1
+// @name io.ksonnet.pkg.params
+// @description Params
+// @shortDescription Params
+// @optionalParam replicas number two Number of replicas
+// @optionalParam labels object app=web Labels
+// @optionalParam ports array 80 Ports
+// @optionalParam size quantity large Size
+// @optionalParam module string web Module
+// @optionalParam module string web Module
+{
+  replicas: params.replicas,
+  labels: params.labels,
+  ports: params.ports,
+  size: params.size,
+  module: params.module,
+}`,
+			expected: []LintIssue{
+				{Severity: LintError, Message: "default for param \"replicas\": Could not convert parameter 'replicas' to a number"},
+				{Severity: LintError, Message: "default \"app=web\" for param \"labels\": value is not valid Jsonnet"},
+				{Severity: LintError, Message: "default \"80\" for param \"ports\": value is not of type array"},
+				{Severity: LintError, Message: "default for param \"size\": Parameter 'size' is not a valid resource quantity"},
+				{Severity: LintError, Message: "param \"module\" conflicts with the \"module\" flag of `prototype use`"},
+				{Severity: LintError, Message: "param \"module\" is declared more than once"},
+			},
+		},
+		{
+			name: "template issues",
+			src: `// @apiVersion 0.0.1
+// @name io.ksonnet.pkg.template
+// @description Template
+// @shortDescription Template
+// @param name string Name
+// @optionalParam unused string x Unused
+{
+  metadata: { name: params.name, namespace: params.namespace },
+`,
+			expected: []LintIssue{
+				{Severity: LintError, Message: "jsonnet template references undeclared param \"namespace\""},
+				{Severity: LintError, Message: "jsonnet template does not render: prototype:3:1 Unexpected: end of file while parsing field definition"},
+				{Severity: LintWarning, Message: "param \"unused\" is not used by any template"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Lint(tc.src))
+		})
+	}
+}
+
+func TestLint_evaluate_jsonnet(t *testing.T) {
+	src := `// @apiVersion 0.0.1
+// @name io.ksonnet.pkg.evaluate
+// @description Evaluate
+// @shortDescription Evaluate
+// @param name string Name
+// @optionalParam replicas number 1 Replicas
+{
+  metadata: { name: params.name },
+  spec: { replicas: params.replicas / 0 },
+}`
+
+	var got map[string]string
+	evaluate := func(p *Prototype, values map[string]string) (string, error) {
+		got = values
+		return "", errors.New("RUNTIME ERROR: division by zero")
+	}
+
+	expected := []LintIssue{
+		{Severity: LintError, Message: "jsonnet template does not render: RUNTIME ERROR: division by zero"},
+	}
+	assert.Equal(t, expected, Lint(src, WithJsonnetEvaluator(evaluate)))
+	assert.Equal(t, map[string]string{"name": `"name"`, "replicas": "1"}, got)
+
+	// without an evaluator, the template is only parsed.
+	assert.Empty(t, Lint(src))
+}
+
+func TestSampleValues(t *testing.T) {
+	p := &Prototype{
+		Params: ParamSchemas{
+			{Name: "name", Type: String},
+			{Name: "replicas", Type: Number},
+			{Name: "labels", Type: Object},
+			{Name: "ports", Type: Array},
+			{Name: "image", Type: String, Default: strPtr("nginx")},
+			{Name: "enabled", Type: Bool},
+			{Name: "port", Type: Integer, Min: int64Ptr(1024), Max: int64Ptr(65535)},
+			{Name: "type", Type: Enum, Values: []string{"ClusterIP", "NodePort"}},
+			{Name: "cpu", Type: Quantity},
+			{Name: "sidecar", Type: Image},
+		},
+	}
+
+	expected := map[string]string{
+		"name":     `"name"`,
+		"replicas": "1",
+		"labels":   "{}",
+		"ports":    "[]",
+		"image":    `"nginx"`,
+		"enabled":  "true",
+		"port":     "1024",
+		"type":     `"ClusterIP"`,
+		"cpu":      `"1"`,
+		"sidecar":  `"nginx"`,
+	}
+
+	assert.Equal(t, expected, SampleValues(p))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
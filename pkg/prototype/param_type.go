@@ -15,7 +15,11 @@
 
 package prototype
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // ParamType represents a type constraint for a prototype parameter (e.g., it
 // must be a number).
@@ -37,6 +41,25 @@ const (
 
 	// Array represents a prototype parameter that must be a array.
 	Array ParamType = "array"
+
+	// Bool represents a prototype parameter that must be a boolean.
+	Bool ParamType = "bool"
+
+	// Enum represents a prototype parameter that must be one of a set of
+	// strings, e.g. `enum(ClusterIP|NodePort)`.
+	Enum ParamType = "enum"
+
+	// Integer represents a prototype parameter that must be an integer. It
+	// can be limited to a range, e.g. `int(1..65535)`.
+	Integer ParamType = "int"
+
+	// Quantity represents a prototype parameter that must be a Kubernetes
+	// resource quantity, e.g. `500m` or `1Gi`.
+	Quantity ParamType = "quantity"
+
+	// Image represents a prototype parameter that must be a container image
+	// reference, e.g. `nginx:1.15`.
+	Image ParamType = "image"
 )
 
 func parseParamType(t string) (ParamType, error) {
@@ -51,6 +74,16 @@ func parseParamType(t string) (ParamType, error) {
 		return Object, nil
 	case "array":
 		return Array, nil
+	case "bool":
+		return Bool, nil
+	case "int":
+		return Integer, nil
+	case "quantity":
+		return Quantity, nil
+	case "image":
+		return Image, nil
+	case "enum":
+		return "", fmt.Errorf("enum param type requires allowed values, e.g. 'enum(a|b)'")
 	default:
 		return "", fmt.Errorf("unknown param type '%s'", t)
 	}
@@ -68,7 +101,93 @@ func (pt ParamType) String() string {
 		return "object"
 	case Array:
 		return "array"
+	case Bool:
+		return "bool"
+	case Enum:
+		return "enum"
+	case Integer:
+		return "int"
+	case Quantity:
+		return "quantity"
+	case Image:
+		return "image"
 	default:
 		return "unknown"
 	}
 }
+
+// parseParamSchemaType parses the type of a param directive into a
+// ParamSchema. Enum and integer types take constraints in parentheses, e.g.
+// `enum(ClusterIP|NodePort)` or `int(1..65535)`.
+func parseParamSchemaType(ps *ParamSchema, t string) error {
+	open := strings.Index(t, "(")
+	if open == -1 {
+		pt, err := parseParamType(t)
+		if err != nil {
+			return err
+		}
+
+		ps.Type = pt
+		return nil
+	}
+
+	if !strings.HasSuffix(t, ")") {
+		return fmt.Errorf("param type '%s' is missing ')'", t)
+	}
+
+	name, args := t[:open], t[open+1:len(t)-1]
+
+	switch name {
+	case "enum":
+		values := strings.Split(args, "|")
+		for _, v := range values {
+			if v == "" {
+				return fmt.Errorf("param type '%s' has an empty value", t)
+			}
+		}
+
+		ps.Type = Enum
+		ps.Values = values
+	case "int":
+		bounds := strings.Split(args, "..")
+		if len(bounds) != 2 {
+			return fmt.Errorf("param type '%s' must have a range like 'int(1..10)'", t)
+		}
+
+		min, err := parseBound(bounds[0])
+		if err != nil {
+			return fmt.Errorf("param type '%s' has an invalid minimum", t)
+		}
+
+		max, err := parseBound(bounds[1])
+		if err != nil {
+			return fmt.Errorf("param type '%s' has an invalid maximum", t)
+		}
+
+		if min != nil && max != nil && *min > *max {
+			return fmt.Errorf("param type '%s' has a minimum greater than its maximum", t)
+		}
+
+		ps.Type = Integer
+		ps.Min = min
+		ps.Max = max
+	default:
+		return fmt.Errorf("param type '%s' does not take arguments", name)
+	}
+
+	return nil
+}
+
+// parseBound parses a bound of an integer range. An empty bound is unbounded.
+func parseBound(s string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
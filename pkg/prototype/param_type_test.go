@@ -47,6 +47,26 @@ func Test_parseParamType(t *testing.T) {
 			name:     "array",
 			expected: Array,
 		},
+		{
+			name:     "bool",
+			expected: Bool,
+		},
+		{
+			name:     "int",
+			expected: Integer,
+		},
+		{
+			name:     "quantity",
+			expected: Quantity,
+		},
+		{
+			name:     "image",
+			expected: Image,
+		},
+		{
+			name:  "enum",
+			isErr: true,
+		},
 		{
 			name:  "invalid",
 			isErr: true,
@@ -94,6 +114,26 @@ func TestParseParam_String(t *testing.T) {
 			name: "array",
 			in:   Array,
 		},
+		{
+			name: "bool",
+			in:   Bool,
+		},
+		{
+			name: "enum",
+			in:   Enum,
+		},
+		{
+			name: "int",
+			in:   Integer,
+		},
+		{
+			name: "quantity",
+			in:   Quantity,
+		},
+		{
+			name: "image",
+			in:   Image,
+		},
 		{
 			name: "unknown",
 			in:   ParamType("unknown"),
@@ -107,3 +147,96 @@ func TestParseParam_String(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseParamSchemaType(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected ParamSchema
+		isErr    bool
+	}{
+		{
+			name:     "simple type",
+			in:       "bool",
+			expected: ParamSchema{Type: Bool},
+		},
+		{
+			name:     "enum",
+			in:       "enum(ClusterIP|NodePort|LoadBalancer)",
+			expected: ParamSchema{Type: Enum, Values: []string{"ClusterIP", "NodePort", "LoadBalancer"}},
+		},
+		{
+			name:     "int range",
+			in:       "int(1..65535)",
+			expected: ParamSchema{Type: Integer, Min: int64Ptr(1), Max: int64Ptr(65535)},
+		},
+		{
+			name:     "int with minimum",
+			in:       "int(0..)",
+			expected: ParamSchema{Type: Integer, Min: int64Ptr(0)},
+		},
+		{
+			name:     "int with maximum",
+			in:       "int(..10)",
+			expected: ParamSchema{Type: Integer, Max: int64Ptr(10)},
+		},
+		{
+			name:  "unknown type",
+			in:    "float",
+			isErr: true,
+		},
+		{
+			name:  "missing parenthesis",
+			in:    "enum(a|b",
+			isErr: true,
+		},
+		{
+			name:  "empty enum value",
+			in:    "enum(a||b)",
+			isErr: true,
+		},
+		{
+			name:  "int without range",
+			in:    "int(10)",
+			isErr: true,
+		},
+		{
+			name:  "invalid minimum",
+			in:    "int(a..10)",
+			isErr: true,
+		},
+		{
+			name:  "invalid maximum",
+			in:    "int(1..b)",
+			isErr: true,
+		},
+		{
+			name:  "minimum greater than maximum",
+			in:    "int(10..1)",
+			isErr: true,
+		},
+		{
+			name:  "type without arguments",
+			in:    "string(a)",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ps ParamSchema
+			err := parseParamSchemaType(&ps, tc.in)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ps)
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
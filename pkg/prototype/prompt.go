@@ -0,0 +1,111 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prototype
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/util/prompt"
+	"github.com/pkg/errors"
+)
+
+// Prompter prompts for the values of a prototype's params.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter creates an instance of Prompter which reads answers from in
+// and writes prompts to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Param prompts for the value of a param. The param's type and description
+// are shown with the prompt. An empty answer selects def, unless def is
+// empty and the param is required. Answers are validated against the param's
+// type, and the prompt is repeated until a valid value is entered.
+func (pr *Prompter) Param(param *ParamSchema, def string) (string, error) {
+	fmt.Fprintf(pr.out, "%s (%s)", param.Name, param.TypeString())
+	if param.Description != "" {
+		fmt.Fprintf(pr.out, " - %s", param.Description)
+	}
+	fmt.Fprintln(pr.out)
+
+	for {
+		if def != "" {
+			fmt.Fprintf(pr.out, "  value [%s]: ", def)
+		} else {
+			fmt.Fprint(pr.out, "  value: ")
+		}
+
+		answer, err := pr.readLine()
+		if err != nil {
+			return "", err
+		}
+
+		if answer == "" {
+			answer = def
+		}
+
+		if answer == "" && param.Default == nil {
+			fmt.Fprintf(pr.out, "  %s is required\n", param.Name)
+			continue
+		}
+
+		if err := ValidateValue(param, answer); err != nil {
+			fmt.Fprintf(pr.out, "  %v\n", err)
+			continue
+		}
+
+		return answer, nil
+	}
+}
+
+// Confirm asks a yes or no question. Anything other than "y" or "yes" is
+// treated as no.
+func (pr *Prompter) Confirm(question string) (bool, error) {
+	return prompt.Confirm(pr.in, pr.out, question)
+}
+
+func (pr *Prompter) readLine() (string, error) {
+	line, err := pr.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", errors.New("input ended before all values were entered")
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// ValidateValue checks a value given for a param, e.g. on the command line,
+// is valid for the param's type once quoted.
+func ValidateValue(param *ParamSchema, value string) error {
+	quoted, err := param.Quote(value)
+	if err != nil {
+		return err
+	}
+
+	return checkParamValue(param.Type, quoted)
+}
@@ -0,0 +1,126 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prototype
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompter_Param(t *testing.T) {
+	cases := []struct {
+		name     string
+		param    *ParamSchema
+		def      string
+		in       string
+		expected string
+		out      string
+		isErr    bool
+	}{
+		{
+			name:     "required value",
+			param:    &ParamSchema{Name: "image", Type: String, Description: "Container image"},
+			in:       "nginx\n",
+			expected: "nginx",
+			out:      "image (string) - Container image\n  value: ",
+		},
+		{
+			name:     "required value is re-prompted",
+			param:    &ParamSchema{Name: "image", Type: String},
+			in:       "\nnginx\n",
+			expected: "nginx",
+			out:      "image (string)\n  value:   image is required\n  value: ",
+		},
+		{
+			name:     "default",
+			param:    &ParamSchema{Name: "port", Type: Number, Default: strPtr("80")},
+			def:      "80",
+			in:       "\n",
+			expected: "80",
+			out:      "port (number)\n  value [80]: ",
+		},
+		{
+			name:     "invalid value is re-prompted",
+			param:    &ParamSchema{Name: "port", Type: Number, Default: strPtr("80")},
+			def:      "80",
+			in:       "eighty\n8080\n",
+			expected: "8080",
+			out:      "port (number)\n  value [80]:   Could not convert parameter 'port' to a number\n  value [80]: ",
+		},
+		{
+			name:     "invalid object is re-prompted",
+			param:    &ParamSchema{Name: "labels", Type: Object, Default: strPtr("{}")},
+			def:      "{}",
+			in:       "[]\n{app: 'web'}",
+			expected: "{app: 'web'}",
+			out:      "labels (object)\n  value [{}]:   value is not of type object\n  value [{}]: ",
+		},
+		{
+			name:  "input ends",
+			param: &ParamSchema{Name: "image", Type: String},
+			in:    "",
+			out:   "image (string)\n  value: ",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			pr := NewPrompter(strings.NewReader(tc.in), &out)
+
+			value, err := pr.Param(tc.param, tc.def)
+			if tc.isErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, value)
+			}
+
+			assert.Equal(t, tc.out, out.String())
+		})
+	}
+}
+
+func TestPrompter_Confirm(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected bool
+	}{
+		{name: "yes", in: "y\n", expected: true},
+		{name: "yes in full", in: "Yes\n", expected: true},
+		{name: "no", in: "n\n"},
+		{name: "empty", in: "\n"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			pr := NewPrompter(strings.NewReader(tc.in), &out)
+
+			ok, err := pr.Confirm("Continue?")
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, ok)
+			assert.Equal(t, "Continue? [y/N]: ", out.String())
+		})
+	}
+}
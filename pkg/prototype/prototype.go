@@ -22,8 +22,10 @@ import (
 	"strings"
 
 	"github.com/blang/semver"
+	"github.com/docker/distribution/reference"
 	"github.com/ksonnet/ksonnet/pkg/util/version"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -54,6 +56,9 @@ type Prototype struct {
 	Params   ParamSchemas  `json:"params"`
 	Template SnippetSchema `json:"template"`
 	Version  string        `json:"-"` // Version of container package. Not serialized.
+	Package  string        `json:"-"` // Name of container package. Not serialized.
+	Registry string        `json:"-"` // Registry of container package. Not serialized.
+	Keywords []string      `json:"-"` // Keywords of container package. Not serialized.
 }
 
 func (s *Prototype) validate() error {
@@ -210,6 +215,36 @@ type ParamSchema struct {
 	Description string    `json:"description"`
 	Default     *string   `json:"default"` // `nil` only if the parameter is optional.
 	Type        ParamType `json:"type"`
+	Values      []string  `json:"values,omitempty"` // Allowed values of enum params.
+	Min         *int64    `json:"min,omitempty"`    // Optional minimum of int params.
+	Max         *int64    `json:"max,omitempty"`    // Optional maximum of int params.
+}
+
+// TypeString returns the type of the parameter as it is written in a param
+// directive, including any allowed values or range.
+func (ps *ParamSchema) TypeString() string {
+	switch ps.Type {
+	case Enum:
+		return fmt.Sprintf("enum(%s)", strings.Join(ps.Values, "|"))
+	case Integer:
+		if ps.Min == nil && ps.Max == nil {
+			return ps.Type.String()
+		}
+		return fmt.Sprintf("int(%s)", ps.rangeString())
+	default:
+		return ps.Type.String()
+	}
+}
+
+func (ps *ParamSchema) rangeString() string {
+	var min, max string
+	if ps.Min != nil {
+		min = strconv.FormatInt(*ps.Min, 10)
+	}
+	if ps.Max != nil {
+		max = strconv.FormatInt(*ps.Max, 10)
+	}
+	return min + ".." + max
 }
 
 // Quote will parse a prototype parameter and quote it appropriately, so that it
@@ -233,6 +268,38 @@ func (ps *ParamSchema) Quote(value string) (string, error) {
 		return fmt.Sprintf("\"%s\"", value), nil
 	case Array, Object:
 		return value, nil
+	case Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("Could not convert parameter '%s' to a boolean", ps.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case Integer:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("Could not convert parameter '%s' to an integer", ps.Name)
+		}
+		if (ps.Min != nil && i < *ps.Min) || (ps.Max != nil && i > *ps.Max) {
+			return "", fmt.Errorf("Parameter '%s' must be in the range %s", ps.Name, ps.rangeString())
+		}
+		return strconv.FormatInt(i, 10), nil
+	case Enum:
+		for _, v := range ps.Values {
+			if v == value {
+				return fmt.Sprintf("\"%s\"", value), nil
+			}
+		}
+		return "", fmt.Errorf("Parameter '%s' must be one of: %s", ps.Name, strings.Join(ps.Values, ", "))
+	case Quantity:
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return "", fmt.Errorf("Parameter '%s' is not a valid resource quantity", ps.Name)
+		}
+		return fmt.Sprintf("\"%s\"", value), nil
+	case Image:
+		if _, err := reference.ParseNormalizedNamed(value); err != nil {
+			return "", fmt.Errorf("Parameter '%s' is not a valid image reference: %v", ps.Name, err)
+		}
+		return fmt.Sprintf("\"%s\"", value), nil
 	default:
 		return "", fmt.Errorf("Unknown param type for param '%s'", ps.Name)
 	}
@@ -271,9 +338,9 @@ func (ps ParamSchemas) PrettyString(prefix string) string {
 
 		var info string
 		if p.Default != nil {
-			info = fmt.Sprintf(" [default: %s, type: %s]", *p.Default, p.Type.String())
+			info = fmt.Sprintf(" [default: %s, type: %s]", *p.Default, p.TypeString())
 		} else {
-			info = fmt.Sprintf(" [type: %s]", p.Type.String())
+			info = fmt.Sprintf(" [type: %s]", p.TypeString())
 		}
 
 		// NOTE: If we don't add 1 here, the longest line will look like:
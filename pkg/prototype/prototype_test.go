@@ -247,3 +247,81 @@ func TestParseTemplateType(t *testing.T) {
 		})
 	}
 }
+
+func TestParamSchema_Quote(t *testing.T) {
+	port := &ParamSchema{Name: "port", Type: Integer, Min: int64Ptr(1), Max: int64Ptr(65535)}
+	serviceType := &ParamSchema{Name: "type", Type: Enum, Values: []string{"ClusterIP", "NodePort"}}
+
+	cases := []struct {
+		name     string
+		param    *ParamSchema
+		in       string
+		expected string
+		isErr    bool
+	}{
+		{name: "number", param: &ParamSchema{Name: "n", Type: Number}, in: "1.5", expected: "1.5"},
+		{name: "invalid number", param: &ParamSchema{Name: "n", Type: Number}, in: "one", isErr: true},
+		{name: "string", param: &ParamSchema{Name: "s", Type: String}, in: "nginx", expected: `"nginx"`},
+		{name: "number or string", param: &ParamSchema{Name: "ns", Type: NumberOrString}, in: "80", expected: "80"},
+		{name: "object", param: &ParamSchema{Name: "o", Type: Object}, in: "{}", expected: "{}"},
+		{name: "bool", param: &ParamSchema{Name: "b", Type: Bool}, in: "True", expected: "true"},
+		{name: "invalid bool", param: &ParamSchema{Name: "b", Type: Bool}, in: "yes", isErr: true},
+		{name: "int", param: &ParamSchema{Name: "i", Type: Integer}, in: "-3", expected: "-3"},
+		{name: "invalid int", param: &ParamSchema{Name: "i", Type: Integer}, in: "1.5", isErr: true},
+		{name: "int in range", param: port, in: "8080", expected: "8080"},
+		{name: "int below range", param: port, in: "0", isErr: true},
+		{name: "int above range", param: port, in: "65536", isErr: true},
+		{name: "enum", param: serviceType, in: "NodePort", expected: `"NodePort"`},
+		{name: "invalid enum", param: serviceType, in: "nodeport", isErr: true},
+		{name: "quantity", param: &ParamSchema{Name: "q", Type: Quantity}, in: "500m", expected: `"500m"`},
+		{name: "invalid quantity", param: &ParamSchema{Name: "q", Type: Quantity}, in: "lots", isErr: true},
+		{name: "image", param: &ParamSchema{Name: "img", Type: Image}, in: "gcr.io/project/app:1.0", expected: `"gcr.io/project/app:1.0"`},
+		{name: "invalid image", param: &ParamSchema{Name: "img", Type: Image}, in: "Nginx:latest", isErr: true},
+		{name: "unknown type", param: &ParamSchema{Name: "u", Type: ParamType("unknown")}, in: "x", isErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.param.Quote(tc.in)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestParamSchema_TypeString(t *testing.T) {
+	cases := []struct {
+		name     string
+		param    *ParamSchema
+		expected string
+	}{
+		{name: "simple type", param: &ParamSchema{Type: Quantity}, expected: "quantity"},
+		{name: "enum", param: &ParamSchema{Type: Enum, Values: []string{"a", "b"}}, expected: "enum(a|b)"},
+		{name: "int", param: &ParamSchema{Type: Integer}, expected: "int"},
+		{name: "int range", param: &ParamSchema{Type: Integer, Min: int64Ptr(1), Max: int64Ptr(10)}, expected: "int(1..10)"},
+		{name: "int with minimum", param: &ParamSchema{Type: Integer, Min: int64Ptr(0)}, expected: "int(0..)"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.param.TypeString())
+		})
+	}
+}
+
+func TestParamSchemas_PrettyString(t *testing.T) {
+	ps := ParamSchemas{
+		{Name: "type", Description: "Service type", Type: Enum, Values: []string{"ClusterIP", "NodePort"}, Default: strPtr("ClusterIP")},
+		{Name: "port", Description: "Port", Type: Integer, Min: int64Ptr(1), Max: int64Ptr(65535)},
+	}
+
+	expected := "  --type=<type> Service type [default: ClusterIP, type: enum(ClusterIP|NodePort)]\n" +
+		"  --port=<port> Port [type: int(1..65535)]"
+
+	assert.Equal(t, expected, ps.PrettyString("  "))
+}
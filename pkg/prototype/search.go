@@ -0,0 +1,266 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prototype
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weights of the prototype fields in search results. A term found in a
+// prototype's name is worth more than the same term in its description.
+const (
+	nameWeight             = 10
+	keywordWeight          = 6
+	shortDescriptionWeight = 4
+	paramWeight            = 2
+	descriptionWeight      = 1
+
+	// prefixMatchRatio is the fraction of a field's weight given to a query
+	// term which is a prefix of an indexed term.
+	prefixMatchRatio = 0.5
+)
+
+// stopWords are ignored in queries and indexed text.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "for": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "one": true, "or": true, "the": true,
+	"to": true, "with": true,
+}
+
+// SearchFilter limits the prototypes returned by a search. Empty fields
+// match every prototype.
+type SearchFilter struct {
+	// Registry is the name of the registry containing the prototype's package.
+	Registry string
+	// Package is the name of the package containing the prototype.
+	Package string
+	// TemplateType is a template type the prototype must support.
+	TemplateType TemplateType
+}
+
+func (f SearchFilter) matches(p *Prototype) bool {
+	if f.Registry != "" && f.Registry != p.Registry {
+		return false
+	}
+
+	if f.Package != "" && f.Package != p.Package {
+		return false
+	}
+
+	if f.TemplateType != "" {
+		for _, t := range p.Template.AvailableTemplates() {
+			if t == f.TemplateType {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// SearchResult is a prototype matched by a search, and its score.
+type SearchResult struct {
+	Prototype *Prototype
+	Score     float64
+}
+
+// searchIndex is an inverted index of the terms in prototypes. It maps a
+// term to the weight of the term in each prototype, keyed by prototype name.
+type searchIndex struct {
+	terms map[string]map[string]float64
+	// sorted contains the indexed terms in sorted order for prefix matching.
+	sorted []string
+}
+
+func newSearchIndex(prototypes map[string]*Prototype) *searchIndex {
+	si := &searchIndex{
+		terms: make(map[string]map[string]float64),
+	}
+
+	for name, p := range prototypes {
+		si.add(name, nameWeight, p.Name)
+		si.add(name, keywordWeight, p.Keywords...)
+		si.add(name, shortDescriptionWeight, p.Template.ShortDescription)
+		si.add(name, descriptionWeight, p.Template.Description)
+		for _, param := range p.Params {
+			si.add(name, paramWeight, param.Name)
+		}
+	}
+
+	for term := range si.terms {
+		si.sorted = append(si.sorted, term)
+	}
+	sort.Strings(si.sorted)
+
+	return si
+}
+
+// add indexes the terms in text for a prototype. A term found in more than
+// one field keeps the highest weight.
+func (si *searchIndex) add(name string, weight float64, text ...string) {
+	for _, s := range text {
+		for _, term := range tokenize(s) {
+			docs, ok := si.terms[term]
+			if !ok {
+				docs = make(map[string]float64)
+				si.terms[term] = docs
+			}
+
+			if weight > docs[name] {
+				docs[name] = weight
+			}
+		}
+	}
+}
+
+// score returns the score of each prototype for a query term.
+func (si *searchIndex) score(term string) map[string]float64 {
+	scores := make(map[string]float64)
+
+	i := sort.SearchStrings(si.sorted, term)
+	for ; i < len(si.sorted) && strings.HasPrefix(si.sorted[i], term); i++ {
+		indexed := si.sorted[i]
+		ratio := 1.0
+		if indexed != term {
+			ratio = prefixMatchRatio
+		}
+
+		for name, weight := range si.terms[indexed] {
+			if s := weight * ratio; s > scores[name] {
+				scores[name] = s
+			}
+		}
+	}
+
+	return scores
+}
+
+// search returns the names of the prototypes matching any of the terms in the
+// query with their scores. Prototypes which match more of the query terms
+// rank higher.
+func (si *searchIndex) search(query string) map[string]float64 {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	total := make(map[string]float64)
+	matched := make(map[string]int)
+	for _, term := range terms {
+		for name, s := range si.score(term) {
+			total[name] += s
+			matched[name]++
+		}
+	}
+
+	for name := range total {
+		total[name] *= float64(matched[name]) / float64(len(terms))
+	}
+
+	return total
+}
+
+// stemSuffixes are removed from terms so different forms of a word, e.g.
+// "persistent" and "persistence", match each other.
+var stemSuffixes = []string{"ence", "ance", "ent", "ant", "ing", "ion", "s"}
+
+// unpluralSuffixes end words which aren't plurals, e.g. "redis" or "ingress".
+var unpluralSuffixes = []string{"is", "ss", "us"}
+
+// minStemLength is the shortest term left after removing a suffix.
+const minStemLength = 4
+
+// stem removes a common suffix from a term.
+func stem(term string) string {
+	for _, suffix := range unpluralSuffixes {
+		if strings.HasSuffix(term, suffix) {
+			return term
+		}
+	}
+
+	for _, suffix := range stemSuffixes {
+		if strings.HasSuffix(term, suffix) && len(term)-len(suffix) >= minStemLength {
+			return strings.TrimSuffix(term, suffix)
+		}
+	}
+
+	return term
+}
+
+// tokenize splits text into lower case, stemmed terms. Text is split on
+// anything which isn't a letter or a digit, and on camel case boundaries.
+// Stop words and duplicate terms are removed.
+func tokenize(s string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+
+	add := func(term string) {
+		term = strings.ToLower(term)
+		if term == "" || stopWords[term] {
+			return
+		}
+
+		term = stem(term)
+		if seen[term] {
+			return
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	var cur []rune
+	var prev rune
+	for _, r := range s {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			add(string(cur))
+			cur = cur[:0]
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			add(string(cur))
+			cur = append(cur[:0], r)
+		default:
+			cur = append(cur, r)
+		}
+		prev = r
+	}
+	add(string(cur))
+
+	return terms
+}
+
+func (idx *index) Search(query string, filter SearchFilter) ([]SearchResult, error) {
+	var results []SearchResult
+	for name, score := range idx.search.search(query) {
+		p := idx.prototypes[name]
+		if !filter.matches(p) {
+			continue
+		}
+
+		results = append(results, SearchResult{Prototype: p, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Prototype.Name < results[j].Prototype.Name
+	})
+
+	return results, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prototype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Search(t *testing.T) {
+	prototypes := []*Prototype{
+		{
+			Name:     "io.ksonnet.pkg.redis-persistent",
+			Package:  "redis",
+			Registry: "incubator",
+			Keywords: []string{"redis", "database"},
+			Params: ParamSchemas{
+				{Name: "name"},
+				{Name: "volumeSize"},
+			},
+			Template: SnippetSchema{
+				ShortDescription: "Redis backed by a persistent volume claim",
+				Description:      "Deploys a redis instance which stores its data on a persistent volume.",
+				JsonnetBody:      []string{"{}"},
+			},
+		},
+		{
+			Name:     "io.ksonnet.pkg.redis-stateless",
+			Package:  "redis",
+			Registry: "incubator",
+			Keywords: []string{"redis", "database"},
+			Params: ParamSchemas{
+				{Name: "name"},
+			},
+			Template: SnippetSchema{
+				ShortDescription: "Redis without persistence",
+				YAMLBody:         []string{"{}"},
+			},
+		},
+		{
+			Name:     "io.ksonnet.pkg.memcached",
+			Package:  "memcached",
+			Registry: "stable",
+			Keywords: []string{"cache"},
+			Template: SnippetSchema{
+				ShortDescription: "Memcached cluster",
+				Description:      "An alternative to redis for caching.",
+				JsonnetBody:      []string{"{}"},
+			},
+		},
+	}
+
+	idx, err := NewIndex(prototypes, DefaultBuilder)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		query    string
+		filter   SearchFilter
+		expected []string
+	}{
+		{
+			name:  "ranked by field",
+			query: "redis",
+			expected: []string{
+				"io.ksonnet.pkg.redis-persistent",
+				"io.ksonnet.pkg.redis-stateless",
+				"io.ksonnet.pkg.memcached",
+			},
+		},
+		{
+			name:  "multiple terms with stop words",
+			query: "the redis one with persistence",
+			expected: []string{
+				"io.ksonnet.pkg.redis-persistent",
+				"io.ksonnet.pkg.redis-stateless",
+				"io.ksonnet.pkg.memcached",
+			},
+		},
+		{
+			name:  "parameter name",
+			query: "volume size",
+			expected: []string{
+				"io.ksonnet.pkg.redis-persistent",
+			},
+		},
+		{
+			name:  "keyword",
+			query: "cache",
+			expected: []string{
+				"io.ksonnet.pkg.memcached",
+			},
+		},
+		{
+			name:   "filter by registry",
+			query:  "redis",
+			filter: SearchFilter{Registry: "stable"},
+			expected: []string{
+				"io.ksonnet.pkg.memcached",
+			},
+		},
+		{
+			name:   "filter by package and template type",
+			query:  "redis",
+			filter: SearchFilter{Package: "redis", TemplateType: YAML},
+			expected: []string{
+				"io.ksonnet.pkg.redis-stateless",
+			},
+		},
+		{
+			name:  "no results",
+			query: "postgres",
+		},
+		{
+			name:  "only stop words",
+			query: "the one",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := idx.Search(tc.query, tc.filter)
+			require.NoError(t, err)
+
+			var names []string
+			for _, r := range results {
+				names = append(names, r.Prototype.Name)
+			}
+
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func Test_tokenize(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected []string
+	}{
+		{
+			name:     "prototype name",
+			in:       "io.ksonnet.pkg.configMap",
+			expected: []string{"io", "ksonnet", "pkg", "config", "map"},
+		},
+		{
+			name:     "sentence",
+			in:       "The redis one with persistence, and the redis caches",
+			expected: []string{"redis", "persist", "cache"},
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tokenize(tc.in))
+		})
+	}
+}
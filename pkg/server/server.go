@@ -0,0 +1,550 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+// Package server serves a ksonnet application over HTTP. Rendered objects,
+// parameters, environments, and validation results are returned as JSON.
+//
+// The server keeps its caches between requests: rendered modules are cached in
+// memory until their sources change, the packages of each environment are
+// revendored once until their versions change, and OpenAPI schemas are loaded
+// once per Kubernetes version. The application configuration is reloaded when
+// app.yaml or app.override.yaml changes.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/component"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/ksonnet/ksonnet/pkg/ksonnet"
+	"github.com/ksonnet/ksonnet/pkg/openapi"
+	"github.com/ksonnet/ksonnet/pkg/params"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/ksonnet/ksonnet/pkg/registry"
+	"github.com/ksonnet/ksonnet/utils"
+	"github.com/pkg/errors"
+	godiff "github.com/shazow/go-diff"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	environmentsPrefix = "/v1/environments/"
+)
+
+type validateObjectFn func(a app.App, obj *unstructured.Unstructured, envName string) []error
+
+type loadAppFn func(fs afero.Fs, httpClient *http.Client, root string) (app.App, error)
+
+// Opt is an option for configuring Server.
+type Opt func(*Server)
+
+// WithEvaluateSettings sets the Jsonnet settings used for every request.
+func WithEvaluateSettings(settings env.EvaluateSettings) Opt {
+	return func(s *Server) {
+		s.settings = settings
+	}
+}
+
+// Server serves an application over HTTP. It is safe for concurrent use.
+type Server struct {
+	// mu guards the application and the state derived from it. Requests hold
+	// a read lock, and reloading the application holds the write lock.
+	mu       sync.RWMutex
+	app      app.App
+	appStamp string
+	settings env.EvaluateSettings
+	renderer *ksonnet.Renderer
+	cache    pipeline.Cache
+	mux      *http.ServeMux
+
+	// defaultPackageManager is true if the package manager was created by the
+	// server, and has to be recreated when the application is reloaded.
+	defaultPackageManager bool
+
+	loadAppFn        loadAppFn
+	validateObjectFn validateObjectFn
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// New creates an instance of Server for an application.
+func New(a app.App, opts ...Opt) *Server {
+	s := &Server{
+		app:              a,
+		renderer:         ksonnet.NewRenderer(a),
+		cache:            pipeline.NewMemoryCache(),
+		mux:              http.NewServeMux(),
+		loadAppFn:        app.Load,
+		validateObjectFn: openapi.NewValidator().Validate,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.settings.PackageManager == nil {
+		s.settings.PackageManager = registry.NewPackageManager(a, registry.HTTPClientOpt(a.HTTPClient()))
+		s.defaultPackageManager = true
+	}
+
+	if s.settings.VendorCache == nil {
+		s.settings.VendorCache = env.NewVendorCache()
+	}
+
+	s.appStamp = s.currentAppStamp()
+
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/v1/components", s.handleComponents)
+	s.mux.HandleFunc("/v1/diff", s.handleDiff)
+	s.mux.HandleFunc("/v1/environments", s.handleEnvironments)
+	s.mux.HandleFunc(environmentsPrefix, s.handleEnvironment)
+	s.mux.HandleFunc("/v1/params", s.handleParams)
+
+	return s
+}
+
+// ServeHTTP serves a request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.WithFields(log.Fields{
+		"action": "server",
+		"method": r.Method,
+		"path":   r.URL.Path,
+	})
+	logger.Debug("handling request")
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	if err := s.reloadApp(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close removes the packages revendored by the server.
+func (s *Server) Close() error {
+	return s.settings.VendorCache.Clear()
+}
+
+// reloadApp reloads the application if its configuration has changed since it
+// was loaded.
+func (s *Server) reloadApp() error {
+	s.mu.RLock()
+	changed := s.currentAppStamp() != s.appStamp
+	s.mu.RUnlock()
+
+	if !changed {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stamp := s.currentAppStamp()
+	if stamp == s.appStamp {
+		// Another request reloaded the application.
+		return nil
+	}
+
+	log.WithField("action", "server").Info("reloading application configuration")
+
+	a, err := s.loadAppFn(s.app.Fs(), s.app.HTTPClient(), s.app.Root())
+	if err != nil {
+		return errors.Wrap(err, "reloading application")
+	}
+
+	if s.defaultPackageManager {
+		s.settings.PackageManager = registry.NewPackageManager(a, registry.HTTPClientOpt(a.HTTPClient()))
+	}
+
+	s.app = a
+	s.appStamp = stamp
+	s.renderer = ksonnet.NewRenderer(a)
+	s.cache = pipeline.NewMemoryCache()
+
+	return nil
+}
+
+// currentAppStamp identifies the current version of the application
+// configuration by the size and modification time of its files.
+func (s *Server) currentAppStamp() string {
+	var parts []string
+	for _, name := range []string{"app.yaml", "app.override.yaml"} {
+		fi, err := s.app.Fs().Stat(filepath.Join(s.app.Root(), name))
+		if err != nil {
+			parts = append(parts, "-")
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf("%d:%d", fi.Size(), fi.ModTime().UnixNano()))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Environment describes an environment.
+type Environment struct {
+	Name              string `json:"name"`
+	Override          bool   `json:"override"`
+	KubernetesVersion string `json:"kubernetesVersion"`
+	Namespace         string `json:"namespace"`
+	Server            string `json:"server"`
+}
+
+func (s *Server) handleEnvironments(w http.ResponseWriter, r *http.Request) {
+	environments, err := s.app.Environments()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	list := []Environment{}
+	for name, e := range environments {
+		item := Environment{
+			Name:              name,
+			Override:          s.app.IsEnvOverride(name),
+			KubernetesVersion: e.KubernetesVersion,
+		}
+
+		if e.Destination != nil {
+			item.Namespace = e.Destination.Namespace
+			item.Server = e.Destination.Server
+		}
+
+		list = append(list, item)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"environments": list})
+}
+
+// handleEnvironment handles requests for a single environment. Environment
+// names can be nested, so the last path segment is the resource.
+func (s *Server) handleEnvironment(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, environmentsPrefix)
+
+	i := strings.LastIndex(path, "/")
+	if i < 1 {
+		writeError(w, http.StatusNotFound, errors.Errorf("%s was not found", r.URL.Path))
+		return
+	}
+
+	envName, resource := path[:i], path[i+1:]
+
+	if _, err := s.app.Environment(envName); err != nil {
+		writeError(w, http.StatusNotFound, errors.Errorf("environment %q was not found", envName))
+		return
+	}
+
+	components := r.URL.Query()["component"]
+
+	switch resource {
+	default:
+		writeError(w, http.StatusNotFound, errors.Errorf("%s was not found", r.URL.Path))
+	case "objects":
+		s.handleObjects(w, envName, components)
+	case "params":
+		s.handleEnvParams(w, envName, r.URL.Query().Get("component"))
+	case "validate":
+		s.handleValidate(w, envName, components)
+	}
+}
+
+func (s *Server) handleObjects(w http.ResponseWriter, envName string, components []string) {
+	objects, err := s.objects(envName, components)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	list := make([]map[string]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		list = append(list, obj.Object)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"objects": list})
+}
+
+// ValidationResult is the result of validating an object.
+type ValidationResult struct {
+	Object string   `json:"object"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, envName string, components []string) {
+	objects, err := s.objects(envName, components)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	valid := true
+	results := []ValidationResult{}
+
+	for _, obj := range objects {
+		result := ValidationResult{
+			Object: fmt.Sprintf("%s %s %s", obj.GetAPIVersion(), obj.GetKind(), utils.FqName(obj)),
+		}
+
+		for _, err := range s.validateObjectFn(s.app, obj, envName) {
+			result.Errors = append(result.Errors, err.Error())
+			valid = false
+		}
+
+		results = append(results, result)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"valid":   valid,
+		"results": results,
+	})
+}
+
+func (s *Server) handleEnvParams(w http.ResponseWriter, envName, componentName string) {
+	p := s.pipeline(envName)
+
+	modules, err := p.Modules()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	lister := params.NewLister(s.app.Root(), app.EnvironmentDestinationSpec{})
+
+	var entries []params.Entry
+	for _, m := range modules {
+		source, err := p.EnvParameters(m.Name(), true)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		moduleEntries, err := lister.List(strings.NewReader(source), componentName)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		entries = append(entries, moduleEntries...)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"params": toParams(entries)})
+}
+
+func (s *Server) handleParams(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	module, err := component.GetModule(s.app, query.Get("module"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	source, err := module.ParamsSource()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, errors.Wrap(err, "reading module parameters"))
+		return
+	}
+	defer source.Close()
+
+	lister := params.NewLister(s.app.Root(), app.EnvironmentDestinationSpec{})
+
+	entries, err := lister.List(source, query.Get("component"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"params": toParams(entries)})
+}
+
+// Param is a component parameter.
+type Param struct {
+	Component string `json:"component"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Reference string `json:"reference,omitempty"`
+}
+
+func toParams(entries []params.Entry) []Param {
+	list := make([]Param, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, Param{
+			Component: entry.ComponentName,
+			Name:      entry.ParamName,
+			Value:     entry.Value,
+			Reference: entry.Reference,
+		})
+	}
+
+	return list
+}
+
+// Component describes a component.
+type Component struct {
+	Name   string `json:"name"`
+	Module string `json:"module"`
+	Type   string `json:"type"`
+}
+
+func (s *Server) handleComponents(w http.ResponseWriter, r *http.Request) {
+	modules, err := component.Modules(s.app)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	list := []Component{}
+	for _, m := range modules {
+		components, err := m.Components()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		for _, c := range components {
+			list = append(list, Component{
+				Name:   c.Name(true),
+				Module: m.Name(),
+				Type:   c.Type(),
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"components": list})
+}
+
+// handleDiff compares the objects rendered for two environments. The diff
+// is returned as text in unified format.
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, to := query.Get("from"), query.Get("to")
+
+	if from == "" || to == "" {
+		writeError(w, http.StatusBadRequest, errors.New("diff requires from and to environments"))
+		return
+	}
+
+	for _, envName := range []string{from, to} {
+		if _, err := s.app.Environment(envName); err != nil {
+			writeError(w, http.StatusNotFound, errors.Errorf("environment %q was not found", envName))
+			return
+		}
+	}
+
+	components := query["component"]
+
+	var fromBuf, toBuf bytes.Buffer
+	if err := s.renderer.YAML(&fromBuf, from, s.renderOpts(components)...); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := s.renderer.YAML(&toBuf, to, s.renderOpts(components)...); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	err := godiff.DefaultDiffer().Diff(&buf,
+		bytes.NewReader(fromBuf.Bytes()),
+		bytes.NewReader(toBuf.Bytes()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, errors.Wrap(err, "diffing environments"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	buf.WriteTo(w)
+}
+
+func (s *Server) objects(envName string, components []string) ([]*unstructured.Unstructured, error) {
+	return s.renderer.Objects(envName, s.renderOpts(components)...)
+}
+
+func (s *Server) renderOpts(components []string) []ksonnet.RenderOpt {
+	opts := []ksonnet.RenderOpt{
+		ksonnet.WithComponents(components...),
+		ksonnet.WithJPaths(s.settings.JPaths...),
+		ksonnet.WithPackageManager(s.settings.PackageManager),
+		ksonnet.WithRenderCache(s.cache),
+		ksonnet.WithVendorCache(s.settings.VendorCache),
+	}
+
+	for k, v := range s.settings.ExtVars {
+		opts = append(opts, ksonnet.WithExtVar(k, v))
+	}
+
+	for k, v := range s.settings.TlaVars {
+		opts = append(opts, ksonnet.WithTlaVar(k, v))
+	}
+
+	return opts
+}
+
+func (s *Server) pipeline(envName string) *pipeline.Pipeline {
+	return pipeline.New(s.app, envName, pipeline.WithEvaluateSettings(s.settings))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, errors.Wrap(err, "encoding response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	log.WithError(err).WithField("status", status).Debug("request failed")
+
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
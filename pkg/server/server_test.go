@@ -0,0 +1,348 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/env"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const serverAppYAML = `apiVersion: 0.3.0
+environments:
+  default:
+    destination:
+      namespace: default
+      server: http://example.com
+    k8sVersion: v1.10.0
+    path: default
+  prod:
+    destination:
+      namespace: prod
+      server: http://example.com
+    k8sVersion: v1.10.0
+    path: prod
+kind: ksonnet.io/app
+name: server
+version: 0.0.1
+`
+
+const prodParams = `
+local params = std.extVar("__ksonnet/params");
+local globals = import "globals.libsonnet";
+local envParams = params + {
+  components+: {
+    config+: { replicas: 3 },
+  },
+};
+
+{
+  components: {
+    [x]: envParams.components[x] + globals, for x in std.objectFields(envParams.components)
+  },
+}
+`
+
+const configComponent = `
+local params = std.extVar("__ksonnet/params").components.config;
+
+{
+  apiVersion: "v1",
+  kind: "ConfigMap",
+  metadata: { name: params.name },
+  data: { replicas: std.toString(params.replicas) },
+}
+`
+
+// withServer stages an application with a single `config` component in a
+// temporary directory, and serves it.
+func withServer(t *testing.T, fn func(s *Server, ts *httptest.Server)) {
+	root, err := ioutil.TempDir("", "server")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"app.yaml":                               serverAppYAML,
+		"components/params.libsonnet":            `{ global: {}, components: { config: { name: "config", replicas: 1 } } }`,
+		"components/config.jsonnet":              configComponent,
+		"environments/base.libsonnet":            string(env.DefaultBaseData),
+		"environments/default/main.jsonnet":      string(env.DefaultOverrideData),
+		"environments/default/params.libsonnet":  string(env.DefaultParamsData),
+		"environments/default/globals.libsonnet": string(env.DefaultGlobalsData),
+		"environments/prod/main.jsonnet":         string(env.DefaultOverrideData),
+		"environments/prod/params.libsonnet":     prodParams,
+		"environments/prod/globals.libsonnet":    string(env.DefaultGlobalsData),
+		"lib/ksonnet-lib/v1.10.0/k.libsonnet":    `{}`,
+		"lib/ksonnet-lib/v1.10.0/k8s.libsonnet":  `{}`,
+	}
+
+	for path, content := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	a, err := app.Load(afero.NewOsFs(), http.DefaultClient, root)
+	require.NoError(t, err)
+
+	s := New(a)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	fn(s, ts)
+}
+
+func get(t *testing.T, ts *httptest.Server, path string, v interface{}) int {
+	resp, err := http.Get(ts.URL + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	if v != nil {
+		require.NoError(t, json.Unmarshal(data, v), string(data))
+	}
+
+	return resp.StatusCode
+}
+
+func TestServer_environments(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		var got struct {
+			Environments []Environment `json:"environments"`
+		}
+
+		status := get(t, ts, "/v1/environments", &got)
+		require.Equal(t, http.StatusOK, status)
+
+		expected := []Environment{
+			{Name: "default", KubernetesVersion: "v1.10.0", Namespace: "default", Server: "http://example.com"},
+			{Name: "prod", KubernetesVersion: "v1.10.0", Namespace: "prod", Server: "http://example.com"},
+		}
+		assert.Equal(t, expected, got.Environments)
+	})
+}
+
+func TestServer_reload_app(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		var got struct {
+			Environments []Environment `json:"environments"`
+		}
+
+		status := get(t, ts, "/v1/environments", &got)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, got.Environments, 2)
+
+		appYAML := strings.Replace(serverAppYAML, "kind: ksonnet.io/app", `  staging:
+    destination:
+      namespace: staging
+      server: http://example.com
+    k8sVersion: v1.10.0
+    path: staging
+kind: ksonnet.io/app`, 1)
+
+		path := filepath.Join(s.app.Root(), "app.yaml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(appYAML), 0644))
+		// Ensure the change is seen on file systems with coarse timestamps.
+		modified := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(path, modified, modified))
+
+		status = get(t, ts, "/v1/environments", &got)
+		require.Equal(t, http.StatusOK, status)
+
+		var names []string
+		for _, e := range got.Environments {
+			names = append(names, e.Name)
+		}
+		assert.Equal(t, []string{"default", "prod", "staging"}, names)
+	})
+}
+
+func TestServer_objects(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		cases := []struct {
+			path     string
+			status   int
+			replicas string
+		}{
+			{path: "/v1/environments/default/objects", status: http.StatusOK, replicas: "1"},
+			{path: "/v1/environments/prod/objects?component=config", status: http.StatusOK, replicas: "3"},
+			// repeated requests are served from the cache.
+			{path: "/v1/environments/prod/objects", status: http.StatusOK, replicas: "3"},
+			{path: "/v1/environments/missing/objects", status: http.StatusNotFound},
+			{path: "/v1/environments/default/missing", status: http.StatusNotFound},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.path, func(t *testing.T) {
+				var got struct {
+					Objects []map[string]interface{} `json:"objects"`
+					Error   string                   `json:"error"`
+				}
+
+				status := get(t, ts, tc.path, &got)
+				require.Equal(t, tc.status, status)
+
+				if tc.status != http.StatusOK {
+					assert.NotEmpty(t, got.Error)
+					return
+				}
+
+				require.Len(t, got.Objects, 1)
+				obj := unstructured.Unstructured{Object: got.Objects[0]}
+				assert.Equal(t, "config", obj.GetName())
+
+				replicas, _, err := unstructured.NestedString(obj.Object, "data", "replicas")
+				require.NoError(t, err)
+				assert.Equal(t, tc.replicas, replicas)
+			})
+		}
+	})
+}
+
+func TestServer_params(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		cases := []struct {
+			path     string
+			expected []Param
+		}{
+			{
+				path: "/v1/params",
+				expected: []Param{
+					{Component: "config", Name: "name", Value: `'config'`},
+					{Component: "config", Name: "replicas", Value: "1"},
+				},
+			},
+			{
+				path: "/v1/environments/prod/params?component=config",
+				expected: []Param{
+					{Component: "config", Name: "name", Value: `'config'`},
+					{Component: "config", Name: "replicas", Value: "3"},
+				},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.path, func(t *testing.T) {
+				var got struct {
+					Params []Param `json:"params"`
+				}
+
+				status := get(t, ts, tc.path, &got)
+				require.Equal(t, http.StatusOK, status)
+				assert.Equal(t, tc.expected, got.Params)
+			})
+		}
+	})
+}
+
+func TestServer_components(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		var got struct {
+			Components []Component `json:"components"`
+		}
+
+		status := get(t, ts, "/v1/components", &got)
+		require.Equal(t, http.StatusOK, status)
+
+		expected := []Component{
+			{Name: "config", Module: "/", Type: "jsonnet"},
+		}
+		assert.Equal(t, expected, got.Components)
+	})
+}
+
+func TestServer_validate(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		s.validateObjectFn = func(_ app.App, obj *unstructured.Unstructured, envName string) []error {
+			if envName == "prod" {
+				return []error{errors.New("invalid")}
+			}
+			return nil
+		}
+
+		cases := []struct {
+			envName string
+			valid   bool
+			errors  []string
+		}{
+			{envName: "default", valid: true},
+			{envName: "prod", valid: false, errors: []string{"invalid"}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.envName, func(t *testing.T) {
+				var got struct {
+					Valid   bool               `json:"valid"`
+					Results []ValidationResult `json:"results"`
+				}
+
+				status := get(t, ts, "/v1/environments/"+tc.envName+"/validate", &got)
+				require.Equal(t, http.StatusOK, status)
+
+				assert.Equal(t, tc.valid, got.Valid)
+				expected := []ValidationResult{
+					{Object: "v1 ConfigMap config", Errors: tc.errors},
+				}
+				assert.Equal(t, expected, got.Results)
+			})
+		}
+	})
+}
+
+func TestServer_diff(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		resp, err := http.Get(ts.URL + "/v1/diff?from=default&to=prod")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(data), `-  replicas: "1"`)
+		assert.Contains(t, string(data), `+  replicas: "3"`)
+
+		status := get(t, ts, "/v1/diff?from=default", nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestServer_method_not_allowed(t *testing.T) {
+	withServer(t, func(s *Server, ts *httptest.Server) {
+		resp, err := http.Post(ts.URL+"/v1/environments", "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
@@ -0,0 +1,383 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+// Package snapshot writes and checks golden file snapshots of the objects
+// rendered for an environment.
+//
+// A snapshot is a directory with one YAML file per component. Each file
+// contains the component's objects in the same format and order as `ks show`.
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	"github.com/ksonnet/ksonnet/pkg/cluster"
+	"github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/ksonnet/ksonnet/pkg/pipeline"
+	"github.com/pkg/errors"
+	godiff "github.com/shazow/go-diff"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// DefaultDir is the default snapshot directory, relative to the application root.
+	DefaultDir = "snapshots"
+
+	snapshotExt = ".yaml"
+)
+
+type findObjectsFn func(a app.App, envName string) ([]*unstructured.Unstructured, error)
+
+// Opt is an option for configuring Snapshot.
+type Opt func(*Snapshot)
+
+// Snapshot manages the snapshot of an environment.
+type Snapshot struct {
+	app     app.App
+	envName string
+	dir     string
+
+	findObjectsFn findObjectsFn
+}
+
+// New creates an instance of Snapshot. The snapshot for the environment is
+// stored in `<dir>/<envName>`. If dir is relative, it is relative to the
+// application root.
+func New(a app.App, envName, dir string, opts ...Opt) *Snapshot {
+	if dir == "" {
+		dir = DefaultDir
+	}
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(a.Root(), dir)
+	}
+
+	s := &Snapshot{
+		app:           a,
+		envName:       envName,
+		dir:           filepath.Join(dir, envName),
+		findObjectsFn: findObjects,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func findObjects(a app.App, envName string) ([]*unstructured.Unstructured, error) {
+	p := pipeline.New(a, envName)
+	return p.Objects(nil)
+}
+
+// Dir returns the directory of the environment's snapshot.
+func (s *Snapshot) Dir() string {
+	return s.dir
+}
+
+// Update renders the environment and writes its snapshot. Snapshot files for
+// components which no longer exist are removed.
+func (s *Snapshot) Update() error {
+	rendered, err := s.render()
+	if err != nil {
+		return err
+	}
+
+	fs := s.app.Fs()
+
+	if err := fs.MkdirAll(s.dir, app.DefaultFolderPermissions); err != nil {
+		return errors.Wrap(err, "creating snapshot directory")
+	}
+
+	existing, err := s.snapshotFiles()
+	if err != nil {
+		return err
+	}
+
+	for componentName := range existing {
+		if _, ok := rendered[componentName]; ok {
+			continue
+		}
+
+		if err := fs.Remove(s.path(componentName)); err != nil {
+			return errors.Wrapf(err, "removing snapshot for component %q", componentName)
+		}
+	}
+
+	for componentName, data := range rendered {
+		if err := afero.WriteFile(fs, s.path(componentName), data, app.DefaultFilePermissions); err != nil {
+			return errors.Wrapf(err, "writing snapshot for component %q", componentName)
+		}
+	}
+
+	return nil
+}
+
+// Check renders the environment and compares it to its snapshot. It writes
+// the differences to w, and returns true if there were none.
+func (s *Snapshot) Check(w io.Writer) (bool, error) {
+	rendered, err := s.render()
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := s.snapshotFiles()
+	if err != nil {
+		return false, err
+	}
+
+	names := make(map[string]bool)
+	for name := range rendered {
+		names[name] = true
+	}
+	for name := range existing {
+		names[name] = true
+	}
+
+	var sortedNames []string
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	matches := true
+
+	for _, componentName := range sortedNames {
+		data, isRendered := rendered[componentName]
+		if !isRendered {
+			fmt.Fprintf(w, "component %q: not rendered, but has a snapshot\n", componentName)
+			matches = false
+			continue
+		}
+
+		if !existing[componentName] {
+			fmt.Fprintf(w, "component %q: rendered, but has no snapshot\n", componentName)
+			matches = false
+			continue
+		}
+
+		snapshotData, err := afero.ReadFile(s.app.Fs(), s.path(componentName))
+		if err != nil {
+			return false, errors.Wrapf(err, "reading snapshot for component %q", componentName)
+		}
+
+		ok, err := diffComponent(w, componentName, snapshotData, data)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			matches = false
+		}
+	}
+
+	return matches, nil
+}
+
+// render renders the environment's objects as YAML grouped by component.
+func (s *Snapshot) render() (map[string][]byte, error) {
+	objects, err := s.findObjectsFn(s.app, s.envName)
+	if err != nil {
+		return nil, errors.Wrap(err, "find objects")
+	}
+
+	byComponent := make(map[string][]*unstructured.Unstructured)
+	for _, obj := range objects {
+		componentName := obj.GetLabels()[metadata.LabelComponent]
+		if componentName == "" {
+			componentName = obj.GetName()
+		}
+
+		byComponent[componentName] = append(byComponent[componentName], obj)
+	}
+
+	rendered := make(map[string][]byte)
+	for componentName, componentObjects := range byComponent {
+		cluster.UnstructuredSlice(componentObjects).Sort()
+
+		var buf bytes.Buffer
+		if err := cluster.ShowYAML(&buf, componentObjects); err != nil {
+			return nil, errors.Wrapf(err, "rendering component %q", componentName)
+		}
+
+		rendered[componentName] = buf.Bytes()
+	}
+
+	return rendered, nil
+}
+
+// snapshotFiles returns the names of the components with snapshot files.
+func (s *Snapshot) snapshotFiles() (map[string]bool, error) {
+	fs := s.app.Fs()
+
+	exists, err := afero.DirExists(fs, s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "checking %q", s.dir)
+	}
+
+	names := make(map[string]bool)
+	if !exists {
+		return names, nil
+	}
+
+	fis, err := afero.ReadDir(fs, s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %q", s.dir)
+	}
+
+	for _, fi := range fis {
+		if fi.IsDir() || filepath.Ext(fi.Name()) != snapshotExt {
+			continue
+		}
+
+		names[strings.TrimSuffix(fi.Name(), snapshotExt)] = true
+	}
+
+	return names, nil
+}
+
+func (s *Snapshot) path(componentName string) string {
+	return filepath.Join(s.dir, componentName+snapshotExt)
+}
+
+// diffComponent compares a component's snapshot to its rendered objects. Objects
+// are matched by API version, kind, namespace, and name.
+func diffComponent(w io.Writer, componentName string, snapshotData, renderedData []byte) (bool, error) {
+	snapshotObjects, err := decodeObjects(snapshotData)
+	if err != nil {
+		return false, errors.Wrapf(err, "decoding snapshot for component %q", componentName)
+	}
+
+	renderedObjects, err := decodeObjects(renderedData)
+	if err != nil {
+		return false, errors.Wrapf(err, "decoding rendered component %q", componentName)
+	}
+
+	snapshotByID := make(map[string]*unstructured.Unstructured)
+	for _, obj := range snapshotObjects {
+		snapshotByID[objectID(obj)] = obj
+	}
+
+	renderedByID := make(map[string]*unstructured.Unstructured)
+	for _, obj := range renderedObjects {
+		renderedByID[objectID(obj)] = obj
+	}
+
+	var out bytes.Buffer
+
+	for _, obj := range renderedObjects {
+		id := objectID(obj)
+
+		old, ok := snapshotByID[id]
+		if !ok {
+			fmt.Fprintf(&out, "  added: %s\n", id)
+			continue
+		}
+
+		if reflect.DeepEqual(old.Object, obj.Object) {
+			continue
+		}
+
+		fmt.Fprintf(&out, "  changed: %s\n", id)
+		if err := diffObjects(&out, old, obj); err != nil {
+			return false, err
+		}
+	}
+
+	for _, obj := range snapshotObjects {
+		id := objectID(obj)
+		if _, ok := renderedByID[id]; !ok {
+			fmt.Fprintf(&out, "  removed: %s\n", id)
+		}
+	}
+
+	if out.Len() == 0 {
+		return true, nil
+	}
+
+	fmt.Fprintf(w, "component %q:\n", componentName)
+	_, err = out.WriteTo(w)
+	return false, err
+}
+
+func diffObjects(w io.Writer, old, updated *unstructured.Unstructured) error {
+	var oldBuf, updatedBuf bytes.Buffer
+	if err := cluster.ShowYAML(&oldBuf, []*unstructured.Unstructured{old}); err != nil {
+		return err
+	}
+
+	if err := cluster.ShowYAML(&updatedBuf, []*unstructured.Unstructured{updated}); err != nil {
+		return err
+	}
+
+	var diffBuf bytes.Buffer
+	err := godiff.DefaultDiffer().Diff(&diffBuf,
+		bytes.NewReader(oldBuf.Bytes()),
+		bytes.NewReader(updatedBuf.Bytes()))
+	if err != nil {
+		return errors.Wrap(err, "diffing objects")
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(diffBuf.String(), "\n"), "\n") {
+		fmt.Fprintln(w, strings.TrimRight("    "+line, " "))
+	}
+
+	return nil
+}
+
+// decodeObjects decodes a YAML stream of objects. Rendered objects are decoded
+// from YAML as well, so both sides of a comparison have the same value types.
+func decodeObjects(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		var m map[string]interface{}
+		if err := decoder.Decode(&m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		if m == nil {
+			continue
+		}
+
+		objects = append(objects, &unstructured.Unstructured{Object: m})
+	}
+
+	return objects, nil
+}
+
+// objectID identifies an object in a snapshot.
+func objectID(obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+
+	return fmt.Sprintf("%s %s %s", obj.GetAPIVersion(), obj.GetKind(), name)
+}
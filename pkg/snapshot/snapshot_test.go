@@ -0,0 +1,192 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ksonnet/ksonnet/pkg/app"
+	amocks "github.com/ksonnet/ksonnet/pkg/app/mocks"
+	"github.com/ksonnet/ksonnet/pkg/metadata"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(component, kind, name string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+				"labels": map[string]interface{}{
+					metadata.LabelComponent: component,
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+		},
+	}
+}
+
+func withSnapshot(t *testing.T, objects *[]*unstructured.Unstructured, fn func(*Snapshot, afero.Fs)) {
+	fs := afero.NewMemMapFs()
+
+	a := &amocks.App{}
+	a.On("Fs").Return(fs)
+	a.On("Root").Return("/app")
+
+	findObjectsOpt := func(s *Snapshot) {
+		s.findObjectsFn = func(_ app.App, envName string) ([]*unstructured.Unstructured, error) {
+			assert.Equal(t, "default", envName)
+			return *objects, nil
+		}
+	}
+
+	s := New(a, "default", "", findObjectsOpt)
+	fn(s, fs)
+}
+
+func TestSnapshot_Update(t *testing.T) {
+	objects := []*unstructured.Unstructured{
+		newObject("web", "Service", "web", 1),
+		newObject("web", "ReplicationController", "web", 1),
+		newObject("db", "Service", "db", 1),
+	}
+
+	withSnapshot(t, &objects, func(s *Snapshot, fs afero.Fs) {
+		assert.Equal(t, "/app/snapshots/default", s.Dir())
+
+		require.NoError(t, afero.WriteFile(fs, "/app/snapshots/default/old.yaml", []byte("---\n"), 0644))
+
+		require.NoError(t, s.Update())
+
+		web, err := afero.ReadFile(fs, "/app/snapshots/default/web.yaml")
+		require.NoError(t, err)
+
+		expected := `---
+apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    ksonnet.io/component: web
+  name: web
+  namespace: default
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: ReplicationController
+metadata:
+  labels:
+    ksonnet.io/component: web
+  name: web
+  namespace: default
+spec:
+  replicas: 1
+`
+		assert.Equal(t, expected, string(web))
+
+		exists, err := afero.Exists(fs, "/app/snapshots/default/db.yaml")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = afero.Exists(fs, "/app/snapshots/default/old.yaml")
+		require.NoError(t, err)
+		assert.False(t, exists, "expected stale snapshot to be removed")
+	})
+}
+
+func TestSnapshot_Check(t *testing.T) {
+	cases := []struct {
+		name     string
+		update   []*unstructured.Unstructured
+		expected string
+	}{
+		{
+			name: "unchanged",
+			update: []*unstructured.Unstructured{
+				newObject("web", "Service", "web", 1),
+				newObject("db", "Service", "db", 1),
+			},
+		},
+		{
+			name: "changed object",
+			update: []*unstructured.Unstructured{
+				newObject("web", "Service", "web", 2),
+				newObject("db", "Service", "db", 1),
+			},
+			expected: `component "web":
+  changed: v1 Service default/web
+    @@ -7,5 +7,5 @@
+       name: web
+       namespace: default
+     spec:
+    -  replicas: 1
+    +  replicas: 2
+
+`,
+		},
+		{
+			name: "added and removed objects",
+			update: []*unstructured.Unstructured{
+				newObject("web", "ConfigMap", "web", 1),
+				newObject("db", "Service", "db", 1),
+			},
+			expected: `component "web":
+  added: v1 ConfigMap default/web
+  removed: v1 Service default/web
+`,
+		},
+		{
+			name: "added and removed components",
+			update: []*unstructured.Unstructured{
+				newObject("web", "Service", "web", 1),
+				newObject("cache", "Service", "cache", 1),
+			},
+			expected: `component "cache": rendered, but has no snapshot
+component "db": not rendered, but has a snapshot
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := []*unstructured.Unstructured{
+				newObject("web", "Service", "web", 1),
+				newObject("db", "Service", "db", 1),
+			}
+
+			withSnapshot(t, &objects, func(s *Snapshot, fs afero.Fs) {
+				require.NoError(t, s.Update())
+
+				objects = tc.update
+
+				var buf bytes.Buffer
+				ok, err := s.Check(&buf)
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expected == "", ok)
+				assert.Equal(t, tc.expected, buf.String())
+			})
+		})
+	}
+}
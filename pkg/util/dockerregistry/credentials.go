@@ -0,0 +1,253 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package dockerregistry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// dockerHubServer is the server docker uses to store Docker Hub credentials.
+	dockerHubServer = "https://index.docker.io/v1/"
+	// tokenUsername is the username credential helpers return for identity tokens.
+	tokenUsername = "<token>"
+)
+
+// Credentials are the credentials for a registry.
+type Credentials struct {
+	Username string
+	Password string
+	// IdentityToken is a refresh token which is exchanged for bearer tokens.
+	IdentityToken string
+	// RegistryToken is a bearer token which is sent to the registry as is.
+	RegistryToken string
+}
+
+// CredentialStore looks up registry credentials.
+type CredentialStore interface {
+	// Credentials returns the credentials for a registry host. It returns nil
+	// if there are no credentials for the host.
+	Credentials(host string) (*Credentials, error)
+}
+
+// dockerAuth is an entry in the auths section of a docker config.
+type dockerAuth struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// DockerConfig is a docker client config, usually ~/.docker/config.json.
+type DockerConfig struct {
+	Auths       map[string]dockerAuth `json:"auths,omitempty"`
+	CredsStore  string                `json:"credsStore,omitempty"`
+	CredHelpers map[string]string     `json:"credHelpers,omitempty"`
+
+	// helperFn runs a credential helper.
+	helperFn func(helper, serverURL string) ([]byte, error)
+}
+
+var _ CredentialStore = (*DockerConfig)(nil)
+
+// DockerConfigPath returns the path of the docker config. It is in the
+// directory named by $DOCKER_CONFIG, or in ~/.docker.
+func DockerConfigPath() string {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir = filepath.Join(os.Getenv("HOME"), ".docker")
+	}
+
+	return filepath.Join(dir, "config.json")
+}
+
+// LoadDockerConfig loads a docker config. If the config doesn't exist, it
+// returns an empty config.
+func LoadDockerConfig(fs afero.Fs, path string) (*DockerConfig, error) {
+	config := &DockerConfig{helperFn: runCredentialHelper}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrapf(err, "decoding %s", path)
+	}
+
+	return config, nil
+}
+
+// Credentials returns the credentials for a registry host. Credential helpers
+// configured for the host, or for every host, are used before credentials
+// stored in the config.
+func (c *DockerConfig) Credentials(host string) (*Credentials, error) {
+	server := registryServer(host)
+
+	helper := c.CredHelpers[server]
+	if helper == "" {
+		helper = c.CredHelpers[host]
+	}
+	if helper == "" {
+		helper = c.CredsStore
+	}
+
+	if helper != "" {
+		return c.helperCredentials(helper, server)
+	}
+
+	for key, auth := range c.Auths {
+		if registryServer(key) != server {
+			continue
+		}
+
+		return auth.credentials()
+	}
+
+	return nil, nil
+}
+
+func (c *DockerConfig) helperCredentials(helper, server string) (*Credentials, error) {
+	serverURL := server
+	if server == registryServer(dockerHubServer) {
+		serverURL = dockerHubServer
+	}
+
+	out, err := c.helperFn(helper, serverURL)
+	if err != nil {
+		if strings.Contains(err.Error(), "credentials not found") {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "running credential helper %q for %s", helper, serverURL)
+	}
+
+	var resp struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, errors.Wrapf(err, "decoding output of credential helper %q", helper)
+	}
+
+	if resp.Username == tokenUsername {
+		return &Credentials{IdentityToken: resp.Secret}, nil
+	}
+
+	return &Credentials{Username: resp.Username, Password: resp.Secret}, nil
+}
+
+func (a dockerAuth) credentials() (*Credentials, error) {
+	creds := &Credentials{
+		Username:      a.Username,
+		Password:      a.Password,
+		IdentityToken: a.IdentityToken,
+		RegistryToken: a.RegistryToken,
+	}
+
+	if a.Auth != "" {
+		data, err := base64.StdEncoding.DecodeString(a.Auth)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding registry auth")
+		}
+
+		parts := strings.SplitN(string(data), ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("registry auth is not in the form username:password")
+		}
+
+		creds.Username, creds.Password = parts[0], parts[1]
+	}
+
+	return creds, nil
+}
+
+// registryServer normalizes a registry host or a server in a docker config,
+// e.g. https://index.docker.io/v1/, to a host.
+func registryServer(server string) string {
+	server = strings.TrimPrefix(server, "https://")
+	server = strings.TrimPrefix(server, "http://")
+	if i := strings.Index(server, "/"); i >= 0 {
+		server = server[:i]
+	}
+
+	switch server {
+	case "docker.io", "index.docker.io", defaultRegistry:
+		return "index.docker.io"
+	}
+
+	return server
+}
+
+// runCredentialHelper runs `docker-credential-<helper> get`. If the helper
+// fails, the error contains its output.
+func runCredentialHelper(helper, serverURL string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stdout.String() + stderr.String())
+		if output == "" {
+			return nil, err
+		}
+		return nil, errors.Errorf("%v: %s", err, output)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// defaultCredentials loads the docker config at DockerConfigPath the first
+// time credentials are needed.
+type defaultCredentials struct {
+	once   sync.Once
+	config *DockerConfig
+	err    error
+}
+
+// DefaultCredentials returns a credential store which uses the docker config
+// at DockerConfigPath.
+func DefaultCredentials() CredentialStore {
+	return &defaultCredentials{}
+}
+
+func (d *defaultCredentials) Credentials(host string) (*Credentials, error) {
+	d.once.Do(func() {
+		d.config, d.err = LoadDockerConfig(afero.NewOsFs(), DockerConfigPath())
+	})
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return d.config.Credentials(host)
+}
@@ -0,0 +1,161 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package dockerregistry
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDockerConfig = `{
+  "auths": {
+    "https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="},
+    "registry.example.com": {"username": "user", "password": "pass"},
+    "token.example.com": {"identitytoken": "refresh"},
+    "bearer.example.com:5000": {"registrytoken": "registry-token"}
+  },
+  "credHelpers": {
+    "gcr.io": "gcr"
+  }
+}`
+
+func TestDockerConfig_Credentials(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		host     string
+		expected *Credentials
+		isErr    bool
+	}{
+		{
+			name:     "docker hub",
+			config:   testDockerConfig,
+			host:     "registry-1.docker.io",
+			expected: &Credentials{Username: "hub", Password: "secret"},
+		},
+		{
+			name:     "username and password",
+			config:   testDockerConfig,
+			host:     "registry.example.com",
+			expected: &Credentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:     "identity token",
+			config:   testDockerConfig,
+			host:     "token.example.com",
+			expected: &Credentials{IdentityToken: "refresh"},
+		},
+		{
+			name:     "registry token",
+			config:   testDockerConfig,
+			host:     "bearer.example.com:5000",
+			expected: &Credentials{RegistryToken: "registry-token"},
+		},
+		{
+			name:     "credential helper",
+			config:   testDockerConfig,
+			host:     "gcr.io",
+			expected: &Credentials{Username: "_json_key", Password: "gcr-secret"},
+		},
+		{
+			name:   "no credentials",
+			config: testDockerConfig,
+			host:   "other.example.com",
+		},
+		{
+			name:     "credential store",
+			config:   `{"credsStore": "desktop"}`,
+			host:     "registry-1.docker.io",
+			expected: &Credentials{IdentityToken: "desktop-token"},
+		},
+		{
+			name:   "credential store without credentials",
+			config: `{"credsStore": "desktop"}`,
+			host:   "other.example.com",
+		},
+		{
+			name:   "invalid auth",
+			config: `{"auths": {"registry.example.com": {"auth": "dXNlcg=="}}}`,
+			host:   "registry.example.com",
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "/config.json", []byte(tc.config), 0600))
+
+			config, err := LoadDockerConfig(fs, "/config.json")
+			require.NoError(t, err)
+
+			config.helperFn = func(helper, serverURL string) ([]byte, error) {
+				switch {
+				case helper == "gcr" && serverURL == "gcr.io":
+					return []byte(`{"ServerURL": "gcr.io", "Username": "_json_key", "Secret": "gcr-secret"}`), nil
+				case helper == "desktop" && serverURL == dockerHubServer:
+					return []byte(`{"ServerURL": "https://index.docker.io/v1/", "Username": "<token>", "Secret": "desktop-token"}`), nil
+				}
+
+				return nil, errors.New("exit status 1: credentials not found in native keychain")
+			}
+
+			got, err := config.Credentials(tc.host)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestLoadDockerConfig_missing(t *testing.T) {
+	config, err := LoadDockerConfig(afero.NewMemMapFs(), "/config.json")
+	require.NoError(t, err)
+
+	got, err := config.Credentials("registry.example.com")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestLoadDockerConfig_invalid(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/config.json", []byte("{"), 0600))
+
+	_, err := LoadDockerConfig(fs, "/config.json")
+	require.Error(t, err)
+}
+
+func Test_registryServer(t *testing.T) {
+	cases := map[string]string{
+		"https://index.docker.io/v1/":  "index.docker.io",
+		"registry-1.docker.io":         "index.docker.io",
+		"docker.io":                    "index.docker.io",
+		"https://registry.example.com": "registry.example.com",
+		"registry.example.com:5000":    "registry.example.com:5000",
+	}
+
+	for in, expected := range cases {
+		assert.Equal(t, expected, registryServer(in), in)
+	}
+}
@@ -94,23 +94,6 @@ func (r *Registry) ManifestDigest(reponame, tag string) (string, error) {
 	return digest, nil
 }
 
-// stolen from golang 1.8
-func stripPort(hostport string) string {
-	colon := strings.IndexByte(hostport, ':')
-	if colon == -1 {
-		return hostport
-	}
-	if i := strings.IndexByte(hostport, ']'); i != -1 {
-		return strings.TrimPrefix(hostport[:i], "[")
-	}
-	return hostport[:colon]
-}
-
-func matchesDomain(url *url.URL, domain string) bool {
-	host := stripPort(url.Host)
-	return strings.HasSuffix(host, domain)
-}
-
 type authChallenge struct {
 	Scheme string
 	Params map[string]string
@@ -148,43 +131,61 @@ func parseAuthHeader(header http.Header) []*authChallenge {
 	return ret
 }
 
-// NewAuthTransport returns a roundtripper that does bearer/etc authentication
+// NewAuthTransport returns a roundtripper that does bearer/etc authentication.
+// Credentials are loaded from the docker config when a registry requires them.
 func NewAuthTransport(inner http.RoundTripper) http.RoundTripper {
+	return NewCredentialsTransport(inner, DefaultCredentials())
+}
+
+// NewCredentialsTransport returns a roundtripper that does bearer/etc
+// authentication with credentials from store. It follows the Docker registry v2
+// token authentication flow.
+func NewCredentialsTransport(inner http.RoundTripper, store CredentialStore) http.RoundTripper {
 	return &authTransport{
-		Transport:  inner,
-		Client:     &http.Client{Transport: inner},
-		tokenCache: map[string]string{},
+		Transport:   inner,
+		Client:      &http.Client{Transport: inner},
+		tokenCache:  map[string]string{},
+		credentials: store,
 	}
 }
 
 type authTransport struct {
-	Client     *http.Client
-	Transport  http.RoundTripper
-	tokenCache map[string]string
-	HostDomain string
-	Username   string
-	Password   string
+	Client      *http.Client
+	Transport   http.RoundTripper
+	tokenCache  map[string]string
+	credentials CredentialStore
 }
 
 // RoundTrip is required for the http.RoundTripper interface
 func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	creds, err := t.lookupCredentials(req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds.RegistryToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", creds.RegistryToken))
+	}
+
 	log.Debugf("=> %v", req)
 	resp, err := t.Transport.RoundTrip(req)
 	log.Debugf("<= err=%v resp=%v", err, resp)
-	if err == nil && resp.StatusCode == http.StatusUnauthorized && matchesDomain(req.URL, t.HostDomain) {
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && creds.RegistryToken == "" {
 		schemes := parseAuthHeader(resp.Header)
 		for _, scheme := range schemes {
 			if scheme.Scheme == "basic" {
 				log.Debugf("Retrying with basic auth")
-				req.SetBasicAuth(t.Username, t.Password)
+				resp.Body.Close()
+				req.SetBasicAuth(creds.Username, creds.Password)
 				log.Debugf("=> %v", req)
 				return t.Transport.RoundTrip(req)
 			}
 			if scheme.Scheme == "bearer" {
-				token, err := t.bearerAuth(scheme.Params["realm"], scheme.Params["service"], scheme.Params["scope"])
+				token, err := t.bearerAuth(scheme.Params["realm"], scheme.Params["service"], scheme.Params["scope"], creds)
 				if err != nil {
 					return resp, err
 				}
+				resp.Body.Close()
 				log.Debugf("Retrying with bearer auth")
 				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 				log.Debugf("=> %v", req)
@@ -197,31 +198,70 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return resp, err
 }
 
-func (t *authTransport) bearerAuth(realm, service, scope string) (string, error) {
-	cacheKey := fmt.Sprintf("%s!%s!%s", realm, service, scope)
-	if token := t.tokenCache[cacheKey]; token != "" {
-		return token, nil
+// lookupCredentials returns the credentials for a registry host. If there are
+// none, it returns empty credentials.
+func (t *authTransport) lookupCredentials(host string) (*Credentials, error) {
+	if t.credentials == nil {
+		return &Credentials{}, nil
 	}
 
-	url, err := url.Parse(realm)
+	creds, err := t.credentials.Credentials(host)
 	if err != nil {
-		return "", err
+		return nil, errors.Wrapf(err, "loading credentials for %s", host)
+	}
+
+	if creds == nil {
+		return &Credentials{}, nil
 	}
 
-	q := url.Query()
-	q.Set("service", service)
-	if scope != "" {
-		q.Set("scope", scope)
+	return creds, nil
+}
+
+func (t *authTransport) bearerAuth(realm, service, scope string, creds *Credentials) (string, error) {
+	cacheKey := fmt.Sprintf("%s!%s!%s!%s", realm, service, scope, creds.Username)
+	if token := t.tokenCache[cacheKey]; token != "" {
+		return token, nil
 	}
-	url.RawQuery = q.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	u, err := url.Parse(realm)
 	if err != nil {
 		return "", err
 	}
 
-	if t.Username != "" || t.Password != "" {
-		req.SetBasicAuth(t.Username, t.Password)
+	var req *http.Request
+	if creds.IdentityToken != "" {
+		// identity tokens are exchanged for bearer tokens with an OAuth2
+		// refresh token grant.
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", creds.IdentityToken)
+		form.Set("service", service)
+		form.Set("client_id", "ksonnet")
+		if scope != "" {
+			form.Set("scope", scope)
+		}
+
+		req, err = http.NewRequest(http.MethodPost, u.String(), strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		q := u.Query()
+		q.Set("service", service)
+		if scope != "" {
+			q.Set("scope", scope)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err = http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return "", err
+		}
+
+		if creds.Username != "" || creds.Password != "" {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
 	}
 
 	log.Debugf("Performing oauth request to %s", req.URL)
@@ -236,13 +276,23 @@ func (t *authTransport) bearerAuth(realm, service, scope string) (string, error)
 	}
 
 	type authToken struct {
-		Token string `json:"token"`
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
 	}
 	var token authToken
 	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
 		return "", err
 	}
-	log.Debugf("Got oauth token %q", token.Token)
-	t.tokenCache[cacheKey] = token.Token
-	return token.Token, err
+
+	value := token.Token
+	if value == "" {
+		value = token.AccessToken
+	}
+	if value == "" {
+		return "", errors.New("Auth response did not contain a token")
+	}
+
+	log.Debugf("Got oauth token")
+	t.tokenCache[cacheKey] = value
+	return value, nil
 }
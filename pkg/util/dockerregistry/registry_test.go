@@ -17,8 +17,11 @@ package dockerregistry
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,3 +56,120 @@ func Test_RegistryClient_ManifestDigest(t *testing.T) {
 
 	require.Equal(t, "sha256:abcde", digest)
 }
+
+type stubCredentialStore map[string]*Credentials
+
+func (s stubCredentialStore) Credentials(host string) (*Credentials, error) {
+	return s[host], nil
+}
+
+func Test_authTransport(t *testing.T) {
+	cases := []struct {
+		name string
+		// challenge is the WWW-Authenticate header for unauthorized requests.
+		// %s is replaced with the token service URL.
+		challenge string
+		// authorization is the Authorization header the registry accepts.
+		authorization string
+		creds         *Credentials
+		isErr         bool
+	}{
+		{
+			name:          "basic",
+			challenge:     `Basic realm="registry"`,
+			authorization: "Basic dXNlcjpwYXNz",
+			creds:         &Credentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:          "bearer with username and password",
+			challenge:     `Bearer realm="%s/token",service="registry",scope="repository:foo/bar:pull"`,
+			authorization: "Bearer basic-token",
+			creds:         &Credentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:          "bearer with identity token",
+			challenge:     `Bearer realm="%s/token",service="registry",scope="repository:foo/bar:pull"`,
+			authorization: "Bearer refreshed-token",
+			creds:         &Credentials{IdentityToken: "refresh"},
+		},
+		{
+			name:          "anonymous bearer",
+			challenge:     `Bearer realm="%s/token",service="registry"`,
+			authorization: "Bearer anonymous-token",
+		},
+		{
+			name:          "registry token",
+			challenge:     `Bearer realm="%s/token",service="registry"`,
+			authorization: "Bearer registry-token",
+			creds:         &Credentials{RegistryToken: "registry-token"},
+		},
+		{
+			name:          "invalid credentials",
+			challenge:     `Basic realm="registry"`,
+			authorization: "Basic dXNlcjpwYXNz",
+			creds:         &Credentials{Username: "user", Password: "wrong"},
+			isErr:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "registry", r.FormValue("service"))
+
+				var token string
+				switch {
+				case r.Method == http.MethodPost:
+					assert.Equal(t, "refresh_token", r.FormValue("grant_type"))
+					assert.Equal(t, "refresh", r.FormValue("refresh_token"))
+					token = `{"access_token": "refreshed-token"}`
+				case r.Header.Get("Authorization") == "Basic dXNlcjpwYXNz":
+					token = `{"token": "basic-token"}`
+				case r.Header.Get("Authorization") == "":
+					token = `{"token": "anonymous-token"}`
+				default:
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				fmt.Fprint(w, token)
+			})
+
+			mux.HandleFunc("/v2/foo/bar/manifests/1.0", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != tc.authorization {
+					challenge := tc.challenge
+					if strings.Contains(challenge, "%s") {
+						challenge = fmt.Sprintf(challenge, ts.URL)
+					}
+					w.Header().Set("WWW-Authenticate", challenge)
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				w.Header().Set("Docker-Content-Digest", "sha256:abcde")
+			})
+
+			u, err := url.Parse(ts.URL)
+			require.NoError(t, err)
+
+			store := stubCredentialStore{u.Host: tc.creds}
+			client := &http.Client{
+				Timeout:   1 * time.Second,
+				Transport: NewCredentialsTransport(http.DefaultTransport, store),
+			}
+
+			digest, err := NewRegistryClient(client, ts.URL).ManifestDigest("foo/bar", "1.0")
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "sha256:abcde", digest)
+		})
+	}
+}
@@ -32,6 +32,13 @@ type registryResolver struct {
 	cache  map[string]string
 }
 
+// NewResolver returns a resolver that uses httpClient to look up docker
+// registries. Resolved digests are cached, so a resolver can be reused to
+// resolve many images.
+func NewResolver(httpClient *http.Client) Resolver {
+	return newRegistryResolver(httpClient)
+}
+
 // newRegistryResolver returns a resolver that looks up a docker
 // registry to resolve digests
 func newRegistryResolver(httpClient *http.Client) Resolver {
@@ -97,3 +97,33 @@ func Test_defaultDigester_ManifestV2Digest(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResolver(t *testing.T) {
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "/v2/foo/bar/manifests/1.0", r.URL.Path)
+		w.Header().Set("Docker-Content-Digest", "sha256:abcde")
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	r := NewResolver(&http.Client{
+		Timeout: 1 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		n, err := ParseImageName(u.Host + "/foo/bar:1.0")
+		require.NoError(t, err)
+
+		require.NoError(t, r.Resolve(&n))
+		require.Equal(t, u.Host+"/foo/bar@sha256:abcde", n.String())
+	}
+
+	require.Equal(t, 1, requests, "expected resolved digests to be cached")
+}
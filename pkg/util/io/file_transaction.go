@@ -0,0 +1,182 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package io
+
+import (
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// FileTransaction writes and removes a set of files together. Every change is
+// applied, or none are: files are staged with TransactionWriter, and if a file
+// can't be replaced, the files which were already changed are restored.
+type FileTransaction struct {
+	fs      afero.Fs
+	writes  map[string][]byte
+	removes map[string]bool
+}
+
+// NewFileTransaction creates an instance of FileTransaction.
+func NewFileTransaction(fs afero.Fs) *FileTransaction {
+	return &FileTransaction{
+		fs:      fs,
+		writes:  make(map[string][]byte),
+		removes: make(map[string]bool),
+	}
+}
+
+// Write sets the contents of a file when the transaction is committed.
+func (ft *FileTransaction) Write(path string, data []byte) {
+	delete(ft.removes, path)
+	ft.writes[path] = data
+}
+
+// Remove removes a file when the transaction is committed.
+func (ft *FileTransaction) Remove(path string) {
+	delete(ft.writes, path)
+	ft.removes[path] = true
+}
+
+// backup is the state of a file before it was changed.
+type backup struct {
+	path    string
+	data    []byte
+	mode    os.FileMode
+	existed bool
+}
+
+// Commit applies the changes. Files are written in path order, and then files
+// are removed.
+func (ft *FileTransaction) Commit() error {
+	if ft.fs == nil {
+		return errors.Errorf("fs required")
+	}
+
+	var paths []string
+	for path := range ft.writes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var writers []*TransactionWriter
+	abort := func() {
+		for _, w := range writers {
+			_ = w.Abort()
+		}
+	}
+
+	for _, path := range paths {
+		w, err := NewTransactionWriter(ft.fs, path)
+		if err != nil {
+			abort()
+			return errors.Wrapf(err, "staging %s", path)
+		}
+		writers = append(writers, w)
+
+		if _, err := w.Write(ft.writes[path]); err != nil {
+			abort()
+			return errors.Wrapf(err, "staging %s", path)
+		}
+	}
+
+	var removes []string
+	for path := range ft.removes {
+		removes = append(removes, path)
+	}
+	sort.Strings(removes)
+
+	var backups []backup
+	for _, path := range append(append([]string{}, paths...), removes...) {
+		b, err := ft.backup(path)
+		if err != nil {
+			abort()
+			return err
+		}
+		backups = append(backups, b)
+	}
+
+	var changed []backup
+	fail := func(err error) error {
+		abort()
+		if restoreErr := ft.restore(changed); restoreErr != nil {
+			return errors.Wrapf(err, "restoring files failed (%v)", restoreErr)
+		}
+		return err
+	}
+
+	for i, w := range writers {
+		// Record the file before committing, so a partially replaced file is
+		// restored as well.
+		changed = append(changed, backups[i])
+		if err := w.Commit(); err != nil {
+			return fail(errors.Wrapf(err, "writing %s", paths[i]))
+		}
+	}
+
+	for i, path := range removes {
+		changed = append(changed, backups[len(paths)+i])
+		if err := ft.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fail(errors.Wrapf(err, "removing %s", path))
+		}
+	}
+
+	return nil
+}
+
+func (ft *FileTransaction) backup(path string) (backup, error) {
+	b := backup{path: path}
+
+	fi, err := ft.fs.Stat(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return b, errors.Wrapf(err, "checking %s", path)
+	}
+
+	data, err := afero.ReadFile(ft.fs, path)
+	if err != nil {
+		return b, errors.Wrapf(err, "reading %s", path)
+	}
+
+	b.data = data
+	b.mode = fi.Mode()
+	b.existed = true
+	return b, nil
+}
+
+// restore restores changed files to their backups, in reverse order.
+func (ft *FileTransaction) restore(changed []backup) error {
+	for i := len(changed) - 1; i >= 0; i-- {
+		b := changed[i]
+
+		if !b.existed {
+			if err := ft.fs.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "removing %s", b.path)
+			}
+			continue
+		}
+
+		if err := afero.WriteFile(ft.fs, b.path, b.data, b.mode); err != nil {
+			return errors.Wrapf(err, "restoring %s", b.path)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package io
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withTempFs(t *testing.T, fn func(fs afero.Fs, root string)) {
+	root, err := ioutil.TempDir("", "file-transaction")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	fn(afero.NewOsFs(), root)
+}
+
+func TestFileTransaction_Commit(t *testing.T) {
+	withTempFs(t, func(fs afero.Fs, root string) {
+		a := filepath.Join(root, "a.txt")
+		b := filepath.Join(root, "dir", "b.txt")
+		c := filepath.Join(root, "c.txt")
+
+		require.NoError(t, afero.WriteFile(fs, a, []byte("old"), 0644))
+		require.NoError(t, afero.WriteFile(fs, c, []byte("remove"), 0644))
+
+		ft := NewFileTransaction(fs)
+		ft.Write(a, []byte("new"))
+		ft.Write(b, []byte("created"))
+		ft.Remove(c)
+
+		require.NoError(t, ft.Commit())
+
+		data, err := afero.ReadFile(fs, a)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+
+		data, err = afero.ReadFile(fs, b)
+		require.NoError(t, err)
+		assert.Equal(t, "created", string(data))
+
+		exists, err := afero.Exists(fs, c)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestFileTransaction_Commit_permissions(t *testing.T) {
+	withTempFs(t, func(fs afero.Fs, root string) {
+		existing := filepath.Join(root, "existing.txt")
+		created := filepath.Join(root, "created.txt")
+
+		require.NoError(t, afero.WriteFile(fs, existing, []byte("old"), 0640))
+		require.NoError(t, fs.Chmod(existing, 0640))
+
+		ft := NewFileTransaction(fs)
+		ft.Write(existing, []byte("new"))
+		ft.Write(created, []byte("created"))
+
+		require.NoError(t, ft.Commit())
+
+		fi, err := fs.Stat(existing)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), fi.Mode().Perm())
+
+		fi, err = fs.Stat(created)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+	})
+}
+
+func TestFileTransaction_Commit_restores_files(t *testing.T) {
+	withTempFs(t, func(fs afero.Fs, root string) {
+		a := filepath.Join(root, "a.txt")
+		b := filepath.Join(root, "b.txt")
+		c := filepath.Join(root, "c.txt")
+		// The parent of d is a file, so d can't be written.
+		d := filepath.Join(c, "d.txt")
+
+		require.NoError(t, afero.WriteFile(fs, a, []byte("old"), 0644))
+		require.NoError(t, afero.WriteFile(fs, c, []byte("file"), 0644))
+
+		ft := NewFileTransaction(fs)
+		ft.Write(a, []byte("new"))
+		ft.Write(b, []byte("created"))
+		ft.Write(d, []byte("unwritable"))
+
+		require.Error(t, ft.Commit())
+
+		data, err := afero.ReadFile(fs, a)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(data), "file was not restored")
+
+		exists, err := afero.Exists(fs, b)
+		require.NoError(t, err)
+		assert.False(t, exists, "created file was not removed")
+
+		files, err := afero.ReadDir(fs, root)
+		require.NoError(t, err)
+		var names []string
+		for _, fi := range files {
+			names = append(names, fi.Name())
+		}
+		assert.Equal(t, []string{"a.txt", "c.txt"}, names, "staged files were not removed")
+	})
+}
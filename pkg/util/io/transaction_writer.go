@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
@@ -42,7 +43,15 @@ func NewTransactionWriter(fs afero.Fs, path string) (*TransactionWriter, error)
 	if fs == nil {
 		return nil, errors.Errorf("fs required")
 	}
-	tmp, err := afero.TempFile(fs, "", "kstemp-")
+
+	// stage the temporary file next to the target when possible, so Commit()
+	// renames it within a single filesystem.
+	dir := filepath.Dir(path)
+	if ok, err := afero.DirExists(fs, dir); err != nil || !ok {
+		dir = ""
+	}
+
+	tmp, err := afero.TempFile(fs, dir, "kstemp-")
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +109,18 @@ func (tw *TransactionWriter) Commit() error {
 		return errors.Wrapf(err, "failed creating target directory: %v", targetDir)
 	}
 
+	// Temporary files are only readable by their owner. Give the staged file
+	// the mode of the file it replaces, so committing doesn't change it.
+	mode := app.DefaultFilePermissions
+	if fi, err := tw.fs.Stat(tw.targetPath); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	if err := tw.fs.Chmod(tw.tmpPath, mode); err != nil {
+		_ = tw.Abort()
+		return errors.Wrapf(err, "failed setting permissions of %v", tw.targetPath)
+	}
+
 	// Move temp file to correct location
 	if err := tw.fs.Rename(tw.tmpPath, tw.targetPath); err != nil {
 		_ = tw.Abort()
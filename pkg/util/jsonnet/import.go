@@ -133,8 +133,10 @@ func (ai *AferoImporter) tryPath(dir, importedPath string) (found bool, content
 	return true, content, absPath, err
 }
 
-// Import imports a file.
-func (ai *AferoImporter) Import(dir, importedPath string) (contents jsonnet.Contents, foundHere string, err error) {
+// Import imports a file. Relative imports are resolved from the directory of
+// the importing file first.
+func (ai *AferoImporter) Import(importedFrom, importedPath string) (contents jsonnet.Contents, foundHere string, err error) {
+	dir, _ := path.Split(importedFrom)
 	found, content, foundHere, err := ai.tryPath(dir, importedPath)
 	if err != nil {
 		return jsonnet.MakeContents(""), "", err
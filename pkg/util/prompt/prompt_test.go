@@ -0,0 +1,53 @@
+// Copyright 2018 The ksonnet authors
+//
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected bool
+	}{
+		{name: "yes", in: "y\n", expected: true},
+		{name: "yes in full", in: "Yes\n", expected: true},
+		{name: "yes without newline", in: "yes", expected: true},
+		{name: "no", in: "n\n"},
+		{name: "empty", in: "\n"},
+		{name: "end of input"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			ok, err := Confirm(bufio.NewReader(strings.NewReader(tc.in)), &out, "Continue?")
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, ok)
+			assert.Equal(t, "Continue? [y/N]: ", out.String())
+		})
+	}
+}
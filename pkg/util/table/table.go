@@ -16,17 +16,26 @@
 package table
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"text/template"
 
+	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 const (
 	// sepChar is the character used to separate the header from the content in a table.
 	sepChar = "="
+
+	// templatePrefix and jsonPathPrefix introduce the template in a
+	// go-template or jsonpath format name.
+	templatePrefix = "go-template="
+	jsonPathPrefix = "jsonpath="
 )
 
 // Format is the output format.
@@ -37,23 +46,57 @@ const (
 	FormatTable Format = iota
 	// FormatJSON prints JSON.
 	FormatJSON
+	// FormatYAML prints YAML.
+	FormatYAML
+	// FormatTemplate prints the output of a Go template.
+	FormatTemplate
+	// FormatJSONPath prints the output of a JSONPath template.
+	FormatJSONPath
 )
 
+// Formats describes the format names accepted by DetectFormat.
+const Formats = "table|json|yaml|go-template=...|jsonpath=..."
+
 // DefaultFormat is the default format for output. It is a table.
 const DefaultFormat = FormatTable
 
-// DetectFormat detects a format from a string.
+// DetectFormat detects a format from a string. Go template and JSONPath
+// formats are named with their template, e.g. "jsonpath={.data[*].name}".
 func DetectFormat(formatName string) (Format, error) {
+	f, _, err := parseFormat(formatName)
+	return f, err
+}
+
+// parseFormat detects a format from a string, and returns the template given
+// with go-template and jsonpath formats.
+func parseFormat(formatName string) (Format, string, error) {
+	switch {
+	case strings.HasPrefix(formatName, templatePrefix):
+		return templateFormat(FormatTemplate, strings.TrimPrefix(formatName, templatePrefix))
+	case strings.HasPrefix(formatName, jsonPathPrefix):
+		return templateFormat(FormatJSONPath, strings.TrimPrefix(formatName, jsonPathPrefix))
+	}
+
 	switch formatName {
 	case "json":
-		return FormatJSON, nil
+		return FormatJSON, "", nil
+	case "yaml":
+		return FormatYAML, "", nil
 	case "", "table":
-		return FormatTable, nil
+		return FormatTable, "", nil
 	default:
-		return Format(-1), errors.Errorf("unknown output format %q", formatName)
+		return Format(-1), "", errors.Errorf("unknown output format %q", formatName)
 	}
 }
 
+func templateFormat(f Format, tmpl string) (Format, string, error) {
+	if tmpl == "" {
+		return Format(-1), "", errors.New("output format requires a template")
+	}
+
+	return f, tmpl, nil
+}
+
 // Table creates an output table. Use the New constructor to ensure
 // defaults are set properly.
 type Table struct {
@@ -63,8 +106,9 @@ type Table struct {
 
 	printf func(w io.Writer, format string, a ...interface{}) (int, error)
 
-	header []string
-	rows   [][]string
+	header   []string
+	rows     [][]string
+	template string
 }
 
 // New creates an instance of table.
@@ -87,6 +131,19 @@ func (t *Table) SetFormat(f Format) {
 	t.Format = f
 }
 
+// SetOutput sets the output format from a format name, including the
+// template given with go-template and jsonpath formats.
+func (t *Table) SetOutput(formatName string) error {
+	f, tmpl, err := parseFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	t.Format = f
+	t.template = tmpl
+	return nil
+}
+
 // Append appends a row to the table.
 func (t *Table) Append(row []string) {
 	t.rows = append(t.rows, row)
@@ -108,18 +165,20 @@ func (t *Table) Render() error {
 		return t.renderTable()
 	case FormatTable:
 		return t.renderTable()
-	case FormatJSON:
-		return t.renderJSON()
+	case FormatJSON, FormatYAML, FormatTemplate, FormatJSONPath:
+		return t.renderStructured()
 	}
 }
 
-// jsonOutput is the structure for printing JSON output.
-type jsonOutput struct {
-	Kind string              `json:"kind"`
-	Data []map[string]string `json:"data"`
+// structuredOutput is the structure printed by every format other than
+// table. Data is a list of rows keyed by column name for tables, and an
+// object for the values rendered with RenderObject.
+type structuredOutput struct {
+	Kind string      `json:"kind"`
+	Data interface{} `json:"data"`
 }
 
-func (t *Table) renderJSON() error {
+func (t *Table) renderStructured() error {
 	if len(t.header) == 0 {
 		return errors.New("headers aren't defined for output")
 	}
@@ -138,15 +197,98 @@ func (t *Table) renderJSON() error {
 		out = append(out, m)
 	}
 
-	encoder := json.NewEncoder(t.w)
-	encoder.SetIndent("", "\t")
-
-	jo := jsonOutput{
+	so := structuredOutput{
 		Kind: t.Name,
 		Data: out,
 	}
 
-	return encoder.Encode(&jo)
+	return encode(t.w, t.Format, t.template, &so)
+}
+
+// RenderObject writes an object to w in the format named by formatName.
+// Objects don't have a tabular form, so text is called to write them in the
+// table format. In other formats, the object is wrapped with kind in the same
+// way as table rows.
+func RenderObject(w io.Writer, kind, formatName string, v interface{}, text func(io.Writer) error) error {
+	f, tmpl, err := parseFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	if f == FormatTable {
+		return text(w)
+	}
+
+	so := structuredOutput{
+		Kind: kind,
+		Data: v,
+	}
+
+	return encode(w, f, tmpl, &so)
+}
+
+// encode writes v to w in a structured format. Templates are executed
+// against the JSON form of v, so fields are named the same in all formats.
+func encode(w io.Writer, f Format, tmpl string, v interface{}) error {
+	if f == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		return encoder.Encode(v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshaling output")
+	}
+
+	switch f {
+	case FormatYAML:
+		y, err := yaml.JSONToYAML(b)
+		if err != nil {
+			return errors.Wrap(err, "converting output to YAML")
+		}
+
+		_, err = w.Write(y)
+		return err
+	case FormatTemplate:
+		data, err := decodeJSON(b)
+		if err != nil {
+			return err
+		}
+
+		t, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return errors.Wrap(err, "parsing go-template")
+		}
+
+		return t.Execute(w, data)
+	case FormatJSONPath:
+		data, err := decodeJSON(b)
+		if err != nil {
+			return err
+		}
+
+		jp := jsonpath.New("output")
+		if err := jp.Parse(tmpl); err != nil {
+			return errors.Wrap(err, "parsing jsonpath")
+		}
+
+		return jp.Execute(w, data)
+	default:
+		return errors.Errorf("format %d is not a structured format", f)
+	}
+}
+
+func decodeJSON(b []byte) (interface{}, error) {
+	var data interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, errors.Wrap(err, "decoding output")
+	}
+
+	return data, nil
 }
 
 func (t *Table) renderTable() error {
@@ -49,6 +49,31 @@ func TestDetectFormat(t *testing.T) {
 			formatName: "",
 			expected:   FormatTable,
 		},
+		{
+			name:       "yaml",
+			formatName: "yaml",
+			expected:   FormatYAML,
+		},
+		{
+			name:       "go-template",
+			formatName: "go-template={{.kind}}",
+			expected:   FormatTemplate,
+		},
+		{
+			name:       "jsonpath",
+			formatName: "jsonpath={.kind}",
+			expected:   FormatJSONPath,
+		},
+		{
+			name:       "go-template without a template returns an error",
+			formatName: "go-template=",
+			isErr:      true,
+		},
+		{
+			name:       "jsonpath without a template returns an error",
+			formatName: "jsonpath=",
+			isErr:      true,
+		},
 		{
 			name:       "unknown format returns an error",
 			formatName: "unknown",
@@ -134,6 +159,146 @@ func TestTable(t *testing.T) {
 
 }
 
+func TestTable_SetOutput(t *testing.T) {
+	cases := []struct {
+		name       string
+		formatName string
+		output     string
+		expected   string
+		isErr      bool
+	}{
+		{
+			name:       "yaml",
+			formatName: "yaml",
+			output:     "output.yaml",
+		},
+		{
+			name:       "go-template",
+			formatName: `go-template={{range .data}}{{.name}} {{.SERVER}}{{"\n"}}{{end}}`,
+			expected:   "default http://default\ndev http://dev\neast/prod http://east-prod\n",
+		},
+		{
+			name:       "jsonpath",
+			formatName: "jsonpath={.data[*].name}",
+			expected:   "default dev east/prod",
+		},
+		{
+			name:       "invalid go-template",
+			formatName: "go-template={{.data",
+			isErr:      true,
+		},
+		{
+			name:       "invalid jsonpath",
+			formatName: "jsonpath={.data[",
+			isErr:      true,
+		},
+		{
+			name:       "unknown format",
+			formatName: "unknown",
+			isErr:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			table := New("test", &buf)
+
+			table.SetHeader([]string{"name", "version", "Namespace", "SERVER"})
+			table.AppendBulk([][]string{
+				{"default", "v1.7.0", "default", "http://default"},
+				{"dev", "v1.8.0", "dev", "http://dev"},
+				{"east/prod", "v1.8.0", "east/prod", "http://east-prod"},
+			})
+
+			err := table.SetOutput(tc.formatName)
+			if err == nil {
+				err = table.Render()
+			}
+
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := tc.expected
+			if tc.output != "" {
+				expected = test.ReadTestData(t, tc.output)
+			}
+			assert.Equal(t, expected, buf.String())
+		})
+	}
+}
+
+func TestRenderObject(t *testing.T) {
+	obj := struct {
+		Name     string   `json:"name"`
+		Replicas int      `json:"replicas"`
+		Tags     []string `json:"tags"`
+	}{
+		Name:     "web",
+		Replicas: 3,
+		Tags:     []string{"a", "b"},
+	}
+
+	text := func(w io.Writer) error {
+		_, err := io.WriteString(w, "web has 3 replicas\n")
+		return err
+	}
+
+	cases := []struct {
+		name       string
+		formatName string
+		expected   string
+		isErr      bool
+	}{
+		{
+			name:     "text",
+			expected: "web has 3 replicas\n",
+		},
+		{
+			name:       "json",
+			formatName: "json",
+			expected:   "{\n\t\"kind\": \"test\",\n\t\"data\": {\n\t\t\"name\": \"web\",\n\t\t\"replicas\": 3,\n\t\t\"tags\": [\n\t\t\t\"a\",\n\t\t\t\"b\"\n\t\t]\n\t}\n}\n",
+		},
+		{
+			name:       "yaml",
+			formatName: "yaml",
+			expected:   "data:\n  name: web\n  replicas: 3\n  tags:\n  - a\n  - b\nkind: test\n",
+		},
+		{
+			name:       "go-template",
+			formatName: "go-template={{.data.name}}={{.data.replicas}}",
+			expected:   "web=3",
+		},
+		{
+			name:       "jsonpath",
+			formatName: "jsonpath={.data.tags[1]}",
+			expected:   "b",
+		},
+		{
+			name:       "unknown format",
+			formatName: "unknown",
+			isErr:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := RenderObject(&buf, "test", tc.formatName, obj, text)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
 func TestTable_no_header(t *testing.T) {
 	cases := []struct {
 		name   string